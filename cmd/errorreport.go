@@ -0,0 +1,113 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/types/report"
+	"github.com/sirupsen/logrus"
+)
+
+// errorReportHelp is added to the help of the commands that write an error report
+var errorReportHelp = fmt.Sprintf(`
+
+If errors occur they are written to %s along with the transformers and services they affect.
+Exit codes: %d success, %d aborted, and when the command completes despite errors:
+%d path mapping failure, %d transform failure, %d transformer init failure, %d service detect failure, %d QA validation failure.
+If there are errors of several kinds the exit code of the first kind in this list is used.`,
+	report.ErrorReportFileName, report.ExitCodeSuccess, report.ExitCodeFatal,
+	report.ExitCodePathMappingFailure, report.ExitCodeTransformFailure, report.ExitCodeInitFailure, report.ExitCodeDetectFailure, report.ExitCodeQAValidationFailure,
+)
+
+var (
+	errorReportCommand string
+	// errorReportDir is the directory the error report is written to, nothing is written until it is set
+	errorReportDir  string
+	errorReportOnce sync.Once
+	// exitCode is the exit code for the errors of the last command, it is set when the command finishes
+	exitCode = report.ExitCodeSuccess
+)
+
+// fatalErrorHook records the error that aborts the command in the error report
+type fatalErrorHook struct{}
+
+func (*fatalErrorHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.FatalLevel}
+}
+
+func (*fatalErrorHook) Fire(entry *logrus.Entry) error {
+	report.AddError(report.FatalFailure, errors.New(entry.Message), report.RunError{})
+	return nil
+}
+
+// startErrorReport starts collecting the errors of the command. Fatal errors write the error report before exiting.
+func startErrorReport(command string) {
+	report.ResetErrors()
+	errorReportCommand = command
+	errorReportDir = ""
+	exitCode = report.ExitCodeSuccess
+	errorReportOnce.Do(func() {
+		logrus.AddHook(&fatalErrorHook{})
+		logrus.StandardLogger().ExitFunc = func(code int) {
+			if exitCode := writeErrorReport(); exitCode != report.ExitCodeSuccess {
+				code = exitCode
+			}
+			os.Exit(code)
+		}
+	})
+}
+
+// writeErrorReport writes the errors collected so far to the error report, if there are any, and returns the exit code for them
+func writeErrorReport() int {
+	errorReport := report.NewErrorReport(errorReportCommand)
+	if errorReportDir == "" || len(errorReport.Errors) == 0 {
+		return errorReport.ExitCode
+	}
+	errorReportPath := filepath.Join(errorReportDir, report.ErrorReportFileName)
+	errorReportBytes, err := json.MarshalIndent(errorReport, "", "    ")
+	if err != nil {
+		logrus.Errorf("failed to marshal the error report to json. Error: %q", err)
+		return errorReport.ExitCode
+	}
+	if err := os.WriteFile(errorReportPath, errorReportBytes, common.DefaultFilePermission); err != nil {
+		logrus.Errorf("failed to write the error report to a file at path %s . Error: %q", errorReportPath, err)
+		return errorReport.ExitCode
+	}
+	logrus.Warnf("%d errors occurred. The error report can be found at [%s].", len(errorReport.Errors), errorReportPath)
+	return errorReport.ExitCode
+}
+
+// finishErrorReport writes the error report and records the exit code of the most severe error, if there were any.
+// It is deferred by the command handlers. The process exits with the code after main has cleaned up.
+func finishErrorReport() {
+	if r := recover(); r != nil {
+		panic(r)
+	}
+	exitCode = writeErrorReport()
+}
+
+// GetExitCode returns the exit code for the errors of the last command that was run
+func GetExitCode() int {
+	return exitCode
+}
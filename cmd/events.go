@@ -0,0 +1,119 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	graphutils "github.com/konveyor/move2kube-wasm/graph"
+	"github.com/konveyor/move2kube-wasm/types/event"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// eventsStdout is the value of the events flag that writes the events to the standard output
+	eventsStdout = "-"
+	// eventsFdPrefix is the prefix of the value of the events flag that writes the events to an open file descriptor
+	eventsFdPrefix = "fd:"
+)
+
+type eventsFlags struct {
+	// eventsPath is the file, file descriptor or standard output where the events are written as newline-delimited json
+	eventsPath string
+	// eventsPort is the port of the server that streams the events as server-sent events
+	eventsPort int32
+}
+
+// eventsPathHelp is the help of the events flag
+const eventsPathHelp = "Write the progress events (plan started, directory scanned, service detected, transformer started/finished, iteration done, question asked) " +
+	"as newline-delimited json to this file. Use '" + eventsStdout + "' for the standard output and '" + eventsFdPrefix + "N' for an open file descriptor N."
+
+// eventsPortHelp is the help of the events port flag
+const eventsPortHelp = "Start a server on this port that streams the progress events as server-sent events on /events and serves the graph built so far on /graph.json. " +
+	"By default the server is not started."
+
+// openEventsWriter opens the file, file descriptor or standard output the events are written to
+func openEventsWriter(eventsPath string) (io.WriteCloser, error) {
+	if eventsPath == eventsStdout {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	if strings.HasPrefix(eventsPath, eventsFdPrefix) {
+		fd, err := strconv.ParseUint(strings.TrimPrefix(eventsPath, eventsFdPrefix), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("the file descriptor in '%s' is not valid. Error: %w", eventsPath, err)
+		}
+		f := os.NewFile(uintptr(fd), eventsPath)
+		if f == nil {
+			return nil, fmt.Errorf("the file descriptor in '%s' is not valid", eventsPath)
+		}
+		return f, nil
+	}
+	f, err := os.Create(eventsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the events file at path %s . Error: %w", eventsPath, err)
+	}
+	return f, nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// startEventStreams starts writing the events to the file and the server given in the flags.
+// The returned function stops the streams.
+func startEventStreams(flags eventsFlags) func() {
+	stops := []func(){}
+	if flags.eventsPath != "" {
+		w, err := openEventsWriter(flags.eventsPath)
+		if err != nil {
+			logrus.Fatalf("failed to open the events stream. Error: %q", err)
+		}
+		enc := json.NewEncoder(w)
+		removeListener := event.AddListener(func(e event.Event) {
+			if err := enc.Encode(e); err != nil {
+				logrus.Debugf("failed to write the event %s to %s . Error: %q", e.Type, flags.eventsPath, err)
+			}
+		})
+		stops = append(stops, func() {
+			removeListener()
+			if err := w.Close(); err != nil {
+				logrus.Errorf("failed to close the events stream %s . Error: %q", flags.eventsPath, err)
+			}
+		})
+	}
+	if flags.eventsPort != 0 {
+		stopServer, err := graphutils.StartLiveServer(flags.eventsPort)
+		if err != nil {
+			logrus.Fatalf("failed to start the events server. Error: %q", err)
+		}
+		stops = append(stops, stopServer)
+	}
+	return func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+}
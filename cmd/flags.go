@@ -37,6 +37,34 @@ const (
 	qaportFlag              = "qa-port"
 	planProgressPortFlag    = "plan-progress-port"
 	transformerSelectorFlag = "transformer-selector"
+	// dryRunFlag is the name of the flag that runs the transformation without writing to the output directory
+	dryRunFlag = "dry-run"
+	// dryRunOutputFlag is the name of the flag that contains the path where the dry run report should be written
+	dryRunOutputFlag = "dry-run-output"
+	// preserveEditsFlag is the name of the flag that merges the manual edits made to the output directory into the new output
+	preserveEditsFlag = "preserve-edits"
+	// resumeFlag is the name of the flag that continues the transformation from the checkpoint of a previous run
+	resumeFlag = "resume"
+	// formatFlag is the name of the flag that contains the output format
+	formatFlag = "format"
+	// classFlag is the name of the flag that contains the class of the transformer to scaffold
+	classFlag = "class"
+	// consumesFlag is the name of the flag that contains the artifact types consumed by the transformer to scaffold
+	consumesFlag = "consumes"
+	// producesFlag is the name of the flag that contains the artifact types produced by the transformer to scaffold
+	producesFlag = "produces"
+	// planEditMergeFlag is the name of the flag that contains the services to merge
+	planEditMergeFlag = "merge"
+	// planEditSplitFlag is the name of the flag that contains the services to split
+	planEditSplitFlag = "split"
+	// planEditRenameFlag is the name of the flag that contains the services to rename
+	planEditRenameFlag = "rename"
+	// eventsFlag is the name of the flag that contains the path where the progress events are written
+	eventsFlag = "events"
+	// eventsPortFlag is the name of the flag that contains the port of the server that streams the progress events
+	eventsPortFlag = "events-port"
+	// outputArchiveFlag is the name of the flag that contains the path of the archive the output is written to
+	outputArchiveFlag = "output-archive"
 )
 
 type qaflags struct {
@@ -18,8 +18,12 @@ package cmd
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/konveyor/move2kube-wasm/common"
 	graphutils "github.com/konveyor/move2kube-wasm/graph"
@@ -34,33 +38,195 @@ type graphFlags struct {
 	graphFilePath string
 	port          int32
 	outputPath    string
+	format        string
+	summary       bool
 }
 
-func graphHandler(flags graphFlags) {
-	graphFilePath := filepath.Clean(flags.graphFilePath)
-	outputPath := filepath.Clean(flags.outputPath)
+// numSlowestTransformerRuns is the number of transformer runs shown in the summary
+const numSlowestTransformerRuns = 10
+
+// textFormat is the human readable output format of the graph diff command
+const textFormat = "text"
+
+type graphDiffFlags struct {
+	format     string
+	serve      bool
+	port       int32
+	outputPath string
+}
+
+func readGraphFile(graphFilePath string) graphtypes.Graph {
+	graphFilePath = filepath.Clean(graphFilePath)
 	graphFile, err := os.Open(graphFilePath)
 	if err != nil {
 		logrus.Fatalf("failed to the open the graph file at path %s . Error: %q", graphFilePath, err)
 	}
+	defer graphFile.Close()
 	graph := graphtypes.Graph{}
 	if err := json.NewDecoder(graphFile).Decode(&graph); err != nil {
 		logrus.Fatalf("failed to decode the json file at path %s . Error: %q", graphFilePath, err)
 	}
+	return graph
+}
+
+// writeWebGraph writes the nodes and edges used by the web UI to a json file
+func writeWebGraph(webGraph graphtypes.GraphT, outputPath string) {
+	outputPath = filepath.Clean(outputPath)
+	webBytes, err := json.Marshal(webGraph)
+	if err != nil {
+		logrus.Fatalf("failed to marshal the processed graph to json. Error: %q", err)
+	}
+	if err := os.WriteFile(outputPath, webBytes, common.DefaultFilePermission); err != nil {
+		logrus.Fatalf("failed to write the processed graph json to a file at path %s . Error: %q", outputPath, err)
+	}
+}
+
+func graphHandler(flags graphFlags) {
+	outputPath := filepath.Clean(flags.outputPath)
+	graph := readGraphFile(flags.graphFilePath)
+	if flags.summary {
+		printGraphSummary(graph)
+		return
+	}
+	if flags.format != "" {
+		exportedBytes, err := graphutils.Export(graph, flags.format)
+		if err != nil {
+			logrus.Fatalf("failed to export the graph. Error: %q", err)
+		}
+		if flags.outputPath == "" {
+			fmt.Print(string(exportedBytes))
+			return
+		}
+		if err := os.WriteFile(outputPath, exportedBytes, common.DefaultFilePermission); err != nil {
+			logrus.Fatalf("failed to write the exported graph to a file at path %s . Error: %q", outputPath, err)
+		}
+		return
+	}
 	nodes, edges := graphutils.GetNodesAndEdges(graph)
-	graphutils.BfsUpdatePositions(nodes, edges)
+	graphutils.LayeredUpdatePositions(nodes, edges)
 	webGraph := graphtypes.GraphT{Nodes: nodes, Edges: edges}
 	if flags.outputPath != "" {
-		webBytes, err := json.Marshal(webGraph)
-		if err != nil {
-			logrus.Fatalf("failed to marshal the processed graph to json. Error: %q", err)
+		writeWebGraph(webGraph, flags.outputPath)
+		return
+	}
+	logrus.Fatalf("graph server stopped. Error: %q", graphutils.StartServer(webGraph, flags.port))
+}
+
+func graphDiffHandler(flags graphDiffFlags, oldGraphFilePath, newGraphFilePath string) {
+	oldGraph := readGraphFile(oldGraphFilePath)
+	newGraph := readGraphFile(newGraphFilePath)
+	diff := graphutils.DiffGraphs(oldGraph, newGraph)
+	switch flags.format {
+	case jsonFormat:
+		printJSON(getGraphDifferences(diff))
+	case textFormat:
+		printGraphDiff(diff)
+	default:
+		logrus.Fatalf("the format '%s' is not supported. Supported formats: %s, %s", flags.format, textFormat, jsonFormat)
+	}
+	if flags.outputPath == "" && !flags.serve {
+		return
+	}
+	nodes, edges := graphutils.GetDiffNodesAndEdges(oldGraph, newGraph, diff)
+	graphutils.LayeredUpdatePositions(nodes, edges)
+	webGraph := graphtypes.GraphT{Nodes: nodes, Edges: edges}
+	if flags.outputPath != "" {
+		writeWebGraph(webGraph, flags.outputPath)
+	}
+	if flags.serve {
+		logrus.Fatalf("graph server stopped. Error: %q", graphutils.StartServer(webGraph, flags.port))
+	}
+}
+
+// getGraphDifferences returns the diff without the vertices and edges that did not change
+func getGraphDifferences(diff graphtypes.GraphDiff) graphtypes.GraphDiff {
+	differences := graphtypes.GraphDiff{Vertices: []graphtypes.VertexDiff{}, Edges: []graphtypes.EdgeDiff{}}
+	for _, vertexDiff := range diff.Vertices {
+		if vertexDiff.Status != graphtypes.DiffUnchanged {
+			differences.Vertices = append(differences.Vertices, vertexDiff)
 		}
-		if err := os.WriteFile(outputPath, webBytes, common.DefaultFilePermission); err != nil {
-			logrus.Fatalf("failed to write the processed graph json to a file at path %s . Error: %q", outputPath, err)
+	}
+	for _, edgeDiff := range diff.Edges {
+		if edgeDiff.Status != graphtypes.DiffUnchanged {
+			differences.Edges = append(differences.Edges, edgeDiff)
 		}
+	}
+	return differences
+}
+
+var diffStatusPrefixes = map[graphtypes.DiffStatus]string{
+	graphtypes.DiffAdded:   "+",
+	graphtypes.DiffRemoved: "-",
+	graphtypes.DiffChanged: "~",
+}
+
+// printGraphDiff prints the transformers and artifacts that were added, removed or changed
+func printGraphDiff(diff graphtypes.GraphDiff) {
+	differences := getGraphDifferences(diff)
+	if len(differences.Vertices) == 0 && len(differences.Edges) == 0 {
+		fmt.Println("The graphs are the same.")
 		return
 	}
-	logrus.Fatalf("graph server stopped. Error: %q", graphutils.StartServer(webGraph, flags.port))
+	counts := map[graphtypes.DiffStatus]int{}
+	if len(differences.Vertices) != 0 {
+		fmt.Println("Transformers:")
+		for _, vertexDiff := range differences.Vertices {
+			counts[vertexDiff.Status]++
+			fmt.Printf("%s %s\n", diffStatusPrefixes[vertexDiff.Status], vertexDiff.Key)
+			for _, change := range vertexDiff.Changes {
+				fmt.Printf("    %s:\n      before: %s\n      after:  %s\n", change.Field, orDash(change.Before), orDash(change.After))
+			}
+		}
+	}
+	if len(differences.Edges) != 0 {
+		fmt.Println("Artifacts:")
+		for _, edgeDiff := range differences.Edges {
+			fmt.Printf("%s %s : %s -> %s", diffStatusPrefixes[edgeDiff.Status], edgeDiff.Artifact, edgeDiff.From, edgeDiff.To)
+			if edgeDiff.PreviousFrom != "" {
+				fmt.Printf(" (previously from %s)", edgeDiff.PreviousFrom)
+			}
+			fmt.Println()
+		}
+	}
+	fmt.Printf("\nTransformers: %d added, %d removed, %d changed\n", counts[graphtypes.DiffAdded], counts[graphtypes.DiffRemoved], counts[graphtypes.DiffChanged])
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// printGraphSummary prints the slowest and the failed transformer runs in the graph
+func printGraphSummary(graph graphtypes.Graph) {
+	runs := graphutils.GetTransformerRuns(graph)
+	failed := graphutils.GetFailedTransformerRuns(runs)
+	var total time.Duration
+	for _, run := range runs {
+		total += run.Duration
+	}
+	fmt.Printf("Transformer runs: %d, failed: %d, time spent in transformers: %s\n", len(runs), len(failed), total)
+	if len(runs) == 0 {
+		return
+	}
+	fmt.Println("\nSlowest transformers:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TRANSFORMER\tITERATION\tDURATION\tENVIRONMENT\tQA PROBLEMS")
+	for _, run := range graphutils.GetSlowestTransformerRuns(runs, numSlowestTransformerRuns) {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", run.Transformer, run.Iteration, run.Duration, orDash(run.EnvironmentType), joinOrDash(run.QAProblemIds))
+	}
+	w.Flush()
+	if len(failed) == 0 {
+		return
+	}
+	fmt.Println("\nFailed transformers:")
+	w = tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TRANSFORMER\tITERATION\tERROR")
+	for _, run := range failed {
+		fmt.Fprintf(w, "%s\t%d\t%s\n", run.Transformer, run.Iteration, run.Error)
+	}
+	w.Flush()
 }
 
 // GetGraphCommand returns a command to show the graph of all the transformers that were run
@@ -68,15 +234,39 @@ func GetGraphCommand() *cobra.Command {
 	viper.AutomaticEnv()
 	flags := graphFlags{}
 	graphCmd := &cobra.Command{
-		Use:   "graph [-g path/to/m2k-graph.json]",
+		Use:   "graph [-f path/to/m2k-graph.json]",
 		Short: "View the graph generated by transform command.",
 		Long: `View the graph generated by transform command. This command starts a server to serve a web UI and display the graph.
 	To see the graph, go to http://localhost:8080/ in a browser.
-	By default, it will look for the m2k-graph.json file in the current working directory.`,
+	By default, it will look for the m2k-graph.json file in the current working directory.
+	Use --format to print the graph as Graphviz DOT, a Mermaid flowchart or json instead of starting the server, for example:
+	move2kube graph --format dot | dot -Tsvg > m2k-graph.svg`,
 		Run: func(_ *cobra.Command, __ []string) { graphHandler(flags) },
 	}
 	graphCmd.Flags().StringVarP(&flags.graphFilePath, "graph", "f", "m2k-graph.json", "Path to a m2k-graph.json file generated by the transform command.")
 	graphCmd.Flags().Int32VarP(&flags.port, "port", "p", 8080, "Port to start the server on.")
 	graphCmd.Flags().StringVarP(&flags.outputPath, "output", "o", "", "Path where the processed graph json file should be generated. If this flag is used then instead of starting a web server, we will output a file. By default "+types.AppName+" does not output this file.")
+	graphCmd.AddCommand(getGraphDiffCommand())
+	graphCmd.Flags().BoolVar(&flags.summary, "summary", false, "Print the slowest and the failed transformers instead of starting a web server.")
+	graphCmd.Flags().StringVar(&flags.format, formatFlag, "", "Export the graph in one of the formats "+strings.Join(graphutils.ExportFormats, ", ")+" instead of starting a web server. It is printed to stdout unless --output is used.")
 	return graphCmd
 }
+
+func getGraphDiffCommand() *cobra.Command {
+	flags := graphDiffFlags{}
+	diffCmd := &cobra.Command{
+		Use:   "diff old/m2k-graph.json new/m2k-graph.json",
+		Short: "Compare the graphs generated by two transform runs.",
+		Long: `Compare the graphs generated by two transform runs, for example before and after changing a customization or upgrading.
+	The transformer runs are matched using the transformer name, class and iteration, and the artifacts using their name, type
+	and the transformer consuming them. The transformers and artifacts that were added, removed or changed are printed.
+	Use --serve to see both graphs combined in the web UI, with the differences highlighted.`,
+		Args: cobra.ExactArgs(2),
+		Run:  func(_ *cobra.Command, args []string) { graphDiffHandler(flags, args[0], args[1]) },
+	}
+	diffCmd.Flags().StringVar(&flags.format, formatFlag, textFormat, "Output format: "+textFormat+" or "+jsonFormat+".")
+	diffCmd.Flags().BoolVar(&flags.serve, "serve", false, "Start a web server to display the combined graph with the differences highlighted.")
+	diffCmd.Flags().Int32VarP(&flags.port, "port", "p", 8080, "Port to start the server on.")
+	diffCmd.Flags().StringVarP(&flags.outputPath, "output", "o", "", "Path where the processed json file of the combined graph should be generated.")
+	return diffCmd
+}
@@ -12,12 +12,10 @@ import (
 	"strings"
 
 	"github.com/konveyor/move2kube-wasm/common"
-	"github.com/mholt/archiver/v3"
 
 	// "github.com/konveyor/move2kube/common/download"
 	// "github.com/konveyor/move2kube/common/vcs"
 	"github.com/konveyor/move2kube-wasm/lib"
-	"github.com/konveyor/move2kube-wasm/qaengine"
 	plantypes "github.com/konveyor/move2kube-wasm/types/plan"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -39,6 +37,7 @@ type planFlags struct {
 	setconfigs []string
 	//PreSets contains a list of preset configurations
 	preSets []string
+	eventsFlags
 }
 
 // func zip_helper(src, dst string) {
@@ -85,15 +84,16 @@ type planFlags struct {
 // }
 
 func planHandler(cmd *cobra.Command, flags planFlags) {
+	startErrorReport("plan")
+	defer finishErrorReport()
 	ctx, cancel := context.WithCancel(cmd.Context())
 	logrus.AddHook(common.NewCleanupHook(cancel))
 	logrus.AddHook(common.NewCleanupHook(lib.Destroy))
 	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
 	go func() {
 		<-ctx.Done()
-		lib.Destroy()
+		// the transformers stop using the cancelled context, a second interrupt stops the process immediately
 		stop()
-		common.Interrupt()
 	}()
 	defer lib.Destroy()
 
@@ -132,15 +132,20 @@ func planHandler(cmd *cobra.Command, flags planFlags) {
 	}
 
 	customizationsPath := flags.customizationsPath
-	// Global settings
-	common.DisableLocalExecution = flags.disableLocalExecution
-	// Global settings
+	engine := startEngine(lib.EngineOptions{
+		Configs:               flags.configs,
+		SetConfigs:            flags.setconfigs,
+		PreSets:               flags.preSets,
+		DisableLocalExecution: flags.disableLocalExecution,
+	})
+	defer engine.Close()
 
 	planfile, err = filepath.Abs(planfile)
 	if err != nil {
 		logrus.Fatalf("Failed to make the plan file path %q absolute. Error: %q", planfile, err)
 	}
 	var fi fs.FileInfo
+	sourceArchive := ""
 	// if srcpath != "" && !isRemotePath {
 	if srcpath != "" {
 		srcpath, err = filepath.Abs(srcpath)
@@ -152,28 +157,11 @@ func planHandler(cmd *cobra.Command, flags planFlags) {
 			logrus.Fatalf("Unable to access source directory : %s", err)
 		}
 		if !fi.IsDir() {
-			supportedExtensions := []string{".zip", ".tar", ".tar.gz", ".tgz"}
-			supported := false
-			for _, ext := range supportedExtensions {
-				if strings.HasSuffix(fi.Name(), ext) {
-					// expand the archive
-					archivePath := srcpath
-					archiveExpandedPath := srcpath + "-expanded"
-					if err := archiver.Unarchive(archivePath, archiveExpandedPath); err != nil {
-						logrus.Fatalf("failed to expand the archive at path %s into path %s . Trying other formats. Error: %q", archivePath, archiveExpandedPath, err)
-					}
-					srcpath = archiveExpandedPath
-					logrus.Infof("using '%s' as the source directory", srcpath)
-					supported = true
-					if err := os.WriteFile(filepath.Join(archiveExpandedPath, ".m2kignore"), []byte("."), common.DefaultFilePermission); err != nil {
-						logrus.Fatalf("Could not write .m2kignore file. Error: %q", err)
-					}
-					break
-				}
-			}
-			if !supported {
-				logrus.Fatalf("The input path '%s' is a file, expected a directory", srcpath)
+			if !common.IsSupportedArchive(srcpath) {
+				logrus.Fatalf("The input path '%s' is a file, expected a directory or an archive with one of the extensions %+v", srcpath, common.SupportedArchiveExtensions)
 			}
+			sourceArchive = srcpath
+			srcpath = extractSourceArchive(sourceArchive)
 		}
 	}
 	{
@@ -233,16 +221,26 @@ func planHandler(cmd *cobra.Command, flags planFlags) {
 		}
 	}
 
-	qaengine.StartEngine(true, 0, true)
-	qaengine.SetupConfigFile("", flags.setconfigs, flags.configs, flags.preSets, false)
+	errorReportDir = filepath.Dir(planfile)
+	defer startEventStreams(flags.eventsFlags)()
 	if flags.progressServerPort != 0 {
 		startPlanProgressServer(flags.progressServerPort)
 	}
-	p, err := lib.CreatePlan(ctx, srcpath, "", customizationsPath, flags.transformerSelector, name)
+	p, err := engine.Plan(ctx, lib.PlanOptions{
+		SourcePath:          srcpath,
+		ProjectName:         name,
+		CustomizationsPath:  customizationsPath,
+		TransformerSelector: flags.transformerSelector,
+	})
 	if err != nil {
 		logrus.Fatalf("failed to create the plan. Error: %q", err)
 	}
-	if err = plantypes.WritePlan(planfile, p); err != nil {
+	if sourceArchive != "" {
+		err = plantypes.WritePlanForArchive(planfile, p, sourceArchive)
+	} else {
+		err = plantypes.WritePlan(planfile, p)
+	}
+	if err != nil {
 		logrus.Fatalf("failed to write the plan to file at path %s . Error: %q", planfile, err)
 	}
 	logrus.Debugf("Plan : %+v", p)
@@ -278,11 +276,11 @@ func GetPlanCommand() *cobra.Command {
 	planCmd := &cobra.Command{
 		Use:   "plan",
 		Short: "Plan out a move",
-		Long:  "Discover and create a plan file based on an input directory",
+		Long:  "Discover and create a plan file based on an input directory" + errorReportHelp,
 		Run:   func(cmd *cobra.Command, _ []string) { planHandler(cmd, flags) },
 	}
 
-	planCmd.Flags().StringVarP(&flags.srcpath, sourceFlag, "s", "", "Specify source directory or a git url (see https://move2kube.konveyor.io/concepts/git-support).")
+	planCmd.Flags().StringVarP(&flags.srcpath, sourceFlag, "s", "", "Specify source directory, a .zip, .tar or .tar.gz archive of it, or a git url (see https://move2kube.konveyor.io/concepts/git-support).")
 	planCmd.Flags().StringVarP(&flags.planfile, planFlag, "p", common.DefaultPlanFile, "Specify a file path to save plan to.")
 	planCmd.Flags().StringVarP(&flags.name, nameFlag, "n", common.DefaultProjectName, "Specify the project name.")
 	planCmd.Flags().StringVarP(&flags.customizationsPath, customizationsFlag, "c", "", "Specify directory or a git url (see https://move2kube.konveyor.io/concepts/git-support) where customizations are stored. By default we look for "+common.DefaultCustomizationDir)
@@ -290,11 +288,15 @@ func GetPlanCommand() *cobra.Command {
 	planCmd.Flags().StringVarP(&flags.transformerSelector, transformerSelectorFlag, "t", "", "Specify the transformer selector.")
 	planCmd.Flags().StringSliceVar(&flags.preSets, preSetFlag, []string{}, "Specify preset config to use.")
 	planCmd.Flags().StringArrayVar(&flags.setconfigs, setConfigFlag, []string{}, "Specify config key-value pairs.")
+	planCmd.Flags().StringVar(&flags.eventsPath, eventsFlag, "", eventsPathHelp)
+	planCmd.Flags().Int32Var(&flags.eventsPort, eventsPortFlag, 0, eventsPortHelp)
 	planCmd.Flags().IntVar(&flags.progressServerPort, planProgressPortFlag, 0, "Port for the plan progress server. If not provided, the server won't be started.")
 	planCmd.Flags().BoolVar(&flags.disableLocalExecution, common.DisableLocalExecutionFlag, false, "Allow files to be executed locally.")
 	planCmd.Flags().BoolVar(&flags.failOnEmptyPlan, common.FailOnEmptyPlan, false, "If true, planning will exit with a failure exit code if no services are detected (and no default transformers are found).")
 
 	must(planCmd.Flags().MarkHidden(planProgressPortFlag))
 
+	planCmd.AddCommand(GetPlanEditCommand())
+
 	return planCmd
 }
@@ -0,0 +1,123 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/lib"
+	plantypes "github.com/konveyor/move2kube-wasm/types/plan"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type planEditFlags struct {
+	qaflags
+	// planfile is the path to the plan file to edit
+	planfile string
+	// merges contains the services to merge in the format <service1>,<service2>=<new name>
+	merges []string
+	// splits contains the services to split into their child modules
+	splits []string
+	// renames contains the services to rename in the format <service>=<new name>
+	renames []string
+}
+
+func planEditHandler(cmd *cobra.Command, flags planEditFlags) {
+	engineOptions := lib.EngineOptions{}
+	if len(flags.splits) == 0 && len(flags.merges) == 0 && len(flags.renames) == 0 {
+		// the operations are asked for
+		engineOptions = getQAEngineOptions(flags.qaflags)
+	}
+	engine := startEngine(engineOptions)
+	defer engine.Close()
+	planfile, err := filepath.Abs(flags.planfile)
+	if err != nil {
+		logrus.Fatalf("Failed to make the plan file path %q absolute. Error: %q", flags.planfile, err)
+	}
+	if fi, err := os.Stat(planfile); err == nil && fi.IsDir() {
+		planfile = filepath.Join(planfile, common.DefaultPlanFile)
+	}
+	p, err := plantypes.ReadPlan(planfile, "")
+	if err != nil {
+		logrus.Fatalf("Unable to read the plan at path %s Error: %q", planfile, err)
+	}
+	operations := []lib.PlanEditOperation{}
+	for _, split := range flags.splits {
+		operations = append(operations, lib.PlanEditOperation{Type: lib.SplitPlanEditOperation, Services: []string{split}})
+	}
+	for _, merge := range flags.merges {
+		operation, err := lib.ParsePlanEditOperation(string(lib.MergePlanEditOperation) + " " + merge)
+		if err != nil {
+			logrus.Fatalf("Invalid value for the --%s flag. Error: %q", planEditMergeFlag, err)
+		}
+		operations = append(operations, operation)
+	}
+	for _, rename := range flags.renames {
+		operation, err := lib.ParsePlanEditOperation(string(lib.RenamePlanEditOperation) + " " + rename)
+		if err != nil {
+			logrus.Fatalf("Invalid value for the --%s flag. Error: %q", planEditRenameFlag, err)
+		}
+		operations = append(operations, operation)
+	}
+	if len(operations) == 0 {
+		operations = lib.GetPlanEditOperations(cmd.Context(), p)
+	}
+	if len(operations) == 0 {
+		logrus.Infof("No operations were specified. The plan at [%s] is unchanged.", planfile)
+		return
+	}
+	p, err = lib.EditPlan(p, operations)
+	if err != nil {
+		logrus.Fatalf("failed to edit the plan at path %s . Error: %q", planfile, err)
+	}
+	if err := plantypes.WritePlan(planfile, p); err != nil {
+		logrus.Fatalf("failed to write the plan to file at path %s . Error: %q", planfile, err)
+	}
+	logrus.Infof("Edited plan can be found at [%s].", planfile)
+}
+
+// GetPlanEditCommand returns a command to merge, split and rename the services in a plan
+func GetPlanEditCommand() *cobra.Command {
+	viper.AutomaticEnv()
+
+	flags := planEditFlags{}
+	planEditCmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Merge, split and rename the services in a plan",
+		Long: `Merge, split and rename the services in a plan. The plan is validated after the edits.
+	Splits are performed first, then merges and finally renames.
+	If no operations are given as flags, they are asked for interactively.`,
+		Run: func(cmd *cobra.Command, _ []string) { planEditHandler(cmd, flags) },
+	}
+
+	planEditCmd.Flags().StringVarP(&flags.planfile, planFlag, "p", common.DefaultPlanFile, "Specify the plan file to edit.")
+	planEditCmd.Flags().StringArrayVar(&flags.merges, planEditMergeFlag, []string{}, "Merge services into one pod with a container for each service. Format: <service1>,<service2>=<new name>")
+	planEditCmd.Flags().StringArrayVar(&flags.splits, planEditSplitFlag, []string{}, "Split a service into a service for each of its child modules.")
+	planEditCmd.Flags().StringArrayVar(&flags.renames, planEditRenameFlag, []string{}, "Rename a service. Format: <service>=<new name>")
+	planEditCmd.Flags().StringVar(&flags.configOut, configOutFlag, ".", "Specify config file output location.")
+	planEditCmd.Flags().StringVar(&flags.qaCacheOut, qaCacheOutFlag, ".", "Specify cache file output location.")
+	planEditCmd.Flags().StringSliceVarP(&flags.configs, configFlag, "f", []string{}, "Specify config file locations.")
+	planEditCmd.Flags().StringSliceVar(&flags.preSets, preSetFlag, []string{}, "Specify preset config to use.")
+	planEditCmd.Flags().StringArrayVar(&flags.setconfigs, setConfigFlag, []string{}, "Specify config key-value pairs.")
+	planEditCmd.Flags().BoolVar(&flags.qaskip, qaSkipFlag, false, "Enable/disable the default answers to questions posed in QA Cli sub-system. If disabled, you will have to answer the questions posed by QA during interaction.")
+
+	return planEditCmd
+}
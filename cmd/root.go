@@ -51,5 +51,6 @@ func GetRootCmd() *cobra.Command {
 	rootCmd.AddCommand(GetTransformCommand())
 	rootCmd.AddCommand(GetGenerateDocsCommand())
 	rootCmd.AddCommand(GetGraphCommand())
+	rootCmd.AddCommand(GetTransformersCommand())
 	return rootCmd
 }
@@ -18,6 +18,8 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -27,8 +29,9 @@ import (
 	"github.com/konveyor/move2kube-wasm/common/download"
 	//"github.com/konveyor/move2kube-wasm/common/vcs"
 	"github.com/konveyor/move2kube-wasm/lib"
+	"github.com/konveyor/move2kube-wasm/transformer"
+	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
 	"github.com/konveyor/move2kube-wasm/types/plan"
-	"github.com/mholt/archiver/v3"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -57,9 +60,22 @@ type transformFlags struct {
 	// CustomizationsPaths contains the path to the customizations directory
 	customizationsPath  string
 	transformerSelector string
+	// dryRun runs the transformation without writing to the output directory
+	dryRun bool
+	// dryRunOutput is the path where the dry run report should be written as json
+	dryRunOutput string
+	// preserveEdits merges the edits made to the output directory into the new output
+	preserveEdits bool
+	// resume continues the transformation from the checkpoint of a previous run
+	resume bool
+	// outputArchive is the path of the archive the output, the plan and the graph are written to
+	outputArchive string
+	eventsFlags
 }
 
 func transformHandler(cmd *cobra.Command, flags transformFlags) {
+	startErrorReport("transform")
+	defer finishErrorReport()
 	if flags.profilepath != "" {
 		if f, err := os.Create(flags.profilepath); err != nil {
 			panic(err)
@@ -75,9 +91,8 @@ func transformHandler(cmd *cobra.Command, flags transformFlags) {
 	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
 	go func() {
 		<-ctx.Done()
-		lib.Destroy()
+		// the transformers stop using the cancelled context, a second interrupt stops the process immediately
 		stop()
-		common.Interrupt()
 	}()
 	defer lib.Destroy()
 
@@ -93,6 +108,17 @@ func transformHandler(cmd *cobra.Command, flags transformFlags) {
 			logrus.Fatalf("Failed to make the source directory path %q absolute. Error: %q", flags.srcpath, err)
 		}
 	}
+	if flags.outputArchive != "" {
+		if flags.dryRun {
+			logrus.Fatalf("The --%s flag cannot be used with --%s since nothing is written during a dry run.", outputArchiveFlag, dryRunFlag)
+		}
+		if !common.IsSupportedArchive(flags.outputArchive) {
+			logrus.Fatalf("The output archive %s must have one of the extensions %+v", flags.outputArchive, common.SupportedArchiveExtensions)
+		}
+		if flags.outputArchive, err = filepath.Abs(flags.outputArchive); err != nil {
+			logrus.Fatalf("Failed to make the output archive path %q absolute. Error: %q", flags.outputArchive, err)
+		}
+	}
 	//isRemoteOutPath := vcs.IsRemotePath(flags.outpath)
 	//if !isRemoteOutPath {
 	if flags.outpath, err = filepath.Abs(flags.outpath); err != nil {
@@ -128,10 +154,18 @@ func transformHandler(cmd *cobra.Command, flags transformFlags) {
 		}
 	}
 
+	engineOptions := getQAEngineOptions(flags.qaflags)
+	engineOptions.IgnoreEnvironment = flags.ignoreEnv
+	engineOptions.DisableLocalExecution = flags.disableLocalExecution
+	engine := startEngine(engineOptions)
+	defer engine.Close()
+	sourceArchive := ""
+	if flags.srcpath != "" && isSourceArchive(flags.srcpath) {
+		sourceArchive = flags.srcpath
+		flags.srcpath = extractSourceArchive(sourceArchive)
+	}
 	// Global settings
-	common.IgnoreEnvironment = flags.ignoreEnv
-	common.DisableLocalExecution = flags.disableLocalExecution
-	// Global settings
+	defer startEventStreams(flags.eventsFlags)()
 
 	// Parameter cleaning and curate plan
 	transformationPlan := plan.Plan{}
@@ -151,7 +185,7 @@ func transformHandler(cmd *cobra.Command, flags transformFlags) {
 		//TODO: WASI
 		//if !isRemoteOutPath {
 		flags.outpath = filepath.Join(flags.outpath, flags.name)
-		checkOutputPath(flags.outpath, flags.overwrite)
+		checkOutputPath(flags.outpath, flags.overwrite || flags.dryRun || flags.preserveEdits || flags.resume)
 		//if flags.srcpath != "" && !isRemotePath {
 		if flags.srcpath != "" {
 			checkSourcePath(flags.srcpath)
@@ -159,13 +193,21 @@ func transformHandler(cmd *cobra.Command, flags transformFlags) {
 				logrus.Fatalf("The source path %s and output path %s overlap.", flags.srcpath, flags.outpath)
 			}
 		}
-		if err := os.MkdirAll(flags.outpath, common.DefaultDirectoryPermission); err != nil {
-			logrus.Fatalf("Failed to create the output directory at path %s Error: %q", flags.outpath, err)
+		if !flags.dryRun {
+			if err := os.MkdirAll(flags.outpath, common.DefaultDirectoryPermission); err != nil {
+				logrus.Fatalf("Failed to create the output directory at path %s Error: %q", flags.outpath, err)
+			}
+			errorReportDir = flags.outpath
 		}
 		//}
-		startQA(flags.qaflags)
 		logrus.Debugf("Creating a new plan.")
-		transformationPlan, err = lib.CreatePlan(ctx, flags.srcpath, flags.outpath, flags.customizationsPath, flags.transformerSelector, flags.name)
+		transformationPlan, err = engine.Plan(ctx, lib.PlanOptions{
+			SourcePath:          flags.srcpath,
+			OutputPath:          flags.outpath,
+			ProjectName:         flags.name,
+			CustomizationsPath:  flags.customizationsPath,
+			TransformerSelector: flags.transformerSelector,
+		})
 		if err != nil {
 			logrus.Fatalf("failed to create the plan. Error: %q", err)
 		}
@@ -184,6 +226,12 @@ func transformHandler(cmd *cobra.Command, flags transformFlags) {
 		if transformationPlan, err = plan.ReadPlan(flags.planfile, sourceDir); err != nil {
 			logrus.Fatalf("Unable to read the plan at path %s Error: %q", flags.planfile, err)
 		}
+		if sourceDir == "" && isSourceArchive(transformationPlan.Spec.SourceDir) {
+			sourceArchive = transformationPlan.Spec.SourceDir
+			if transformationPlan, err = plan.ReadPlan(flags.planfile, extractSourceArchive(sourceArchive)); err != nil {
+				logrus.Fatalf("Unable to read the plan at path %s Error: %q", flags.planfile, err)
+			}
+		}
 		if len(transformationPlan.Spec.Services) == 0 && len(transformationPlan.Spec.InvokedByDefaultTransformers) == 0 {
 			logrus.Debugf("Plan : %+v", transformationPlan)
 			logrus.Fatalf("Failed to find any services or default transformers. Aborting.")
@@ -202,39 +250,87 @@ func transformHandler(cmd *cobra.Command, flags transformFlags) {
 		//if transformationPlan.Spec.SourceDir != "" {
 		//	checkSourcePath(transformationPlan.Spec.SourceDir)
 		//}
-		lib.CheckAndCopyCustomizations(transformationPlan.Spec.CustomizationsDir)
 
 		//TODO: WASI
 		//if !isRemoteOutPath {
 		flags.outpath = filepath.Join(flags.outpath, transformationPlan.Name)
-		checkOutputPath(flags.outpath, flags.overwrite)
+		checkOutputPath(flags.outpath, flags.overwrite || flags.dryRun || flags.preserveEdits || flags.resume)
 		if transformationPlan.Spec.SourceDir != "" && (transformationPlan.Spec.SourceDir == flags.outpath || common.IsParent(flags.outpath, transformationPlan.Spec.SourceDir) || common.IsParent(transformationPlan.Spec.SourceDir, flags.outpath)) {
 			logrus.Fatalf("The source path %s and output path %s overlap.", transformationPlan.Spec.SourceDir, flags.outpath)
 		}
-		if err := os.MkdirAll(flags.outpath, common.DefaultDirectoryPermission); err != nil {
-			logrus.Fatalf("Failed to create the output directory at path %s Error: %q", flags.outpath, err)
+		if !flags.dryRun {
+			if err := os.MkdirAll(flags.outpath, common.DefaultDirectoryPermission); err != nil {
+				logrus.Fatalf("Failed to create the output directory at path %s Error: %q", flags.outpath, err)
+			}
+			errorReportDir = flags.outpath
 		}
 		//}
-		startQA(flags.qaflags)
 	}
-	if err := lib.Transform(ctx, transformationPlan, preExistingPlan, flags.outpath, flags.transformerSelector, flags.maxIterations); err != nil {
+	if flags.outputArchive != "" && (flags.outputArchive == flags.outpath || common.IsParent(flags.outputArchive, flags.outpath)) {
+		logrus.Fatalf("The output archive %s cannot be inside the output directory %s", flags.outputArchive, flags.outpath)
+	}
+	transformOptions := lib.TransformOptions{
+		OutputPath:          flags.outpath,
+		TransformerSelector: flags.transformerSelector,
+		MaxIterations:       flags.maxIterations,
+		PreExistingPlan:     preExistingPlan,
+		GraphPath:           graphtypes.GraphFileName,
+		DryRun:              flags.dryRun,
+	}
+	if flags.resume {
+		transformOptions.CheckpointPath = transformer.CheckpointFileName
+		transformOptions.Resume = true
+	} else if flags.preserveEdits {
+		transformOptions.PreserveEdits = true
+	} else {
+		transformOptions.CheckpointPath = transformer.CheckpointFileName
+	}
+	result, err := engine.Transform(ctx, transformationPlan, transformOptions)
+	if err != nil {
 		logrus.Fatalf("failed to transform. Error: %q", err)
 	}
-	logrus.Infof("Transformed target artifacts can be found at [%s].", flags.outpath)
-	{
-		if err := archiver.Archive([]string{"myproject"}, "myproject.zip"); err != nil {
-			logrus.Fatalf("Cannot archive myproject dir. Error: %q", err)
+	if flags.dryRun {
+		fmt.Print(result.DryRunReport.String())
+		if flags.dryRunOutput != "" {
+			reportBytes, err := json.MarshalIndent(result.DryRunReport, "", "    ")
+			if err != nil {
+				logrus.Fatalf("failed to marshal the dry run report to json. Error: %q", err)
+			}
+			if err := os.WriteFile(flags.dryRunOutput, reportBytes, common.DefaultFilePermission); err != nil {
+				logrus.Fatalf("failed to write the dry run report to a file at path %s . Error: %q", flags.dryRunOutput, err)
+			}
+			logrus.Infof("Dry run report can be found at [%s].", flags.dryRunOutput)
 		}
+		return
 	}
-	{
-		logrus.Infof("DEBUG after planning, list files")
-		fs, err := os.ReadDir("myproject/source/language-platforms/")
-		if err != nil {
-			panic(err)
-		}
-		for i, f := range fs {
-			logrus.Infof("DEBUG file[%d] %+v", i, f)
-		}
+	logrus.Infof("Transformed target artifacts can be found at [%s].", flags.outpath)
+	if flags.outputArchive != "" {
+		writeOutputArchive(flags.outputArchive, flags.outpath, transformationPlan, sourceArchive)
+		logrus.Infof("The output, the plan and the graph can be found in the archive [%s].", flags.outputArchive)
+	}
+}
+
+// writeOutputArchive writes the output directory, the plan and the graph of the transformation to a single archive.
+func writeOutputArchive(archivePath, outpath string, transformationPlan plan.Plan, sourceArchive string) {
+	planPath := filepath.Join(common.TempPath, common.DefaultPlanFile)
+	var err error
+	if sourceArchive != "" {
+		err = plan.WritePlanForArchive(planPath, transformationPlan, sourceArchive)
+	} else {
+		err = plan.WritePlan(planPath, transformationPlan)
+	}
+	if err != nil {
+		logrus.Fatalf("failed to write the plan to file at path %s . Error: %q", planPath, err)
+	}
+	entries := map[string]string{
+		filepath.Base(outpath): outpath,
+		common.DefaultPlanFile: planPath,
+	}
+	if _, err := os.Stat(graphtypes.GraphFileName); err == nil {
+		entries[graphtypes.GraphFileName] = graphtypes.GraphFileName
+	}
+	if err := common.CreateArchive(archivePath, entries); err != nil {
+		logrus.Fatalf("failed to write the output archive at path %s . Error: %q", archivePath, err)
 	}
 }
 
@@ -251,7 +347,7 @@ func GetTransformCommand() *cobra.Command {
 	transformCmd := &cobra.Command{
 		Use:        "transform",
 		Short:      "Transform using move2kube plan",
-		Long:       "Transform artifacts using move2kube plan",
+		Long:       "Transform artifacts using move2kube plan" + errorReportHelp,
 		Run:        func(cmd *cobra.Command, _ []string) { transformHandler(cmd, flags) },
 		SuggestFor: []string{"translate"},
 	}
@@ -260,8 +356,9 @@ func GetTransformCommand() *cobra.Command {
 	transformCmd.Flags().StringVar(&flags.profilepath, profileFlag, "", "Path where the CPU profile file should be generated. By default we don't profile.")
 	transformCmd.Flags().StringVarP(&flags.planfile, planFlag, "p", common.DefaultPlanFile, "Specify a plan file to execute.")
 	transformCmd.Flags().BoolVar(&flags.overwrite, overwriteFlag, false, "Overwrite the output directory if it exists. By default we don't overwrite.")
-	transformCmd.Flags().StringVarP(&flags.srcpath, sourceFlag, "s", "", "Specify source directory or a git url (see https://move2kube.konveyor.io/concepts/git-support) to transform. If you already have a m2k.plan then this will override the sourceDir value specified in that plan.")
+	transformCmd.Flags().StringVarP(&flags.srcpath, sourceFlag, "s", "", "Specify source directory, a .zip, .tar or .tar.gz archive of it, or a git url (see https://move2kube.konveyor.io/concepts/git-support) to transform. If you already have a m2k.plan then this will override the sourceDir value specified in that plan.")
 	transformCmd.Flags().StringVarP(&flags.outpath, outputFlag, "o", ".", "Path for output or a git url (see https://move2kube.konveyor.io/concepts/git-support). Default will be directory with the project name.")
+	transformCmd.Flags().StringVar(&flags.outputArchive, outputArchiveFlag, "", "Path of a .zip, .tar or .tar.gz archive to write the output, the plan and the graph to, in addition to the output directory.")
 	transformCmd.Flags().StringVarP(&flags.name, nameFlag, "n", common.DefaultProjectName, "Specify the project name.")
 	transformCmd.Flags().StringVar(&flags.configOut, configOutFlag, ".", "Specify config file output location.")
 	transformCmd.Flags().StringVar(&flags.qaCacheOut, qaCacheOutFlag, ".", "Specify cache file output location.")
@@ -277,6 +374,12 @@ func GetTransformCommand() *cobra.Command {
 	transformCmd.Flags().BoolVar(&flags.ignoreEnv, ignoreEnvFlag, false, "Ignore data from local machine.")
 	transformCmd.Flags().BoolVar(&flags.disableLocalExecution, common.DisableLocalExecutionFlag, false, "Allow files to be executed locally.")
 	transformCmd.Flags().IntVar(&flags.maxIterations, maxIterationsFlag, -1, "The maximum number of iterations to allow. Negative value means infinite. Default is -1.")
+	transformCmd.Flags().BoolVar(&flags.dryRun, dryRunFlag, false, "Run the transformation without writing to the output directory and print the files that would be written.")
+	transformCmd.Flags().BoolVar(&flags.preserveEdits, preserveEditsFlag, false, "Regenerate into an existing output directory, merging the manual edits made to the previous output into the new output.")
+	transformCmd.Flags().BoolVar(&flags.resume, resumeFlag, false, "Resume the transformation from the checkpoint ("+transformer.CheckpointFileName+") left behind by a failed run. The plan and the transformers must not have changed.")
+	transformCmd.Flags().StringVar(&flags.eventsPath, eventsFlag, "", eventsPathHelp)
+	transformCmd.Flags().Int32Var(&flags.eventsPort, eventsPortFlag, 0, eventsPortHelp)
+	transformCmd.Flags().StringVar(&flags.dryRunOutput, dryRunOutputFlag, "", "Path where the dry run report should be written as json. Only used with --"+dryRunFlag+".")
 
 	// Hidden options
 	transformCmd.Flags().BoolVar(&flags.qadisablecli, qadisablecliFlag, false, "Enable/disable the QA Cli sub-system. Without this system, you will have to use the REST API to interact.")
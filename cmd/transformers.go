@@ -0,0 +1,275 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/lib"
+	"github.com/konveyor/move2kube-wasm/transformer"
+	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type transformersFlags struct {
+	// customizationsPath contains the path to the customizations directory
+	customizationsPath string
+	// transformerSelector selects the transformers to consider
+	transformerSelector string
+	// format is the output format of the list and inspect commands
+	format string
+}
+
+const (
+	tableFormat = "table"
+	jsonFormat  = "json"
+)
+
+// getTransformerYamlPaths loads the built-in transformers and the customizations
+func getTransformerYamlPaths(flags transformersFlags) (map[string]string, labels.Selector) {
+	if flags.customizationsPath == "" {
+		if _, err := os.Stat(common.DefaultCustomizationDir); err == nil {
+			flags.customizationsPath = common.DefaultCustomizationDir
+		}
+	}
+	if err := lib.CheckAndCopyCustomizations(flags.customizationsPath); err != nil {
+		logrus.Fatalf("failed to check and copy the customizations. Error: %q", err)
+	}
+	selector, err := common.ConvertStringSelectorsToSelectors(flags.transformerSelector)
+	if err != nil {
+		logrus.Fatalf("failed to parse the transformer selector '%s' . Error: %q", flags.transformerSelector, err)
+	}
+	transformerYamlPaths, err := transformer.GetTransformerYamlPaths(common.AssetsPath)
+	if err != nil {
+		logrus.Fatalf("failed to find the transformers. Error: %q", err)
+	}
+	return transformerYamlPaths, selector
+}
+
+func transformersCheckHandler(flags transformersFlags) {
+	engine := startEngine(lib.EngineOptions{})
+	defer engine.Close()
+	transformerYamlPaths, selector := getTransformerYamlPaths(flags)
+	issues := transformer.ValidateCustomTransformers(common.AssetsPath, transformerYamlPaths, selector)
+	if len(issues) == 0 {
+		fmt.Println("No issues found in the custom transformers.")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TRANSFORMER\tISSUE\tMESSAGE")
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", issue.Transformer, issue.Type, issue.Message)
+	}
+	w.Flush()
+	fmt.Printf("Found %d issues.\n", len(issues))
+	engine.Close()
+	os.Exit(1)
+}
+
+func printJSON(v interface{}) {
+	jsonBytes, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		logrus.Fatalf("failed to marshal to json. Error: %q", err)
+	}
+	fmt.Println(string(jsonBytes))
+}
+
+func transformersListHandler(flags transformersFlags) {
+	engine := startEngine(lib.EngineOptions{})
+	defer engine.Close()
+	transformerYamlPaths, selector := getTransformerYamlPaths(flags)
+	infos := transformer.GetTransformerInfos(common.AssetsPath, transformerYamlPaths, selector)
+	if flags.format == jsonFormat {
+		printJSON(infos)
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCLASS\tSOURCE\tDEFAULT\tCONTAINER\tCONSUMES\tPRODUCES\tFILTERED")
+	for _, info := range infos {
+		filtered := "-"
+		if info.Filtered {
+			filtered = info.FilteredReason
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%t\t%s\t%s\t%s\n",
+			info.Name, info.Class, info.Source, info.SelectedByDefault, info.ContainerBased,
+			joinOrDash(info.ConsumedArtifacts), joinOrDash(info.ProducedArtifacts), filtered)
+	}
+	w.Flush()
+}
+
+func transformersInspectHandler(flags transformersFlags, name string) {
+	engine := startEngine(lib.EngineOptions{})
+	defer engine.Close()
+	transformerYamlPaths, selector := getTransformerYamlPaths(flags)
+	infos := transformer.GetTransformerInfos(common.AssetsPath, transformerYamlPaths, selector)
+	for _, info := range infos {
+		if info.Name != name {
+			continue
+		}
+		if flags.format == jsonFormat {
+			printJSON(info)
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(w, "Name:\t%s\n", info.Name)
+		fmt.Fprintf(w, "Class:\t%s\n", info.Class)
+		fmt.Fprintf(w, "Source:\t%s\n", info.Source)
+		fmt.Fprintf(w, "Path:\t%s\n", info.YamlPath)
+		labelStrs := []string{}
+		for k, v := range info.Labels {
+			labelStrs = append(labelStrs, k+"="+v)
+		}
+		sort.Strings(labelStrs)
+		fmt.Fprintf(w, "Labels:\t%s\n", joinOrDash(labelStrs))
+		fmt.Fprintf(w, "Selected by default:\t%t\n", info.SelectedByDefault)
+		fmt.Fprintf(w, "Container based:\t%t\n", info.ContainerBased)
+		fmt.Fprintf(w, "Invoked by default:\t%t\n", info.InvokedByDefault)
+		fmt.Fprintf(w, "Detects services:\t%t\n", info.DetectsServices)
+		fmt.Fprintf(w, "Consumes:\t%s\n", joinOrDash(info.ConsumedArtifacts))
+		fmt.Fprintf(w, "Produces:\t%s\n", joinOrDash(info.ProducedArtifacts))
+		if info.Filtered {
+			fmt.Fprintf(w, "Filtered:\t%s\n", info.FilteredReason)
+		} else {
+			fmt.Fprintf(w, "Filtered:\tfalse\n")
+		}
+		w.Flush()
+		return
+	}
+	logrus.Fatalf("the transformer '%s' was not found", name)
+}
+
+type transformersNewFlags struct {
+	transformersFlags
+	outpath  string
+	class    string
+	consumes []string
+	produces []string
+}
+
+func transformersNewHandler(flags transformersNewFlags, name string) {
+	engine := startEngine(lib.EngineOptions{})
+	defer engine.Close()
+	transformerYamlPaths, _ := getTransformerYamlPaths(flags.transformersFlags)
+	if _, ok := transformerYamlPaths[name]; ok {
+		logrus.Fatalf("a transformer with the name '%s' already exists", name)
+	}
+	knownTypes := transformer.GetKnownArtifactTypes(transformerYamlPaths)
+	for _, artifactType := range append(append([]string{}, flags.consumes...), flags.produces...) {
+		if !common.IsPresent(knownTypes, artifactType) {
+			logrus.Warnf("the artifact type '%s' is not consumed or produced by any of the existing transformers. Known artifact types: %+v", artifactType, knownTypes)
+		}
+	}
+	scaffold := lib.TransformerScaffold{
+		Name:     name,
+		Class:    flags.class,
+		Consumes: flags.consumes,
+		Produces: flags.produces,
+	}
+	if err := lib.ScaffoldTransformer(scaffold, flags.outpath); err != nil {
+		logrus.Fatalf("failed to generate the transformer '%s' . Error: %q", name, err)
+	}
+	fmt.Printf("Generated the transformer '%s' in %s . See %s for how to try it out.\n", name, flags.outpath, filepath.Join(flags.outpath, "README.md"))
+}
+
+func joinOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ",")
+}
+
+// GetTransformersCommand returns a command to work with the available transformers
+func GetTransformersCommand() *cobra.Command {
+	flags := transformersFlags{}
+	transformersCmd := &cobra.Command{
+		Use:   "transformers",
+		Short: "Work with the available transformers",
+		Long:  "Work with the built-in transformers and the transformers in the customizations directory.",
+	}
+	transformersCmd.PersistentFlags().StringVarP(&flags.customizationsPath, customizationsFlag, "c", "", "Specify directory or a git url (see https://move2kube.konveyor.io/concepts/git-support) where customizations are stored. By default we look for "+common.DefaultCustomizationDir)
+	transformersCmd.PersistentFlags().StringVarP(&flags.transformerSelector, transformerSelectorFlag, "t", "", "Specify the transformer selector.")
+
+	checkCmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check the consumes/produces graph of the transformers",
+		Long: `Check the consumes/produces graph of the transformers for transformers that never receive any artifacts,
+	produced artifact types that nobody consumes, unknown changeTypeTo targets and dependency/override selectors that match nothing.
+	Only the issues of the transformers in the customizations directory are reported, the built-in transformers are part of the graph.
+	Exits with a non-zero code if any issues are found.`,
+		Args: cobra.NoArgs,
+		Run:  func(*cobra.Command, []string) { transformersCheckHandler(flags) },
+	}
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the available transformers",
+		Long: `List the built-in transformers and the transformers in the customizations directory along with their class,
+	consumed/produced artifacts and whether they would be filtered out by the transformer selector.`,
+		Args: cobra.NoArgs,
+		PreRun: func(*cobra.Command, []string) {
+			if flags.format != tableFormat && flags.format != jsonFormat {
+				logrus.Fatalf("the --%s flag must be either %s or %s. Actual: %s", formatFlag, tableFormat, jsonFormat, flags.format)
+			}
+		},
+		Run: func(*cobra.Command, []string) { transformersListHandler(flags) },
+	}
+	inspectCmd := &cobra.Command{
+		Use:   "inspect name",
+		Short: "Show the details of a transformer",
+		Long:  "Show the source, labels, class, consumed/produced artifacts of a transformer and whether it would be filtered out by the transformer selector.",
+		Args:  cobra.ExactArgs(1),
+		PreRun: func(*cobra.Command, []string) {
+			if flags.format != tableFormat && flags.format != jsonFormat {
+				logrus.Fatalf("the --%s flag must be either %s or %s. Actual: %s", formatFlag, tableFormat, jsonFormat, flags.format)
+			}
+		},
+		Run: func(_ *cobra.Command, args []string) { transformersInspectHandler(flags, args[0]) },
+	}
+	for _, cmd := range []*cobra.Command{listCmd, inspectCmd} {
+		cmd.Flags().StringVarP(&flags.format, formatFlag, "f", tableFormat, "Specify the output format. One of: table, json")
+	}
+	newFlags := transformersNewFlags{}
+	newCmd := &cobra.Command{
+		Use:   "new name",
+		Short: "Generate a new custom transformer",
+		Long: `Generate a customizations directory containing a new transformer, a templates directory for the Template path mappings,
+	Python scripts run by the Executable class (or a Starlark stub), and a sample source directory to try it on.
+	Fails if the transformer class is not available in this build of move2kube, since the generated transformer could not be run.`,
+		Args: cobra.ExactArgs(1),
+		PreRun: func(*cobra.Command, []string) {
+			newFlags.transformersFlags = flags
+			if !common.IsPresent(lib.ScaffoldClasses, newFlags.class) {
+				logrus.Fatalf("the --%s flag must be one of %+v. Actual: %s", classFlag, lib.ScaffoldClasses, newFlags.class)
+			}
+		},
+		Run: func(_ *cobra.Command, args []string) { transformersNewHandler(newFlags, args[0]) },
+	}
+	newCmd.Flags().StringVarP(&newFlags.outpath, outputFlag, "o", ".", "Specify the directory where the transformer and the sample source should be generated.")
+	newCmd.Flags().StringVar(&newFlags.class, classFlag, lib.ExecutableScaffoldClass, fmt.Sprintf("Specify the class of the transformer. One of: %+v", lib.ScaffoldClasses))
+	newCmd.Flags().StringSliceVar(&newFlags.consumes, consumesFlag, []string{string(artifacts.ServiceArtifactType)}, "Specify the artifact types consumed by the transformer.")
+	newCmd.Flags().StringSliceVar(&newFlags.produces, producesFlag, nil, "Specify the artifact types produced by the transformer.")
+	transformersCmd.AddCommand(checkCmd, listCmd, inspectCmd, newCmd)
+	return transformersCmd
+}
@@ -18,7 +18,6 @@ package cmd
 
 import (
 	"encoding/json"
-	"github.com/konveyor/move2kube-wasm/qaengine"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -26,6 +25,8 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/lib"
+	"github.com/konveyor/move2kube-wasm/qaengine"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cast"
 )
@@ -51,6 +52,34 @@ func checkSourcePath(srcpath string) {
 	}
 }
 
+// extractSourceArchive extracts the source archive into a temporary directory and returns the directory.
+func extractSourceArchive(archivePath string) string {
+	if err := os.MkdirAll(common.RemoteTempPath, common.DefaultDirectoryPermission); err != nil {
+		logrus.Fatalf("Failed to create the temporary directory at path %s Error: %q", common.RemoteTempPath, err)
+	}
+	tempPath, err := os.MkdirTemp(common.RemoteTempPath, "source-")
+	if err != nil {
+		logrus.Fatalf("Failed to create a temporary directory to extract the source archive %s into. Error: %q", archivePath, err)
+	}
+	// the name of the directory is used to name the service when the files are at the root of the archive
+	extractedPath := filepath.Join(tempPath, common.GetArchiveName(archivePath))
+	if err := common.ExtractArchive(archivePath, extractedPath); err != nil {
+		logrus.Fatalf("Failed to extract the source archive %s Error: %q", archivePath, err)
+	}
+	sourcePath, err := common.GetArchiveRootDir(extractedPath)
+	if err != nil {
+		logrus.Fatalf("Failed to find the source directory in the extracted archive %s Error: %q", archivePath, err)
+	}
+	logrus.Infof("Extracted the source archive %s into the directory %s", archivePath, sourcePath)
+	return sourcePath
+}
+
+// isSourceArchive returns true if the source path is a file with the extension of a supported archive.
+func isSourceArchive(srcpath string) bool {
+	fi, err := os.Stat(srcpath)
+	return err == nil && !fi.IsDir() && common.IsSupportedArchive(srcpath)
+}
+
 // checkOutputPath checks if the output path is already in use.
 func checkOutputPath(outpath string, overwrite bool) {
 	fi, err := os.Stat(outpath)
@@ -82,51 +111,63 @@ Exiting.`, outpath, overwriteFlag, outputFlag)
 	logrus.Infof("Output directory '%s' exists. The contents might get overwritten.", outpath)
 }
 
-func startQA(flags qaflags) {
-	qaengine.StartEngine(flags.qaskip, flags.qaport, flags.qadisablecli)
-	if flags.configOut == "" {
-		qaengine.SetupConfigFile("", flags.setconfigs, flags.configs, flags.preSets, flags.persistPasswords)
-	} else {
-		if flags.configOut == "." {
-			qaengine.SetupConfigFile(common.ConfigFile, flags.setconfigs, flags.configs, flags.preSets, flags.persistPasswords)
-		} else if fi, err := os.Stat(flags.configOut); err == nil {
-			if fi.IsDir() {
-				qaengine.SetupConfigFile(filepath.Join(flags.configOut, common.ConfigFile), flags.setconfigs, flags.configs, flags.preSets, flags.persistPasswords)
-			} else {
-				qaengine.SetupConfigFile(flags.configOut, flags.setconfigs, flags.configs, flags.preSets, flags.persistPasswords)
-			}
-		} else if strings.Contains(filepath.Base(flags.configOut), ".") {
-			os.MkdirAll(filepath.Dir(flags.configOut), common.DefaultDirectoryPermission)
-			qaengine.SetupConfigFile(flags.configOut, flags.setconfigs, flags.configs, flags.preSets, flags.persistPasswords)
-		} else {
-			os.MkdirAll(flags.configOut, common.DefaultDirectoryPermission)
-			qaengine.SetupConfigFile(filepath.Join(flags.configOut, common.ConfigFile), flags.setconfigs, flags.configs, flags.preSets, flags.persistPasswords)
-		}
+// startEngine creates the engine of the command and makes it the one used by the package level functions.
+// The engine is closed when the command exits using logrus.Fatal, otherwise the command should close it.
+func startEngine(options lib.EngineOptions) *lib.Engine {
+	engine, err := lib.NewEngine(options)
+	if err != nil {
+		logrus.Fatalf("failed to create the engine. Error: %q", err)
+	}
+	engine.Activate()
+	logrus.AddHook(common.NewCleanupHook(func() { engine.Close() }))
+	return engine
+}
+
+// getQAEngineOptions returns the engine options for the QA flags
+func getQAEngineOptions(flags qaflags) lib.EngineOptions {
+	qaEngine := qaengine.NewEngine(flags.qaskip, flags.qaport, flags.qadisablecli)
+	if qaEngine == nil {
+		logrus.Warnf("The interactive QA engines are not available in this build. Using the default answers.")
 	}
-	if flags.qaCacheOut != "" {
-		if flags.qaCacheOut == "." {
-			qaengine.SetupWriteCacheFile(common.QACacheFile, flags.persistPasswords)
-		} else if fi, err := os.Stat(flags.qaCacheOut); err == nil {
-			if fi.IsDir() {
-				qaengine.SetupWriteCacheFile(filepath.Join(flags.qaCacheOut, common.QACacheFile), flags.persistPasswords)
-			} else {
-				qaengine.SetupWriteCacheFile(flags.qaCacheOut, flags.persistPasswords)
-			}
-		} else if strings.Contains(filepath.Base(flags.qaCacheOut), ".") {
-			os.MkdirAll(filepath.Dir(flags.qaCacheOut), common.DefaultDirectoryPermission)
-			qaengine.SetupWriteCacheFile(flags.qaCacheOut, flags.persistPasswords)
-		} else {
-			os.MkdirAll(flags.qaCacheOut, common.DefaultDirectoryPermission)
-			qaengine.SetupWriteCacheFile(filepath.Join(flags.qaCacheOut, common.QACacheFile), flags.persistPasswords)
+	return lib.EngineOptions{
+		Configs:          flags.configs,
+		SetConfigs:       flags.setconfigs,
+		PreSets:          flags.preSets,
+		QAEngine:         qaEngine,
+		ConfigOutPath:    getQAOutPath(flags.configOut, common.ConfigFile),
+		QACacheOutPath:   getQAOutPath(flags.qaCacheOut, common.QACacheFile),
+		PersistPasswords: flags.persistPasswords,
+	}
+}
+
+// getQAOutPath returns the path of the file the QA engine writes to.
+// The output location is either a file or a directory, in which case the default file name is used.
+func getQAOutPath(outPath string, defaultFileName string) string {
+	if outPath == "" {
+		return ""
+	}
+	if outPath == "." {
+		return defaultFileName
+	}
+	if fi, err := os.Stat(outPath); err == nil {
+		if fi.IsDir() {
+			return filepath.Join(outPath, defaultFileName)
 		}
+		return outPath
 	}
-	if err := qaengine.WriteStoresToDisk(); err != nil {
-		logrus.Warnf("Failed to write the stores to disk. Error: %q", err)
+	if strings.Contains(filepath.Base(outPath), ".") {
+		os.MkdirAll(filepath.Dir(outPath), common.DefaultDirectoryPermission)
+		return outPath
 	}
+	os.MkdirAll(outPath, common.DefaultDirectoryPermission)
+	return filepath.Join(outPath, defaultFileName)
 }
 
 func startPlanProgressServer(port int) {
 	logrus.Trace("startPlanProgressServer start")
+	if err := common.CheckSupported("the plan progress server"); err != nil {
+		logrus.Fatalf("failed to start the plan progress server. Error: %q", err)
+	}
 	var server http.Server
 	r := mux.NewRouter()
 	r.HandleFunc("/progress", func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,25 @@
+//go:build !m2k_no_cf
+// +build !m2k_no_cf
+
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package collector
+
+// the Cloud Foundry collectors and the Cloud Foundry client are left out when building with the m2k_no_cf tag
+func init() {
+	builtinCollectors = append(builtinCollectors, new(CfAppsCollector), new(CfServicesCollector))
+}
@@ -22,8 +22,12 @@ type Collector interface {
 	GetAnnotations() []string
 }
 
+// builtinCollectors contains the collectors that are compiled in.
+// The optional collectors add themselves from files selected using build tags.
+var builtinCollectors = []Collector{new(ClusterCollector), new(ImagesCollector)}
+
 // GetCollectors returns different collectors
 func GetCollectors() ([]Collector, error) {
-	collectors := []Collector{new(ClusterCollector), new(ImagesCollector), new(CfAppsCollector), new(CfServicesCollector)}
+	collectors := append([]Collector{}, builtinCollectors...)
 	return collectors, nil
 }
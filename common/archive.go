@@ -0,0 +1,339 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package common
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SupportedArchiveExtensions are the file extensions of the archives that can be used as a source or an output
+var SupportedArchiveExtensions = []string{".zip", ".tar", ".tar.gz", ".tgz"}
+
+// IsSupportedArchive returns true if the path has the extension of a supported archive
+func IsSupportedArchive(archivePath string) bool {
+	return getArchiveExtension(archivePath) != ""
+}
+
+// GetArchiveName returns the name of the archive without the directory and the archive extension
+func GetArchiveName(archivePath string) string {
+	name := filepath.Base(archivePath)
+	return name[:len(name)-len(getArchiveExtension(archivePath))]
+}
+
+func getArchiveExtension(archivePath string) string {
+	name := strings.ToLower(filepath.Base(archivePath))
+	// check the longer extensions first so that .tar.gz is not mistaken for .gz
+	for _, ext := range []string{".tar.gz", ".tgz", ".tar", ".zip"} {
+		if strings.HasSuffix(name, ext) {
+			return ext
+		}
+	}
+	return ""
+}
+
+// ExtractArchive extracts a zip, tar or gzipped tar archive into the destination directory.
+// Entries that would be written outside the destination directory cause an error.
+// Symbolic links and hard links are skipped since they could be used to escape the destination directory.
+func ExtractArchive(archivePath, destDir string) error {
+	if err := os.MkdirAll(destDir, DefaultDirectoryPermission); err != nil {
+		return fmt.Errorf("failed to create the directory at path %s . Error: %w", destDir, err)
+	}
+	switch getArchiveExtension(archivePath) {
+	case ".zip":
+		return extractZip(archivePath, destDir)
+	case ".tar":
+		return extractTar(archivePath, destDir, false)
+	case ".tar.gz", ".tgz":
+		return extractTar(archivePath, destDir, true)
+	}
+	return fmt.Errorf("the file at path %s is not a supported archive. Supported extensions are %+v", archivePath, SupportedArchiveExtensions)
+}
+
+// GetArchiveRootDir returns the directory that contains the project in an extracted archive.
+// Archives created by compressing a project directory have a single top level directory which is used as the project directory.
+// Otherwise the files are at the root of the archive and the extraction directory is returned.
+func GetArchiveRootDir(extractedDir string) (string, error) {
+	entries, err := os.ReadDir(extractedDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the directory at path %s . Error: %w", extractedDir, err)
+	}
+	rootDir := ""
+	for _, entry := range entries {
+		// archives created on macOS contain a directory with the resource forks of the files
+		if entry.Name() == "__MACOSX" {
+			continue
+		}
+		if !entry.IsDir() || rootDir != "" {
+			return extractedDir, nil
+		}
+		rootDir = filepath.Join(extractedDir, entry.Name())
+	}
+	if rootDir == "" {
+		return extractedDir, nil
+	}
+	return rootDir, nil
+}
+
+// getExtractPath returns the path the archive entry should be extracted to, making sure that it is inside the destination directory
+func getExtractPath(destDir, name string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if path.IsAbs(name) || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("the archive entry '%s' has an absolute path", name)
+	}
+	cleanName := path.Clean(name)
+	if cleanName == ".." || strings.HasPrefix(cleanName, "../") {
+		return "", fmt.Errorf("the archive entry '%s' is outside the extraction directory", name)
+	}
+	return filepath.Join(destDir, filepath.FromSlash(cleanName)), nil
+}
+
+func writeExtractedFile(filePath string, mode fs.FileMode, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), DefaultDirectoryPermission); err != nil {
+		return fmt.Errorf("failed to create the parent directory of the file at path %s . Error: %w", filePath, err)
+	}
+	if mode.Perm() == 0 {
+		mode = DefaultFilePermission
+	}
+	f, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return fmt.Errorf("failed to create the file at path %s . Error: %w", filePath, err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return fmt.Errorf("failed to write the file at path %s . Error: %w", filePath, err)
+	}
+	return nil
+}
+
+func extractZip(archivePath, destDir string) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open the zip archive at path %s . Error: %w", archivePath, err)
+	}
+	defer zr.Close()
+	for _, zf := range zr.File {
+		filePath, err := getExtractPath(destDir, zf.Name)
+		if err != nil {
+			return err
+		}
+		mode := zf.Mode()
+		if mode.IsDir() {
+			if err := os.MkdirAll(filePath, DefaultDirectoryPermission); err != nil {
+				return fmt.Errorf("failed to create the directory at path %s . Error: %w", filePath, err)
+			}
+			continue
+		}
+		if !mode.IsRegular() {
+			logrus.Warnf("skipping the entry '%s' in the archive %s since it is not a regular file or directory", zf.Name, archivePath)
+			continue
+		}
+		r, err := zf.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open the entry '%s' in the archive %s . Error: %w", zf.Name, archivePath, err)
+		}
+		err = writeExtractedFile(filePath, mode, r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractTar(archivePath, destDir string, gzipped bool) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open the tar archive at path %s . Error: %w", archivePath, err)
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if gzipped {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("failed to read the gzip compressed archive at path %s . Error: %w", archivePath, err)
+		}
+		defer gr.Close()
+		r = gr
+	}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the tar archive at path %s . Error: %w", archivePath, err)
+		}
+		filePath, err := getExtractPath(destDir, header.Name)
+		if err != nil {
+			return err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(filePath, DefaultDirectoryPermission); err != nil {
+				return fmt.Errorf("failed to create the directory at path %s . Error: %w", filePath, err)
+			}
+		case tar.TypeReg:
+			if err := writeExtractedFile(filePath, header.FileInfo().Mode(), tr); err != nil {
+				return err
+			}
+		case tar.TypeXGlobalHeader:
+		default:
+			logrus.Warnf("skipping the entry '%s' in the archive %s since it is not a regular file or directory", header.Name, archivePath)
+		}
+	}
+}
+
+// CreateArchive creates a zip, tar or gzipped tar archive, depending on the extension of the archive path.
+// The keys of the entries are the paths inside the archive and the values are the files or directories to add at those paths.
+func CreateArchive(archivePath string, entries map[string]string) error {
+	ext := getArchiveExtension(archivePath)
+	if ext == "" {
+		return fmt.Errorf("the file at path %s is not a supported archive. Supported extensions are %+v", archivePath, SupportedArchiveExtensions)
+	}
+	if err := os.MkdirAll(filepath.Dir(archivePath), DefaultDirectoryPermission); err != nil {
+		return fmt.Errorf("failed to create the parent directory of the archive at path %s . Error: %w", archivePath, err)
+	}
+	f, err := os.OpenFile(archivePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, DefaultFilePermission)
+	if err != nil {
+		return fmt.Errorf("failed to create the archive at path %s . Error: %w", archivePath, err)
+	}
+	defer f.Close()
+	names := []string{}
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if ext == ".zip" {
+		zw := zip.NewWriter(f)
+		for _, name := range names {
+			if err := walkArchiveEntry(name, entries[name], func(name, filePath string, info fs.FileInfo) error {
+				return addZipEntry(zw, name, filePath, info)
+			}); err != nil {
+				return err
+			}
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("failed to finish writing the zip archive at path %s . Error: %w", archivePath, err)
+		}
+		return nil
+	}
+	var w io.Writer = f
+	var gw *gzip.Writer
+	if ext != ".tar" {
+		gw = gzip.NewWriter(f)
+		w = gw
+	}
+	tw := tar.NewWriter(w)
+	for _, name := range names {
+		if err := walkArchiveEntry(name, entries[name], func(name, filePath string, info fs.FileInfo) error {
+			return addTarEntry(tw, name, filePath, info)
+		}); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish writing the tar archive at path %s . Error: %w", archivePath, err)
+	}
+	if gw != nil {
+		if err := gw.Close(); err != nil {
+			return fmt.Errorf("failed to finish compressing the archive at path %s . Error: %w", archivePath, err)
+		}
+	}
+	return nil
+}
+
+// walkArchiveEntry calls add for the file or for every file and directory inside the directory, with the path they should have in the archive
+func walkArchiveEntry(entryName, entryPath string, add func(name, filePath string, info fs.FileInfo) error) error {
+	return filepath.Walk(entryPath, func(filePath string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to walk the path %s . Error: %w", filePath, err)
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			logrus.Debugf("skipping the path %s since it is not a regular file or directory", filePath)
+			return nil
+		}
+		relPath, err := filepath.Rel(entryPath, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to make the path %s relative to %s . Error: %w", filePath, entryPath, err)
+		}
+		return add(path.Join(filepath.ToSlash(entryName), filepath.ToSlash(relPath)), filePath, info)
+	})
+}
+
+func addZipEntry(zw *zip.Writer, name, filePath string, info fs.FileInfo) error {
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return fmt.Errorf("failed to create the zip header for the path %s . Error: %w", filePath, err)
+	}
+	header.Name = name
+	if info.IsDir() {
+		header.Name += "/"
+	} else {
+		header.Method = zip.Deflate
+	}
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("failed to add the path %s to the zip archive. Error: %w", filePath, err)
+	}
+	if info.IsDir() {
+		return nil
+	}
+	return copyFileTo(w, filePath)
+}
+
+func addTarEntry(tw *tar.Writer, name, filePath string, info fs.FileInfo) error {
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return fmt.Errorf("failed to create the tar header for the path %s . Error: %w", filePath, err)
+	}
+	header.Name = name
+	if info.IsDir() {
+		header.Name += "/"
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to add the path %s to the tar archive. Error: %w", filePath, err)
+	}
+	if info.IsDir() {
+		return nil
+	}
+	return copyFileTo(tw, filePath)
+}
+
+func copyFileTo(w io.Writer, filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open the file at path %s . Error: %w", filePath, err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(w, f); err != nil {
+		return fmt.Errorf("failed to copy the file at path %s to the archive. Error: %w", filePath, err)
+	}
+	return nil
+}
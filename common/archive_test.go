@@ -0,0 +1,155 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package common
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func createTestZip(t *testing.T, archivePath string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("failed to create the zip archive. Error: %q", err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("failed to add the entry %s to the zip archive. Error: %q", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write the entry %s to the zip archive. Error: %q", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close the zip archive. Error: %q", err)
+	}
+}
+
+func TestExtractArchiveLayouts(t *testing.T) {
+	testcases := []struct {
+		name        string
+		files       map[string]string
+		wantRootDir string
+	}{
+		{
+			name:        "files at the root of the archive",
+			files:       map[string]string{"package.json": "{}", "index.js": ""},
+			wantRootDir: "",
+		},
+		{
+			name:        "files in a single top level directory",
+			files:       map[string]string{"nodeapp/package.json": "{}", "nodeapp/index.js": ""},
+			wantRootDir: "nodeapp",
+		},
+		{
+			name:        "files in a single top level directory with macOS metadata",
+			files:       map[string]string{"nodeapp/package.json": "{}", "__MACOSX/nodeapp/._package.json": ""},
+			wantRootDir: "nodeapp",
+		},
+		{
+			name:        "files in multiple top level directories",
+			files:       map[string]string{"frontend/package.json": "{}", "backend/package.json": "{}"},
+			wantRootDir: "",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			archivePath := filepath.Join(tempDir, "project.zip")
+			createTestZip(t, archivePath, tc.files)
+			extractedDir := filepath.Join(tempDir, GetArchiveName(archivePath))
+			if err := ExtractArchive(archivePath, extractedDir); err != nil {
+				t.Fatalf("failed to extract the archive. Error: %q", err)
+			}
+			rootDir, err := GetArchiveRootDir(extractedDir)
+			if err != nil {
+				t.Fatalf("failed to get the root directory of the archive. Error: %q", err)
+			}
+			if want := filepath.Join(extractedDir, tc.wantRootDir); rootDir != want {
+				t.Fatalf("expected the root directory to be %s . Actual: %s", want, rootDir)
+			}
+			for name, content := range tc.files {
+				data, err := os.ReadFile(filepath.Join(extractedDir, filepath.FromSlash(name)))
+				if err != nil {
+					t.Fatalf("failed to read the extracted file %s . Error: %q", name, err)
+				}
+				if string(data) != content {
+					t.Fatalf("expected the extracted file %s to contain %q . Actual: %q", name, content, string(data))
+				}
+			}
+		})
+	}
+}
+
+func TestGetArchiveName(t *testing.T) {
+	testcases := map[string]string{
+		"/tmp/project.zip":    "project",
+		"project.tar":         "project",
+		"/tmp/Project.TAR.GZ": "Project",
+		"project.v1.tgz":      "project.v1",
+	}
+	for archivePath, want := range testcases {
+		if name := GetArchiveName(archivePath); name != want {
+			t.Errorf("expected the name of the archive %s to be %s . Actual: %s", archivePath, want, name)
+		}
+	}
+}
+
+func TestGetExtractPath(t *testing.T) {
+	destDir := filepath.Join(string(filepath.Separator), "tmp", "dest")
+	testcases := []struct {
+		name    string
+		entry   string
+		want    string
+		wantErr bool
+	}{
+		{name: "file at the root", entry: "package.json", want: filepath.Join(destDir, "package.json")},
+		{name: "nested file", entry: "src/main/app.go", want: filepath.Join(destDir, "src", "main", "app.go")},
+		{name: "parent reference that stays inside", entry: "src/../app.go", want: filepath.Join(destDir, "app.go")},
+		{name: "current directory prefix", entry: "./app.go", want: filepath.Join(destDir, "app.go")},
+		{name: "backslash separators", entry: `src\app.go`, want: filepath.Join(destDir, "src", "app.go")},
+		{name: "parent directory", entry: "..", wantErr: true},
+		{name: "file in the parent directory", entry: "../app.go", wantErr: true},
+		{name: "nested parent reference that escapes", entry: "src/../../app.go", wantErr: true},
+		{name: "backslash parent reference", entry: `..\app.go`, wantErr: true},
+		{name: "absolute path", entry: "/etc/passwd", wantErr: true},
+		{name: "absolute path with backslashes", entry: `\etc\passwd`, wantErr: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			extractPath, err := getExtractPath(destDir, tc.entry)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for the entry %s . Actual path: %s", tc.entry, extractPath)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get the extract path for the entry %s . Error: %q", tc.entry, err)
+			}
+			if extractPath != tc.want {
+				t.Fatalf("expected the extract path to be %s . Actual: %s", tc.want, extractPath)
+			}
+		})
+	}
+}
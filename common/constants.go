@@ -95,6 +95,22 @@ const (
 	ConfigSpawnContainersKey = BaseKey + d + "spawncontainers"
 	//ConfigTransformersKey represents transformers Key
 	ConfigTransformersKey = BaseKey + d + "transformers"
+	//ConfigServiceNamingKey represents the service naming Key
+	ConfigServiceNamingKey = BaseKey + d + "servicenaming"
+	//ConfigServiceNamingStrategyKey represents the service naming strategy Key
+	ConfigServiceNamingStrategyKey = ConfigServiceNamingKey + d + "strategy"
+	//ConfigServiceNamingRenameRulesKey represents the service rename rules Key
+	ConfigServiceNamingRenameRulesKey = ConfigServiceNamingKey + d + "renamerules"
+	//ConfigServiceNamingPrefixKey represents the service name prefix Key
+	ConfigServiceNamingPrefixKey = ConfigServiceNamingKey + d + "prefix"
+	//ConfigServiceNamingPatternKey represents the service name pattern Key
+	ConfigServiceNamingPatternKey = ConfigServiceNamingKey + d + "pattern"
+	//ConfigServiceNamingMaxLengthKey represents the service name max length Key
+	ConfigServiceNamingMaxLengthKey = ConfigServiceNamingKey + d + "maxlength"
+	//ConfigPlanEditOperationsKey represents the operations to merge, split and rename the planned services
+	ConfigPlanEditOperationsKey = BaseKey + d + "plan" + d + "edit" + d + "operations"
+	//ConfigOutputProvenanceHeadersKey represents the option to add provenance header comments to the generated files
+	ConfigOutputProvenanceHeadersKey = BaseKey + d + "output" + d + "provenanceheaders"
 	//ConfigTargetKey represents Target Key
 	ConfigTargetKey = BaseKey + d + "target"
 	//ConfigRepoKey represents Repo Key
@@ -115,6 +131,10 @@ const (
 	ConfigRepoLoadPrivKey = ConfigRepoKeysKey + d + "load"
 	//ConfigRepoKeyPathsKey represents paths of keyfiles
 	ConfigRepoKeyPathsKey = ConfigRepoKeysKey + d + "paths"
+	//ConfigTransformersConflictPolicyKey represents the global policy for resolving output path collisions
+	ConfigTransformersConflictPolicyKey = ConfigTransformersKey + d + "conflictpolicy"
+	//ConfigTransformersParallelismKey represents the number of transformers that can run concurrently in an iteration
+	ConfigTransformersParallelismKey = ConfigTransformersKey + d + "parallelism"
 	//ConfigTransformerTypesKey represents Transformers type Key
 	ConfigTransformerTypesKey = ConfigTransformersKey + d + "types"
 	//VolQaPrefixKey represents the storage QA
@@ -169,6 +189,8 @@ const (
 	ConfigActiveMavenProfilesForServiceKeySegment = "activemavenprofiles"
 	// ConfigActiveSpringBootProfilesForServiceKeySegment represent the springboot profiles used for service
 	ConfigActiveSpringBootProfilesForServiceKeySegment = "activespringbootprofiles"
+	// ConfigServicesTransformationOptionKey is the transformation option selected for a service that has more than one option
+	ConfigServicesTransformationOptionKey = ConfigServicesKey + d + "%s" + d + "transformationoption"
 	// ConfigServicesChildModulesNamesKey is true if a detected child module/sub-project of a service is enabled for transformation
 	ConfigServicesChildModulesNamesKey = ConfigServicesKey + d + "%s" + d + "childModules" + d + Special + d + "enable"
 	// ConfigServicesDotNetChildProjectsNamesKey is true if a detected child-project of a dot net service is enabled for transformation
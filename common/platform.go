@@ -0,0 +1,37 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// IsWASI is true when running as a WASI module, where sockets, processes and containers are not available
+const IsWASI = runtime.GOOS == "wasip1"
+
+// ErrUnsupportedOnWASI is returned when a feature that needs sockets, processes or containers is used in a WASI module
+var ErrUnsupportedOnWASI = errors.New("unsupported on wasip1")
+
+// CheckSupported returns an error if the feature needs sockets, processes or containers and we are running as a WASI module
+func CheckSupported(feature string) error {
+	if IsWASI {
+		return fmt.Errorf("%s is %w", feature, ErrUnsupportedOnWASI)
+	}
+	return nil
+}
@@ -17,6 +17,7 @@
 package sshkeys
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/rsa"
 	"crypto/x509"
@@ -51,7 +52,7 @@ var (
 )
 
 // LoadKnownHostsOfCurrentUser loads the public keys from known_hosts
-func LoadKnownHostsOfCurrentUser() {
+func LoadKnownHostsOfCurrentUser(ctx context.Context) {
 	if !firstTimeLoadingKnownHostsOfUser {
 		return
 	}
@@ -71,7 +72,7 @@ func LoadKnownHostsOfCurrentUser() {
 	Move2Kube has public keys for github.com, gitlab.com, and bitbucket.org by default.
 	If any of the repos use ssh authentication we will need public keys in order to verify.
 	Do you want to load the public keys from your [%s]?:`
-	ans := qaengine.FetchBoolAnswer(common.ConfigRepoLoadPubKey, fmt.Sprintf(message, knownHostsPath), []string{"No, I will add them later if necessary."}, false, nil)
+	ans := qaengine.FetchBoolAnswer(ctx, common.ConfigRepoLoadPubKey, fmt.Sprintf(message, knownHostsPath), []string{"No, I will add them later if necessary."}, false, nil)
 	if !ans {
 		logrus.Debug("Don't read public keys from known_hosts. They will be added later if necessary.")
 		return
@@ -90,7 +91,7 @@ func LoadKnownHostsOfCurrentUser() {
 	logrus.Debug("DomainToPublicKeys:", DomainToPublicKeys)
 }
 
-func loadSSHKeysOfCurrentUser() {
+func loadSSHKeysOfCurrentUser(ctx context.Context) {
 	if !firstTimeLoadingSSHKeysOfUser {
 		return
 	}
@@ -114,10 +115,10 @@ func loadSSHKeysOfCurrentUser() {
 	message := `The CI/CD pipeline needs access to the git repos in order to clone, build and push.
 	If any of the repos require ssh keys you will need to provide them.
 	Select an option:`
-	selectedOption := qaengine.FetchSelectAnswer(common.ConfigRepoLoadPrivKey, message, nil, "", options, nil)
+	selectedOption := qaengine.FetchSelectAnswer(ctx, common.ConfigRepoLoadPrivKey, message, nil, "", options, nil)
 	switch selectedOption {
 	case options[0]:
-		selectedKeyFilenames, err := loadKeysFromDirectory(privateKeyDir)
+		selectedKeyFilenames, err := loadKeysFromDirectory(ctx, privateKeyDir)
 		if err != nil {
 			logrus.Warnf("Failed to load the keys from the SSH directory '%s'. Error: %q", privateKeyDir, err)
 			return
@@ -132,7 +133,7 @@ func loadSSHKeysOfCurrentUser() {
 	}
 }
 
-func loadKeysFromDirectory(directory string) ([]string, error) {
+func loadKeysFromDirectory(ctx context.Context, directory string) ([]string, error) {
 	finfos, err := os.ReadDir(directory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read the directory '%s'. Error: %w", directory, err)
@@ -145,6 +146,7 @@ func loadKeysFromDirectory(directory string) ([]string, error) {
 		filenames = append(filenames, finfo.Name())
 	}
 	selectedFilenames := qaengine.FetchMultiSelectAnswer(
+		ctx,
 		common.ConfigRepoKeyPathsKey,
 		fmt.Sprintf("These are the files we found in the SSH directory '%s'. Select the keys to consider:", directory),
 		[]string{"Select all the keys that give access to the git repos."},
@@ -179,7 +181,7 @@ func marshalECDSAIntoPEM(key *ecdsa.PrivateKey) string {
 
 // loadSSHPrivateKeyFromBytes tries to parse the bytes as an SSH private key.
 // The keyName is optional (used to ask the user for the password if necessary).
-func loadSSHPrivateKeyFromBytes(keyBytes []byte, keyName string) (string, error) {
+func loadSSHPrivateKeyFromBytes(ctx context.Context, keyBytes []byte, keyName string) (string, error) {
 	key, err := ssh.ParseRawPrivateKey(keyBytes)
 	if err != nil {
 		// Could be an encrypted private key.
@@ -189,7 +191,7 @@ func loadSSHPrivateKeyFromBytes(keyBytes []byte, keyName string) (string, error)
 		qaKey := common.JoinQASubKeys(common.ConfigRepoPrivKey, `"`+keyName+`"`, "password")
 		desc := fmt.Sprintf("Enter the password to decrypt the SSH private key '%s' : ", keyName)
 		hints := []string{"Password:"}
-		password := qaengine.FetchPasswordAnswer(qaKey, desc, hints, nil)
+		password := qaengine.FetchPasswordAnswer(ctx, qaKey, desc, hints, nil)
 		key, err = ssh.ParseRawPrivateKeyWithPassphrase(keyBytes, []byte(password))
 		if err != nil {
 			return "", fmt.Errorf("failed to decrypt and parse the encrypted private SSH key '%s' . Error %w", keyName, err)
@@ -206,18 +208,18 @@ func loadSSHPrivateKeyFromBytes(keyBytes []byte, keyName string) (string, error)
 	}
 }
 
-func loadSSHPrivateKey(filename string) (string, error) {
+func loadSSHPrivateKey(ctx context.Context, filename string) (string, error) {
 	path := filepath.Join(privateKeyDir, filename)
 	fileBytes, err := os.ReadFile(path)
 	if err != nil {
 		return "", fmt.Errorf("failed to read the SSH private key file '%s' . Error: %w", path, err)
 	}
-	return loadSSHPrivateKeyFromBytes(fileBytes, filename)
+	return loadSSHPrivateKeyFromBytes(ctx, fileBytes, filename)
 }
 
 // GetSSHKey returns the private key for the given domain.
-func GetSSHKey(domain string) (string, bool) {
-	loadSSHKeysOfCurrentUser()
+func GetSSHKey(ctx context.Context, domain string) (string, bool) {
+	loadSSHKeysOfCurrentUser(ctx)
 	if len(privateKeysToConsider) == 0 {
 		return "", false
 	}
@@ -226,6 +228,7 @@ func GetSSHKey(domain string) (string, bool) {
 		qaKey := common.JoinQASubKeys(common.ConfigRepoKeysKey, `"`+domain+`"`, "keyData")
 		validatedKey := ""
 		key := qaengine.FetchStringAnswer(
+			ctx,
 			qaKey,
 			fmt.Sprintf("Provide a PEM-formatted SSH private key for the domain '%s':", domain),
 			[]string{"To skip this question, just leave the answer empty"},
@@ -235,7 +238,7 @@ func GetSSHKey(domain string) (string, bool) {
 				if ans == "" {
 					return nil
 				}
-				t1, err := loadSSHPrivateKeyFromBytes([]byte(ans), domain)
+				t1, err := loadSSHPrivateKeyFromBytes(ctx, []byte(ans), domain)
 				if err == nil {
 					validatedKey = t1
 				}
@@ -255,14 +258,14 @@ func GetSSHKey(domain string) (string, bool) {
 	qaKey := common.JoinQASubKeys(common.ConfigRepoKeysKey, `"`+domain+`"`, "key")
 	desc := fmt.Sprintf("Select the key to use for the git domain '%s' :", domain)
 	hints := []string{fmt.Sprintf("If none of the keys are correct, select '%s'", noAnswer)}
-	filename := qaengine.FetchSelectAnswer(qaKey, desc, hints, noAnswer, filenames, nil)
+	filename := qaengine.FetchSelectAnswer(ctx, qaKey, desc, hints, noAnswer, filenames, nil)
 	if filename == noAnswer {
 		logrus.Debugf("No key was selected for domain '%s'", domain)
 		return "", false
 	}
 
 	logrus.Debug("Loading the key", filename)
-	key, err := loadSSHPrivateKey(filename)
+	key, err := loadSSHPrivateKey(ctx, filename)
 	if err != nil {
 		logrus.Warnf("Failed to load the SSH private key file '%s' . Error %q", filename, err)
 		return "", false
@@ -28,6 +28,7 @@ import (
 	"fmt"
 	"github.com/Masterminds/sprig"
 	"github.com/docker/docker/pkg/ioutils"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/types"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/cast"
@@ -77,7 +78,7 @@ func WriteYaml(outputPath string, data interface{}) error {
 		logrus.Errorf("Failed to encode the object as a yaml string. Error: %q", err)
 		return err
 	}
-	return os.WriteFile(outputPath, yamlBytes, DefaultFilePermission)
+	return vfs.WriteFile(outputPath, yamlBytes, DefaultFilePermission)
 }
 
 // IsParent can be used to check if a path is one of the parent directories of another path.
@@ -122,7 +123,7 @@ func IsPresent[C comparable](list []C, value C) bool {
 // The dst file will be truncated if it exists.
 // Returns an error if it failed to copy all the bytes.
 func CopyFile(dst, src string) error {
-	srcfile, err := os.Open(src)
+	srcfile, err := vfs.Open(src)
 	if err != nil {
 		return fmt.Errorf("failed to open the source file at path %q Error: %q", src, err)
 	}
@@ -132,7 +133,7 @@ func CopyFile(dst, src string) error {
 		return fmt.Errorf("failed to get size of the source file at path %q Error: %q", src, err)
 	}
 	srcfilesize := srcfileinfo.Size()
-	dstfile, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, srcfileinfo.Mode())
+	dstfile, err := vfs.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, srcfileinfo.Mode())
 	if err != nil {
 		return fmt.Errorf("failed to create the destination file at path %q Error: %q", dst, err)
 	}
@@ -150,7 +151,7 @@ func CopyFile(dst, src string) error {
 // GetFilesByName returns files by name
 func GetFilesByName(inputPath string, names []string, nameRegexes []string) ([]string, error) {
 	var files []string
-	if info, err := os.Stat(inputPath); os.IsNotExist(err) {
+	if info, err := vfs.Stat(inputPath); os.IsNotExist(err) {
 		return files, fmt.Errorf("failed to stat the directory '%s' . Error: %w", inputPath, err)
 	} else if !info.IsDir() {
 		logrus.Warnf("The path '%s' is not a directory.", inputPath)
@@ -164,7 +165,7 @@ func GetFilesByName(inputPath string, names []string, nameRegexes []string) ([]s
 		}
 		compiledNameRegexes = append(compiledNameRegexes, compiledNameRegex)
 	}
-	err := filepath.WalkDir(inputPath, func(path string, info os.DirEntry, err error) error {
+	err := vfs.WalkDir(inputPath, func(path string, info os.DirEntry, err error) error {
 		if err != nil {
 			if path == inputPath {
 				// if the root directory returns an error then stop walking and return this error
@@ -313,7 +314,7 @@ func GetObjFromInterface(obj interface{}, loadinto interface{}) error {
 // It checks if apiVersion to see if the group is move2kube and also reports if the
 // version is different from the expected version.
 func ReadMove2KubeYaml(path string, out interface{}) error {
-	yamlData, err := os.ReadFile(path)
+	yamlData, err := vfs.ReadFile(path)
 	if err != nil {
 		logrus.Errorf("Failed to read the yaml file at path %s Error: %q", path, err)
 		return err
@@ -426,12 +427,12 @@ func GetTypesMap(typeInstances interface{}) (typesMap map[string]reflect.Type) {
 // GetFilesByExt returns files by extension
 func GetFilesByExt(inputPath string, exts []string) ([]string, error) {
 	var files []string
-	if info, err := os.Stat(inputPath); os.IsNotExist(err) {
+	if info, err := vfs.Stat(inputPath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("failed to stat the directory '%s' . Error: %w", inputPath, err)
 	} else if !info.IsDir() {
 		logrus.Warnf("The path '%s' is not a directory.", inputPath)
 	}
-	err := filepath.WalkDir(inputPath, func(path string, info os.DirEntry, err error) error {
+	err := vfs.WalkDir(inputPath, func(path string, info os.DirEntry, err error) error {
 		if err != nil {
 			if path == inputPath {
 				// if the root directory returns an error then stop walking and return this error
@@ -505,7 +506,7 @@ func CreateAssetsData(assetsFS embed.FS, permissions map[string]int) (assetsPath
 	}
 
 	// Try to create a new temporary directory for the assets.
-	if newTempPath, err := os.MkdirTemp("", types.AppName+"*"); err != nil {
+	if newTempPath, err := vfs.MkdirTemp("", types.AppName+"*"); err != nil {
 		logrus.Errorf("failed to create a temporary directory for the assets. Defaulting to the local path '%s' . Error: %q", tempPath, err)
 	} else {
 		tempPath = newTempPath
@@ -513,14 +514,14 @@ func CreateAssetsData(assetsFS embed.FS, permissions map[string]int) (assetsPath
 	}
 
 	// Try to create a new temporary directory for the remote source folders.
-	if newTempPath, err := os.MkdirTemp("", types.AppName+"*"); err != nil {
+	if newTempPath, err := vfs.MkdirTemp("", types.AppName+"*"); err != nil {
 		logrus.Errorf("failed to create a temporary directory for the remote sources. Defaulting to the local path '%s' . Error: %q", remoteTempPath, err)
 	} else {
 		remoteTempPath = newTempPath
 	}
 
 	// Either way create the subdirectory and untar the assets into it.
-	if err := os.MkdirAll(assetsPath, DefaultDirectoryPermission); err != nil {
+	if err := vfs.MkdirAll(assetsPath, DefaultDirectoryPermission); err != nil {
 		return "", "", "", fmt.Errorf("failed to create the assets directory at path '%s' . Error: %w", assetsPath, err)
 	}
 	if err := CopyEmbedFSToDir(assetsFS, ".", assetsPath, permissions); err != nil {
@@ -545,7 +546,7 @@ func CopyEmbedFSToDir(embedFS embed.FS, source, dest string, permissions map[str
 		if !ok {
 			logrus.Errorf("permissions missing for the file '%s' . Do `make generate` to update permissions file.", dest)
 		}
-		df, err := os.OpenFile(dest, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(permission))
+		df, err := vfs.OpenFile(dest, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(permission))
 		if err != nil {
 			return fmt.Errorf("failed to open the temporary dest assets file '%s' . Error: %w", dest, err)
 		}
@@ -559,7 +560,7 @@ func CopyEmbedFSToDir(embedFS embed.FS, source, dest string, permissions map[str
 		}
 		return nil
 	}
-	if err := os.MkdirAll(dest, DefaultDirectoryPermission); err != nil {
+	if err := vfs.MkdirAll(dest, DefaultDirectoryPermission); err != nil {
 		return fmt.Errorf("failed to create the destination directory at '%s' . Error: %w", dest, err)
 	}
 	dirEntries, err := embedFS.ReadDir(sourceUnixPath)
@@ -789,7 +790,7 @@ func MergeStringMaps(map1 map[string]string, map2 map[string]string) map[string]
 
 // ReadXML reads an json into an object
 func ReadXML(file string, data interface{}) error {
-	xmlFile, err := os.ReadFile(file)
+	xmlFile, err := vfs.ReadFile(file)
 	if err != nil {
 		return fmt.Errorf("failed to read the xml file at path '%s' . Error: %w", file, err)
 	}
@@ -861,7 +862,7 @@ func MakeFileNameCompliant(name string) string {
 
 // ReadYaml reads an yaml into an object
 func ReadYaml(file string, data interface{}) error {
-	yamlFile, err := os.ReadFile(file)
+	yamlFile, err := vfs.ReadFile(file)
 	if err != nil {
 		logrus.Debugf("Error in reading yaml file %s: %s.", file, err)
 		return err
@@ -945,7 +946,7 @@ func StripQuotes(s string) string {
 func GetFilesInCurrentDirectory(path string, fileNames, fileNameRegexes []string) (matchedFilePaths []string, err error) {
 	matchedFilePaths = []string{}
 	currFileNames := []string{}
-	info, err := os.Stat(path)
+	info, err := vfs.Stat(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to stat the directory at path %s . Error: %q", path, err)
 	}
@@ -953,15 +954,13 @@ func GetFilesInCurrentDirectory(path string, fileNames, fileNameRegexes []string
 		logrus.Warnf("the provided path %s is not a directory. info: %+v", path, info)
 		currFileNames = append(currFileNames, path)
 	} else {
-		dirHandle, err := os.Open(path)
-		if err != nil {
-			return nil, fmt.Errorf("failed to open the directory %s . Error: %q", path, err)
-		}
-		defer dirHandle.Close()
-		currFileNames, err = dirHandle.Readdirnames(0) // 0 to read all files and folders
+		dirEntries, err := vfs.ReadDir(path)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get the list of files in the directory %s . Error: %q", path, err)
 		}
+		for _, dirEntry := range dirEntries {
+			currFileNames = append(currFileNames, dirEntry.Name())
+		}
 	}
 	compiledNameRegexes := []*regexp.Regexp{}
 	for _, nameRegex := range fileNameRegexes {
@@ -992,7 +991,7 @@ func GetFilesInCurrentDirectory(path string, fileNames, fileNameRegexes []string
 // GetFilesByExtInCurrDir returns the files present in current directory which have one of the specified extensions
 func GetFilesByExtInCurrDir(dir string, exts []string) ([]string, error) {
 	var files []string
-	info, err := os.Stat(dir)
+	info, err := vfs.Stat(dir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to stat the directory '%s' . Error: %w", dir, err)
 	}
@@ -1006,7 +1005,7 @@ func GetFilesByExtInCurrDir(dir string, exts []string) ([]string, error) {
 		}
 		return nil, nil
 	}
-	dirEntries, err := os.ReadDir(dir)
+	dirEntries, err := vfs.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read the directory '%s' . Error: %w", dir, err)
 	}
@@ -1039,7 +1038,7 @@ func ConvertUtf8AndUtf16ToUtf8(original []byte) ([]byte, error) {
 
 // ReadJSON reads an json into an object
 func ReadJSON(path string, data interface{}) error {
-	jsonBytes, err := os.ReadFile(path)
+	jsonBytes, err := vfs.ReadFile(path)
 	if err != nil {
 		return fmt.Errorf("failed to read the json file at path '%s' . Error: %w", path, err)
 	}
@@ -1208,7 +1207,7 @@ func GetRandomString() string {
 // ReadMove2KubeYamlStrict is like ReadMove2KubeYaml but returns an error
 // when it finds unknown fields in the yaml
 func ReadMove2KubeYamlStrict(path string, out interface{}, kind string) error {
-	yamlData, err := os.ReadFile(path)
+	yamlData, err := vfs.ReadFile(path)
 	if err != nil {
 		logrus.Debugf("Failed to read the yaml file at path %s Error: %q", path, err)
 		return err
@@ -0,0 +1,495 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package vfs
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// memTempDir is the directory used by MkdirTemp when no directory is given
+	memTempDir = "/tmp"
+)
+
+var (
+	errNotDir   = errors.New("not a directory")
+	errIsDir    = errors.New("is a directory")
+	errNotEmpty = errors.New("directory not empty")
+	errClosed   = errors.New("file already closed")
+)
+
+// MemFileSystem is a filesystem that keeps the files in memory.
+// Relative paths are relative to the root directory and symbolic links are not supported.
+type MemFileSystem struct {
+	mutex sync.RWMutex
+	root  *memNode
+}
+
+type memNode struct {
+	name     string
+	mode     fs.FileMode
+	modTime  time.Time
+	data     []byte
+	children map[string]*memNode
+}
+
+// NewMemFileSystem returns an empty in-memory filesystem
+func NewMemFileSystem() *MemFileSystem {
+	return &MemFileSystem{root: newMemDir(string(filepath.Separator), fs.ModePerm)}
+}
+
+func newMemDir(name string, perm fs.FileMode) *memNode {
+	return &memNode{name: name, mode: fs.ModeDir | perm.Perm(), modTime: time.Now(), children: map[string]*memNode{}}
+}
+
+func (n *memNode) info() fs.FileInfo {
+	return &memFileInfo{name: n.name, size: int64(len(n.data)), mode: n.mode, modTime: n.modTime}
+}
+
+// entries returns the directory entries sorted by name
+func (n *memNode) entries() []fs.DirEntry {
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	entries := make([]fs.DirEntry, 0, len(names))
+	for _, name := range names {
+		entries = append(entries, fs.FileInfoToDirEntry(n.children[name].info()))
+	}
+	return entries
+}
+
+// splitPath returns the names of the directories and the file in the path, starting from the root
+func splitPath(name string) []string {
+	name = filepath.Join(string(filepath.Separator), name)
+	if name == string(filepath.Separator) {
+		return nil
+	}
+	return strings.Split(strings.TrimPrefix(name, string(filepath.Separator)), string(filepath.Separator))
+}
+
+func (m *MemFileSystem) lookup(op, name string) (*memNode, error) {
+	node := m.root
+	for _, part := range splitPath(name) {
+		if !node.mode.IsDir() {
+			return nil, &fs.PathError{Op: op, Path: name, Err: errNotDir}
+		}
+		child, ok := node.children[part]
+		if !ok {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		node = child
+	}
+	return node, nil
+}
+
+// lookupParent returns the directory containing the path and the base name of the path
+func (m *MemFileSystem) lookupParent(op, name string) (*memNode, string, error) {
+	parts := splitPath(name)
+	if len(parts) == 0 {
+		return nil, "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	parent, err := m.lookup(op, filepath.Join(append([]string{string(filepath.Separator)}, parts[:len(parts)-1]...)...))
+	if err != nil {
+		return nil, "", &fs.PathError{Op: op, Path: name, Err: errors.Unwrap(err)}
+	}
+	if !parent.mode.IsDir() {
+		return nil, "", &fs.PathError{Op: op, Path: name, Err: errNotDir}
+	}
+	return parent, parts[len(parts)-1], nil
+}
+
+// Open opens the named file or directory for reading
+func (m *MemFileSystem) Open(name string) (fs.File, error) {
+	return m.OpenFile(name, os.O_RDONLY, 0)
+}
+
+// Stat returns the file info of the named file
+func (m *MemFileSystem) Stat(name string) (fs.FileInfo, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	node, err := m.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return node.info(), nil
+}
+
+// Lstat returns the file info of the named file, it is the same as Stat since there are no symbolic links
+func (m *MemFileSystem) Lstat(name string) (fs.FileInfo, error) {
+	return m.Stat(name)
+}
+
+// ReadDir returns the entries of the named directory sorted by name
+func (m *MemFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	node, err := m.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !node.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errNotDir}
+	}
+	return node.entries(), nil
+}
+
+// ReadFile returns the contents of the named file
+func (m *MemFileSystem) ReadFile(name string) ([]byte, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	node, err := m.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if node.mode.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errIsDir}
+	}
+	return append([]byte{}, node.data...), nil
+}
+
+// Readlink always fails since symbolic links are not supported
+func (m *MemFileSystem) Readlink(name string) (string, error) {
+	return "", &fs.PathError{Op: "readlink", Path: name, Err: errors.ErrUnsupported}
+}
+
+// Create creates or truncates the named file
+func (m *MemFileSystem) Create(name string) (File, error) {
+	return m.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+}
+
+// OpenFile opens the named file with the flags of os.OpenFile
+func (m *MemFileSystem) OpenFile(name string, flag int, perm fs.FileMode) (File, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	writable := flag&(os.O_WRONLY|os.O_RDWR) != 0
+	node, err := m.lookup("open", name)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) || flag&os.O_CREATE == 0 {
+			return nil, err
+		}
+		parent, base, err := m.lookupParent("open", name)
+		if err != nil {
+			return nil, err
+		}
+		node = &memNode{name: base, mode: perm.Perm(), modTime: time.Now()}
+		parent.children[base] = node
+	} else {
+		if flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0 {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrExist}
+		}
+		if node.mode.IsDir() && writable {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: errIsDir}
+		}
+		if writable && flag&os.O_TRUNC != 0 {
+			node.data = nil
+			node.modTime = time.Now()
+		}
+	}
+	return &memFile{fsys: m, node: node, path: name, readable: flag&os.O_WRONLY == 0, writable: writable, append: flag&os.O_APPEND != 0}, nil
+}
+
+// WriteFile writes the data to the named file, creating it if necessary
+func (m *MemFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	f, err := m.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	return err
+}
+
+// Mkdir creates the named directory
+func (m *MemFileSystem) Mkdir(name string, perm fs.FileMode) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	parent, base, err := m.lookupParent("mkdir", name)
+	if err != nil {
+		return err
+	}
+	if _, ok := parent.children[base]; ok {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrExist}
+	}
+	parent.children[base] = newMemDir(base, perm)
+	return nil
+}
+
+// MkdirAll creates the named directory along with its parents
+func (m *MemFileSystem) MkdirAll(name string, perm fs.FileMode) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	node := m.root
+	for _, part := range splitPath(name) {
+		child, ok := node.children[part]
+		if !ok {
+			child = newMemDir(part, perm)
+			node.children[part] = child
+		} else if !child.mode.IsDir() {
+			return &fs.PathError{Op: "mkdir", Path: name, Err: errNotDir}
+		}
+		node = child
+	}
+	return nil
+}
+
+// MkdirTemp creates a new directory in the directory dir, the last * in the pattern is replaced by a random string
+func (m *MemFileSystem) MkdirTemp(dir, pattern string) (string, error) {
+	if dir == "" {
+		dir = memTempDir
+		if err := m.MkdirAll(dir, fs.ModePerm); err != nil {
+			return "", err
+		}
+	}
+	prefix, suffix := pattern, ""
+	if i := strings.LastIndex(pattern, "*"); i >= 0 {
+		prefix, suffix = pattern[:i], pattern[i+1:]
+	}
+	for {
+		name := filepath.Join(dir, prefix+strconv.FormatUint(uint64(rand.Uint32()), 10)+suffix)
+		err := m.Mkdir(name, 0700)
+		if err == nil {
+			return name, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return "", err
+		}
+	}
+}
+
+// Remove removes the named file or empty directory
+func (m *MemFileSystem) Remove(name string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	parent, base, err := m.lookupParent("remove", name)
+	if err != nil {
+		return err
+	}
+	node, ok := parent.children[base]
+	if !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	if node.mode.IsDir() && len(node.children) > 0 {
+		return &fs.PathError{Op: "remove", Path: name, Err: errNotEmpty}
+	}
+	delete(parent.children, base)
+	return nil
+}
+
+// RemoveAll removes the path and everything it contains, it does not fail if the path does not exist
+func (m *MemFileSystem) RemoveAll(name string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if len(splitPath(name)) == 0 {
+		m.root.children = map[string]*memNode{}
+		return nil
+	}
+	parent, base, err := m.lookupParent("removeall", name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	delete(parent.children, base)
+	return nil
+}
+
+// Rename moves oldpath to newpath, replacing newpath if it is a file or an empty directory
+func (m *MemFileSystem) Rename(oldpath, newpath string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	oldParent, oldBase, err := m.lookupParent("rename", oldpath)
+	if err != nil {
+		return err
+	}
+	node, ok := oldParent.children[oldBase]
+	if !ok {
+		return &fs.PathError{Op: "rename", Path: oldpath, Err: fs.ErrNotExist}
+	}
+	newParent, newBase, err := m.lookupParent("rename", newpath)
+	if err != nil {
+		return err
+	}
+	if existing, ok := newParent.children[newBase]; ok && existing.mode.IsDir() && len(existing.children) > 0 {
+		return &fs.PathError{Op: "rename", Path: newpath, Err: errNotEmpty}
+	}
+	delete(oldParent.children, oldBase)
+	node.name = newBase
+	newParent.children[newBase] = node
+	return nil
+}
+
+// Chmod changes the permissions of the named file
+func (m *MemFileSystem) Chmod(name string, mode fs.FileMode) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	node, err := m.lookup("chmod", name)
+	if err != nil {
+		return err
+	}
+	node.mode = node.mode.Type() | mode.Perm()
+	return nil
+}
+
+// Chtimes changes the modification time of the named file, the access time is not stored
+func (m *MemFileSystem) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	node, err := m.lookup("chtimes", name)
+	if err != nil {
+		return err
+	}
+	node.modTime = mtime
+	return nil
+}
+
+// Symlink always fails since symbolic links are not supported
+func (m *MemFileSystem) Symlink(oldname, newname string) error {
+	return &os.LinkError{Op: "symlink", Old: oldname, New: newname, Err: errors.ErrUnsupported}
+}
+
+// memFile is an open file or directory of a MemFileSystem
+type memFile struct {
+	fsys     *MemFileSystem
+	node     *memNode
+	path     string
+	offset   int
+	readable bool
+	writable bool
+	append   bool
+	closed   bool
+	// dirEntries contains the directory entries not yet returned by ReadDir
+	dirEntries []fs.DirEntry
+	dirRead    bool
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) {
+	if f.closed {
+		return nil, &fs.PathError{Op: "stat", Path: f.path, Err: errClosed}
+	}
+	f.fsys.mutex.RLock()
+	defer f.fsys.mutex.RUnlock()
+	return f.node.info(), nil
+}
+
+func (f *memFile) Read(b []byte) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "read", Path: f.path, Err: errClosed}
+	}
+	if !f.readable {
+		return 0, &fs.PathError{Op: "read", Path: f.path, Err: fs.ErrPermission}
+	}
+	f.fsys.mutex.RLock()
+	defer f.fsys.mutex.RUnlock()
+	if f.node.mode.IsDir() {
+		return 0, &fs.PathError{Op: "read", Path: f.path, Err: errIsDir}
+	}
+	if f.offset >= len(f.node.data) {
+		return 0, io.EOF
+	}
+	n := copy(b, f.node.data[f.offset:])
+	f.offset += n
+	return n, nil
+}
+
+func (f *memFile) Write(b []byte) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "write", Path: f.path, Err: errClosed}
+	}
+	if !f.writable {
+		return 0, &fs.PathError{Op: "write", Path: f.path, Err: fs.ErrPermission}
+	}
+	f.fsys.mutex.Lock()
+	defer f.fsys.mutex.Unlock()
+	if f.append {
+		f.offset = len(f.node.data)
+	}
+	if end := f.offset + len(b); end > len(f.node.data) {
+		f.node.data = append(f.node.data, make([]byte, end-len(f.node.data))...)
+	}
+	n := copy(f.node.data[f.offset:], b)
+	f.offset += n
+	f.node.modTime = time.Now()
+	return n, nil
+}
+
+// ReadDir makes the directories opened using Open usable with fs.ReadDir and fs.WalkDir
+func (f *memFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	if f.closed {
+		return nil, &fs.PathError{Op: "readdir", Path: f.path, Err: errClosed}
+	}
+	if !f.dirRead {
+		f.fsys.mutex.RLock()
+		if !f.node.mode.IsDir() {
+			f.fsys.mutex.RUnlock()
+			return nil, &fs.PathError{Op: "readdir", Path: f.path, Err: errNotDir}
+		}
+		f.dirEntries = f.node.entries()
+		f.fsys.mutex.RUnlock()
+		f.dirRead = true
+	}
+	if n <= 0 {
+		entries := f.dirEntries
+		f.dirEntries = nil
+		return entries, nil
+	}
+	if len(f.dirEntries) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(f.dirEntries) {
+		n = len(f.dirEntries)
+	}
+	entries := f.dirEntries[:n]
+	f.dirEntries = f.dirEntries[n:]
+	return entries, nil
+}
+
+func (f *memFile) Close() error {
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.path, Err: errClosed}
+	}
+	f.closed = true
+	return nil
+}
+
+type memFileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (i *memFileInfo) Name() string       { return i.name }
+func (i *memFileInfo) Size() int64        { return i.size }
+func (i *memFileInfo) Mode() fs.FileMode  { return i.mode }
+func (i *memFileInfo) ModTime() time.Time { return i.modTime }
+func (i *memFileInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i *memFileInfo) Sys() interface{}   { return nil }
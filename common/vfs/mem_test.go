@@ -0,0 +1,409 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package vfs
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestMemFileSystemFiles(t *testing.T) {
+	fsys := NewMemFileSystem()
+	if err := fsys.MkdirAll("/src", 0755); err != nil {
+		t.Fatalf("failed to create the directory. Error: %q", err)
+	}
+
+	t.Run("write and read", func(t *testing.T) {
+		if err := fsys.WriteFile("/src/a.txt", []byte("hello"), 0644); err != nil {
+			t.Fatalf("failed to write the file. Error: %q", err)
+		}
+		data, err := fsys.ReadFile("/src/a.txt")
+		if err != nil {
+			t.Fatalf("failed to read the file. Error: %q", err)
+		}
+		if string(data) != "hello" {
+			t.Fatalf("expected the file to contain %q . Actual: %q", "hello", string(data))
+		}
+		info, err := fsys.Stat("/src/a.txt")
+		if err != nil {
+			t.Fatalf("failed to stat the file. Error: %q", err)
+		}
+		if info.Name() != "a.txt" || info.Size() != 5 || info.IsDir() || info.Mode().Perm() != 0644 {
+			t.Fatalf("expected a 5 byte file a.txt with permissions 0644 . Actual: %s %d %s", info.Name(), info.Size(), info.Mode())
+		}
+	})
+
+	t.Run("relative paths resolve from the root", func(t *testing.T) {
+		data, err := fsys.ReadFile("src/a.txt")
+		if err != nil {
+			t.Fatalf("failed to read the file. Error: %q", err)
+		}
+		if string(data) != "hello" {
+			t.Fatalf("expected the file to contain %q . Actual: %q", "hello", string(data))
+		}
+	})
+
+	t.Run("missing files", func(t *testing.T) {
+		if _, err := fsys.ReadFile("/src/missing.txt"); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("expected a not exist error. Actual: %v", err)
+		}
+		if _, err := fsys.Stat("/missing/a.txt"); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("expected a not exist error. Actual: %v", err)
+		}
+		if err := fsys.WriteFile("/missing/a.txt", nil, 0644); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("expected a not exist error. Actual: %v", err)
+		}
+	})
+
+	t.Run("open flags", func(t *testing.T) {
+		if _, err := fsys.OpenFile("/src/a.txt", os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644); !errors.Is(err, fs.ErrExist) {
+			t.Fatalf("expected an exist error. Actual: %v", err)
+		}
+		f, err := fsys.OpenFile("/src/a.txt", os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			t.Fatalf("failed to open the file. Error: %q", err)
+		}
+		if _, err := f.Write([]byte(" world")); err != nil {
+			t.Fatalf("failed to write to the file. Error: %q", err)
+		}
+		if _, err := f.Read(make([]byte, 1)); !errors.Is(err, fs.ErrPermission) {
+			t.Fatalf("expected a permission error when reading a write only file. Actual: %v", err)
+		}
+		if err := f.Close(); err != nil {
+			t.Fatalf("failed to close the file. Error: %q", err)
+		}
+		if err := f.Close(); err == nil {
+			t.Fatalf("expected an error when closing the file a second time")
+		}
+		if data, _ := fsys.ReadFile("/src/a.txt"); string(data) != "hello world" {
+			t.Fatalf("expected the file to contain %q . Actual: %q", "hello world", string(data))
+		}
+		f, err = fsys.OpenFile("/src/a.txt", os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			t.Fatalf("failed to open the file. Error: %q", err)
+		}
+		f.Close()
+		if data, _ := fsys.ReadFile("/src/a.txt"); len(data) != 0 {
+			t.Fatalf("expected the file to be truncated. Actual: %q", string(data))
+		}
+		if _, err := fsys.OpenFile("/src/missing.txt", os.O_RDONLY, 0); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("expected a not exist error. Actual: %v", err)
+		}
+		if _, err := fsys.OpenFile("/src", os.O_WRONLY, 0); err == nil {
+			t.Fatalf("expected an error when opening a directory for writing")
+		}
+	})
+
+	t.Run("read file by chunks", func(t *testing.T) {
+		if err := fsys.WriteFile("/src/b.txt", []byte("0123456789"), 0644); err != nil {
+			t.Fatalf("failed to write the file. Error: %q", err)
+		}
+		f, err := fsys.Open("/src/b.txt")
+		if err != nil {
+			t.Fatalf("failed to open the file. Error: %q", err)
+		}
+		defer f.Close()
+		buf := make([]byte, 4)
+		content := ""
+		for {
+			n, err := f.Read(buf)
+			content += string(buf[:n])
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("failed to read the file. Error: %q", err)
+			}
+		}
+		if content != "0123456789" {
+			t.Fatalf("expected to read %q . Actual: %q", "0123456789", content)
+		}
+	})
+
+	t.Run("chmod and chtimes", func(t *testing.T) {
+		modTime := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+		if err := fsys.Chmod("/src/b.txt", 0600); err != nil {
+			t.Fatalf("failed to change the permissions. Error: %q", err)
+		}
+		if err := fsys.Chtimes("/src/b.txt", modTime, modTime); err != nil {
+			t.Fatalf("failed to change the times. Error: %q", err)
+		}
+		info, err := fsys.Stat("/src/b.txt")
+		if err != nil {
+			t.Fatalf("failed to stat the file. Error: %q", err)
+		}
+		if info.Mode() != 0600 {
+			t.Fatalf("expected the mode to be %s . Actual: %s", fs.FileMode(0600), info.Mode())
+		}
+		if !info.ModTime().Equal(modTime) {
+			t.Fatalf("expected the modification time to be %s . Actual: %s", modTime, info.ModTime())
+		}
+		if err := fsys.Chmod("/src", 0700); err != nil {
+			t.Fatalf("failed to change the permissions. Error: %q", err)
+		}
+		if info, _ := fsys.Stat("/src"); !info.IsDir() {
+			t.Fatalf("expected chmod to keep the directory type")
+		}
+	})
+
+	t.Run("symlinks are not supported", func(t *testing.T) {
+		if err := fsys.Symlink("/src/a.txt", "/src/link"); !errors.Is(err, errors.ErrUnsupported) {
+			t.Fatalf("expected an unsupported error. Actual: %v", err)
+		}
+		if _, err := fsys.Readlink("/src/a.txt"); !errors.Is(err, errors.ErrUnsupported) {
+			t.Fatalf("expected an unsupported error. Actual: %v", err)
+		}
+	})
+}
+
+func TestMemFileSystemDirectories(t *testing.T) {
+	fsys := NewMemFileSystem()
+	if err := fsys.MkdirAll("/a/b/c", 0755); err != nil {
+		t.Fatalf("failed to create the directories. Error: %q", err)
+	}
+	for _, name := range []string{"z.txt", "m.txt", "b/x.txt"} {
+		if err := fsys.WriteFile(filepath.Join("/a", name), []byte(name), 0644); err != nil {
+			t.Fatalf("failed to write the file %s . Error: %q", name, err)
+		}
+	}
+
+	t.Run("read dir is sorted", func(t *testing.T) {
+		entries, err := fsys.ReadDir("/a")
+		if err != nil {
+			t.Fatalf("failed to read the directory. Error: %q", err)
+		}
+		names := []string{}
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		if want := []string{"b", "m.txt", "z.txt"}; !reflect.DeepEqual(names, want) {
+			t.Fatalf("expected the entries to be %v . Actual: %v", want, names)
+		}
+		if !entries[0].IsDir() || entries[1].IsDir() {
+			t.Fatalf("expected only the first entry to be a directory")
+		}
+		if _, err := fsys.ReadDir("/a/m.txt"); err == nil {
+			t.Fatalf("expected an error when reading a file as a directory")
+		}
+		if _, err := fsys.ReadFile("/a/b"); err == nil {
+			t.Fatalf("expected an error when reading a directory as a file")
+		}
+	})
+
+	t.Run("read dir by chunks", func(t *testing.T) {
+		f, err := fsys.Open("/a")
+		if err != nil {
+			t.Fatalf("failed to open the directory. Error: %q", err)
+		}
+		defer f.Close()
+		dir, ok := f.(fs.ReadDirFile)
+		if !ok {
+			t.Fatalf("expected the directory to implement fs.ReadDirFile")
+		}
+		count := 0
+		for {
+			entries, err := dir.ReadDir(2)
+			count += len(entries)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("failed to read the directory. Error: %q", err)
+			}
+		}
+		if count != 3 {
+			t.Fatalf("expected 3 entries. Actual: %d", count)
+		}
+	})
+
+	t.Run("mkdir", func(t *testing.T) {
+		if err := fsys.Mkdir("/a/b", 0755); !errors.Is(err, fs.ErrExist) {
+			t.Fatalf("expected an exist error. Actual: %v", err)
+		}
+		if err := fsys.Mkdir("/missing/d", 0755); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("expected a not exist error. Actual: %v", err)
+		}
+		if err := fsys.MkdirAll("/a/m.txt/d", 0755); err == nil {
+			t.Fatalf("expected an error when creating a directory under a file")
+		}
+		if err := fsys.MkdirAll("/a/b/c", 0755); err != nil {
+			t.Fatalf("expected creating an existing directory to succeed. Error: %q", err)
+		}
+	})
+
+	t.Run("mkdir temp", func(t *testing.T) {
+		first, err := fsys.MkdirTemp("", "move2kube-*-test")
+		if err != nil {
+			t.Fatalf("failed to create the temporary directory. Error: %q", err)
+		}
+		second, err := fsys.MkdirTemp("", "move2kube-*-test")
+		if err != nil {
+			t.Fatalf("failed to create the temporary directory. Error: %q", err)
+		}
+		if first == second {
+			t.Fatalf("expected the temporary directories to be different. Actual: %s", first)
+		}
+		if !strings.HasPrefix(first, "/tmp/move2kube-") || !strings.HasSuffix(first, "-test") {
+			t.Fatalf("expected the temporary directory to match the pattern. Actual: %s", first)
+		}
+		if info, err := fsys.Stat(first); err != nil || !info.IsDir() {
+			t.Fatalf("expected the temporary directory to exist. Error: %v", err)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		if err := fsys.Remove("/a/b"); err == nil {
+			t.Fatalf("expected an error when removing a non empty directory")
+		}
+		if err := fsys.Remove("/a/b/c"); err != nil {
+			t.Fatalf("failed to remove the empty directory. Error: %q", err)
+		}
+		if err := fsys.Remove("/a/b/c"); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("expected a not exist error. Actual: %v", err)
+		}
+		if err := fsys.RemoveAll("/a/b"); err != nil {
+			t.Fatalf("failed to remove the directory. Error: %q", err)
+		}
+		if _, err := fsys.Stat("/a/b/x.txt"); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("expected a not exist error. Actual: %v", err)
+		}
+		if err := fsys.RemoveAll("/a/missing"); err != nil {
+			t.Fatalf("expected removing a missing path to succeed. Error: %q", err)
+		}
+	})
+}
+
+func TestMemFileSystemRename(t *testing.T) {
+	testcases := []struct {
+		name    string
+		oldPath string
+		newPath string
+		wantErr bool
+	}{
+		{name: "file to new path", oldPath: "/src/a.txt", newPath: "/dest/c.txt"},
+		{name: "file over existing file", oldPath: "/src/a.txt", newPath: "/dest/b.txt"},
+		{name: "directory to new path", oldPath: "/src", newPath: "/moved"},
+		{name: "directory over empty directory", oldPath: "/src", newPath: "/empty"},
+		{name: "directory over non empty directory", oldPath: "/src", newPath: "/dest", wantErr: true},
+		{name: "missing path", oldPath: "/missing", newPath: "/dest/c.txt", wantErr: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fsys := NewMemFileSystem()
+			for _, dir := range []string{"/src", "/dest", "/empty"} {
+				if err := fsys.MkdirAll(dir, 0755); err != nil {
+					t.Fatalf("failed to create the directory %s . Error: %q", dir, err)
+				}
+			}
+			if err := fsys.WriteFile("/src/a.txt", []byte("a"), 0644); err != nil {
+				t.Fatalf("failed to write the file. Error: %q", err)
+			}
+			if err := fsys.WriteFile("/dest/b.txt", []byte("b"), 0644); err != nil {
+				t.Fatalf("failed to write the file. Error: %q", err)
+			}
+			err := fsys.Rename(tc.oldPath, tc.newPath)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected renaming %s to %s to fail", tc.oldPath, tc.newPath)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to rename %s to %s . Error: %q", tc.oldPath, tc.newPath, err)
+			}
+			if _, err := fsys.Stat(tc.oldPath); !errors.Is(err, fs.ErrNotExist) {
+				t.Fatalf("expected the old path to be removed. Actual: %v", err)
+			}
+			info, err := fsys.Stat(tc.newPath)
+			if err != nil {
+				t.Fatalf("failed to stat the new path. Error: %q", err)
+			}
+			if info.Name() != filepath.Base(tc.newPath) {
+				t.Fatalf("expected the name to be %s . Actual: %s", filepath.Base(tc.newPath), info.Name())
+			}
+			if info.IsDir() {
+				if data, err := fsys.ReadFile(filepath.Join(tc.newPath, "a.txt")); err != nil || string(data) != "a" {
+					t.Fatalf("expected the directory contents to be moved. Error: %v", err)
+				}
+			} else if data, _ := fsys.ReadFile(tc.newPath); string(data) != "a" {
+				t.Fatalf("expected the file to contain %q . Actual: %q", "a", string(data))
+			}
+		})
+	}
+}
+
+func TestMemFileSystemWalk(t *testing.T) {
+	fsys := NewMemFileSystem()
+	src := fstest.MapFS{
+		"go.mod":           {Data: []byte("module example.com/app\n")},
+		"main.go":          {Data: []byte("package main\n")},
+		"cmd/tool/tool.go": {Data: []byte("package tool\n")},
+	}
+	if err := CopyFS(fsys, "/src", src); err != nil {
+		t.Fatalf("failed to copy the files. Error: %q", err)
+	}
+
+	t.Run("walk dir", func(t *testing.T) {
+		paths := []string{}
+		if err := WalkDirFS(fsys, "/src", func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			paths = append(paths, path)
+			return nil
+		}); err != nil {
+			t.Fatalf("failed to walk the directory. Error: %q", err)
+		}
+		want := []string{"/src", "/src/cmd", "/src/cmd/tool", "/src/cmd/tool/tool.go", "/src/go.mod", "/src/main.go"}
+		if !reflect.DeepEqual(paths, want) {
+			t.Fatalf("expected the walked paths to be %v . Actual: %v", want, paths)
+		}
+	})
+
+	t.Run("skip dir", func(t *testing.T) {
+		paths := []string{}
+		if err := WalkDirFS(fsys, "/src", func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() && d.Name() == "cmd" {
+				return filepath.SkipDir
+			}
+			paths = append(paths, path)
+			return nil
+		}); err != nil {
+			t.Fatalf("failed to walk the directory. Error: %q", err)
+		}
+		if want := []string{"/src", "/src/go.mod", "/src/main.go"}; !reflect.DeepEqual(paths, want) {
+			t.Fatalf("expected the walked paths to be %v . Actual: %v", want, paths)
+		}
+	})
+
+	t.Run("dir fs", func(t *testing.T) {
+		if err := fstest.TestFS(DirFS(fsys, "/src"), "go.mod", "main.go", "cmd/tool/tool.go"); err != nil {
+			t.Fatalf("expected the directory to behave like an fs.FS . Error: %q", err)
+		}
+	})
+}
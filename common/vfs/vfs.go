@@ -0,0 +1,176 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package vfs contains the filesystem abstraction used by the planner and the transformers,
+// so that they can run against the OS filesystem or against in-memory files.
+// Paths are OS paths, like the ones used with the os package.
+package vfs
+
+import (
+	"io"
+	"io/fs"
+	"sync"
+	"time"
+)
+
+// ReadFileSystem is the read side of a filesystem, it is used for the source directory.
+// DirFS returns an fs.FS view of a directory in it.
+type ReadFileSystem interface {
+	Open(name string) (fs.File, error)
+	Stat(name string) (fs.FileInfo, error)
+	Lstat(name string) (fs.FileInfo, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	ReadFile(name string) ([]byte, error)
+	Readlink(name string) (string, error)
+}
+
+// File is a file opened for writing
+type File interface {
+	fs.File
+	io.Writer
+}
+
+// FileSystem is a writable filesystem, it is used for the output and the temporary directories
+type FileSystem interface {
+	ReadFileSystem
+	Create(name string) (File, error)
+	OpenFile(name string, flag int, perm fs.FileMode) (File, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	Mkdir(name string, perm fs.FileMode) error
+	MkdirAll(name string, perm fs.FileMode) error
+	MkdirTemp(dir, pattern string) (string, error)
+	Remove(name string) error
+	RemoveAll(name string) error
+	Rename(oldpath, newpath string) error
+	Chmod(name string, mode fs.FileMode) error
+	Chtimes(name string, atime time.Time, mtime time.Time) error
+	Symlink(oldname, newname string) error
+}
+
+var (
+	defaultMutex      sync.RWMutex
+	defaultFileSystem FileSystem = OS
+)
+
+// Default returns the filesystem used by the package level functions
+func Default() FileSystem {
+	defaultMutex.RLock()
+	defer defaultMutex.RUnlock()
+	return defaultFileSystem
+}
+
+// SetDefault changes the filesystem used by the package level functions and returns the previous one.
+// A nil filesystem resets it to the OS filesystem.
+func SetDefault(fsys FileSystem) FileSystem {
+	defaultMutex.Lock()
+	defer defaultMutex.Unlock()
+	previous := defaultFileSystem
+	if fsys == nil {
+		fsys = OS
+	}
+	defaultFileSystem = fsys
+	return previous
+}
+
+// Open opens the named file for reading using the default filesystem
+func Open(name string) (fs.File, error) {
+	return Default().Open(name)
+}
+
+// Stat returns the file info of the named file using the default filesystem
+func Stat(name string) (fs.FileInfo, error) {
+	return Default().Stat(name)
+}
+
+// Lstat returns the file info of the named file without following symbolic links using the default filesystem
+func Lstat(name string) (fs.FileInfo, error) {
+	return Default().Lstat(name)
+}
+
+// ReadDir returns the entries of the named directory sorted by name using the default filesystem
+func ReadDir(name string) ([]fs.DirEntry, error) {
+	return Default().ReadDir(name)
+}
+
+// ReadFile returns the contents of the named file using the default filesystem
+func ReadFile(name string) ([]byte, error) {
+	return Default().ReadFile(name)
+}
+
+// Readlink returns the destination of the named symbolic link using the default filesystem
+func Readlink(name string) (string, error) {
+	return Default().Readlink(name)
+}
+
+// Create creates or truncates the named file using the default filesystem
+func Create(name string) (File, error) {
+	return Default().Create(name)
+}
+
+// OpenFile opens the named file with the flags of os.OpenFile using the default filesystem
+func OpenFile(name string, flag int, perm fs.FileMode) (File, error) {
+	return Default().OpenFile(name, flag, perm)
+}
+
+// WriteFile writes the data to the named file, creating it if necessary, using the default filesystem
+func WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return Default().WriteFile(name, data, perm)
+}
+
+// Mkdir creates the named directory using the default filesystem
+func Mkdir(name string, perm fs.FileMode) error {
+	return Default().Mkdir(name, perm)
+}
+
+// MkdirAll creates the named directory along with its parents using the default filesystem
+func MkdirAll(name string, perm fs.FileMode) error {
+	return Default().MkdirAll(name, perm)
+}
+
+// MkdirTemp creates a new temporary directory in the directory dir using the default filesystem
+func MkdirTemp(dir, pattern string) (string, error) {
+	return Default().MkdirTemp(dir, pattern)
+}
+
+// Remove removes the named file or empty directory using the default filesystem
+func Remove(name string) error {
+	return Default().Remove(name)
+}
+
+// RemoveAll removes the path and everything it contains using the default filesystem
+func RemoveAll(name string) error {
+	return Default().RemoveAll(name)
+}
+
+// Rename moves oldpath to newpath using the default filesystem
+func Rename(oldpath, newpath string) error {
+	return Default().Rename(oldpath, newpath)
+}
+
+// Chmod changes the mode of the named file using the default filesystem
+func Chmod(name string, mode fs.FileMode) error {
+	return Default().Chmod(name, mode)
+}
+
+// Chtimes changes the access and modification times of the named file using the default filesystem
+func Chtimes(name string, atime time.Time, mtime time.Time) error {
+	return Default().Chtimes(name, atime, mtime)
+}
+
+// Symlink creates newname as a symbolic link to oldname using the default filesystem
+func Symlink(oldname, newname string) error {
+	return Default().Symlink(oldname, newname)
+}
@@ -0,0 +1,185 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package vfs
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// WalkDir walks the directory tree like filepath.WalkDir, using the default filesystem
+func WalkDir(root string, fn fs.WalkDirFunc) error {
+	return WalkDirFS(Default(), root, fn)
+}
+
+// Walk walks the directory tree like filepath.Walk, using the default filesystem
+func Walk(root string, fn filepath.WalkFunc) error {
+	return WalkDirFS(Default(), root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if d == nil {
+				return fn(path, nil, err)
+			}
+			// the directory could not be read, filepath.Walk calls the function a second time with the error
+			info, _ := d.Info()
+			return fn(path, info, err)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return fn(path, nil, err)
+		}
+		return fn(path, info, nil)
+	})
+}
+
+// WalkDirFS walks the directory tree rooted at root in the filesystem like filepath.WalkDir.
+// The entries of each directory are walked in lexical order and symbolic links are not followed.
+func WalkDirFS(fsys ReadFileSystem, root string, fn fs.WalkDirFunc) error {
+	info, err := fsys.Lstat(root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walkDir(fsys, root, fs.FileInfoToDirEntry(info), fn)
+	}
+	if err == filepath.SkipDir || err == filepath.SkipAll {
+		return nil
+	}
+	return err
+}
+
+func walkDir(fsys ReadFileSystem, path string, d fs.DirEntry, fn fs.WalkDirFunc) error {
+	if err := fn(path, d, nil); err != nil || !d.IsDir() {
+		if err == filepath.SkipDir && d.IsDir() {
+			err = nil
+		}
+		return err
+	}
+	entries, err := fsys.ReadDir(path)
+	if err != nil {
+		if err = fn(path, d, err); err != nil {
+			if err == filepath.SkipDir && d.IsDir() {
+				err = nil
+			}
+			return err
+		}
+	}
+	for _, entry := range entries {
+		if err := walkDir(fsys, filepath.Join(path, entry.Name()), entry, fn); err != nil {
+			if err == filepath.SkipDir {
+				break
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// DirFS returns an fs.FS for the directory in the filesystem, for use with fs.WalkDir, fs.Glob, template.ParseFS, etc.
+func DirFS(fsys ReadFileSystem, dir string) fs.FS {
+	return &dirFS{fsys: fsys, dir: dir}
+}
+
+type dirFS struct {
+	fsys ReadFileSystem
+	dir  string
+}
+
+func (d *dirFS) join(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return filepath.Join(d.dir, filepath.FromSlash(name)), nil
+}
+
+func (d *dirFS) Open(name string) (fs.File, error) {
+	fullPath, err := d.join("open", name)
+	if err != nil {
+		return nil, err
+	}
+	return d.fsys.Open(fullPath)
+}
+
+func (d *dirFS) Stat(name string) (fs.FileInfo, error) {
+	fullPath, err := d.join("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return d.fsys.Stat(fullPath)
+}
+
+func (d *dirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	fullPath, err := d.join("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	return d.fsys.ReadDir(fullPath)
+}
+
+func (d *dirFS) ReadFile(name string) ([]byte, error) {
+	fullPath, err := d.join("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+	return d.fsys.ReadFile(fullPath)
+}
+
+// CopyFS copies all the files and directories in src into the directory dir of the filesystem.
+// It can be used to load sources from an embed.FS, a zip.Reader or an fstest.MapFS into a MemFileSystem.
+func CopyFS(fsys FileSystem, dir string, src fs.FS) error {
+	if err := fsys.MkdirAll(dir, fs.ModePerm); err != nil {
+		return fmt.Errorf("failed to create the directory at path %s . Error: %w", dir, err)
+	}
+	return fs.WalkDir(src, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		destPath := filepath.Join(dir, filepath.FromSlash(path))
+		if d.IsDir() {
+			if err := fsys.MkdirAll(destPath, fs.ModePerm); err != nil {
+				return fmt.Errorf("failed to create the directory at path %s . Error: %w", destPath, err)
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("failed to get the info of the file %s . Error: %w", path, err)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		perm := info.Mode().Perm()
+		if perm == 0 {
+			perm = 0644
+		}
+		srcFile, err := src.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open the file %s . Error: %w", path, err)
+		}
+		defer srcFile.Close()
+		destFile, err := fsys.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+		if err != nil {
+			return fmt.Errorf("failed to create the file at path %s . Error: %w", destPath, err)
+		}
+		defer destFile.Close()
+		if _, err := io.Copy(destFile, srcFile); err != nil {
+			return fmt.Errorf("failed to copy the file %s to the path %s . Error: %w", path, destPath, err)
+		}
+		return nil
+	})
+}
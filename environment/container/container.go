@@ -17,6 +17,7 @@
 package container
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/konveyor/move2kube-wasm/common"
@@ -60,6 +61,9 @@ type ContainerEngine interface {
 func initContainerEngine() (err error) {
 	logrus.Trace("initContainerEngine start")
 	defer logrus.Trace("initContainerEngine end")
+	if err := common.CheckSupported("spawning containers"); err != nil {
+		return err
+	}
 	//workingEngine, err = newDockerEngine()
 	if err != nil {
 		return fmt.Errorf("failed to use docker as the container engine. Error: %w", err)
@@ -72,11 +76,12 @@ func initContainerEngine() (err error) {
 }
 
 // GetContainerEngine gets a working container engine
-func GetContainerEngine(spawnContainers bool) (ContainerEngine, error) {
+func GetContainerEngine(ctx context.Context, spawnContainers bool) (ContainerEngine, error) {
 	logrus.Trace("GetContainerEngine start")
 	defer logrus.Trace("GetContainerEngine end")
 	if !inited {
 		enabled = qaengine.FetchBoolAnswer(
+			ctx,
 			common.ConfigSpawnContainersKey,
 			"Allow spawning containers?",
 			[]string{"If this setting is set to false, those transformers that rely on containers will not work."},
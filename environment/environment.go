@@ -18,6 +18,7 @@ package environment
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/fs"
 	"net"
@@ -31,6 +32,7 @@ import (
 	"github.com/konveyor/move2kube-wasm/common"
 	"github.com/konveyor/move2kube-wasm/common/deepcopy"
 	"github.com/konveyor/move2kube-wasm/common/pathconverters"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/types"
 	environmenttypes "github.com/konveyor/move2kube-wasm/types/environment"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
@@ -79,7 +81,7 @@ type EnvironmentInstance interface {
 	Stat(name string) (fs.FileInfo, error)
 	Download(envpath string) (outpath string, err error)
 	Upload(outpath string) (envpath string, err error)
-	Exec(cmd environmenttypes.Command, envList []string) (stdout string, stderr string, exitcode int, err error)
+	Exec(ctx context.Context, cmd environmenttypes.Command, envList []string) (stdout string, stderr string, exitcode int, err error)
 	Destroy() error
 
 	GetSource() string
@@ -91,8 +93,11 @@ func NewEnvironment(envInfo EnvInfo, grpcQAReceiver net.Addr) (env *Environment,
 	if !common.IsPresent(envInfo.EnvPlatformConfig.Platforms, runtime.GOOS) && envInfo.EnvPlatformConfig.Container.Image == "" {
 		return nil, fmt.Errorf("platform '%s' is not supported", runtime.GOOS)
 	}
+	if envInfo.FileSystem == nil {
+		envInfo.FileSystem = vfs.Default()
+	}
 	containerInfo := envInfo.EnvPlatformConfig.Container
-	tempPath, err := os.MkdirTemp(common.TempPath, "environment-"+envInfo.Name+"-*")
+	tempPath, err := envInfo.FileSystem.MkdirTemp(common.TempPath, "environment-"+envInfo.Name+"-*")
 	if err != nil {
 		return env, fmt.Errorf("failed to create the temporary directory. Error: %w", err)
 	}
@@ -148,6 +153,14 @@ func NewEnvironment(envInfo EnvInfo, grpcQAReceiver net.Addr) (env *Environment,
 	return env, nil
 }
 
+// GetType returns the type of the environment instance, like Local
+func (e *Environment) GetType() string {
+	if e.Env == nil {
+		return ""
+	}
+	return reflect.Indirect(reflect.ValueOf(e.Env)).Type().Name()
+}
+
 // AddChild adds a child to the environment
 func (e *Environment) AddChild(env *Environment) {
 	e.Children = append(e.Children, env)
@@ -163,12 +176,12 @@ func (e *Environment) Reset() error {
 	return e.Env.Reset()
 }
 
-// Exec executes an executable within the environment
-func (e *Environment) Exec(cmd environmenttypes.Command, envList []string) (stdout string, stderr string, exitcode int, err error) {
+// Exec executes an executable within the environment. The process is killed when the context is done.
+func (e *Environment) Exec(ctx context.Context, cmd environmenttypes.Command, envList []string) (stdout string, stderr string, exitcode int, err error) {
 	if !e.active {
 		return "", "", 0, ErrEnvironmentNotActive
 	}
-	return e.Env.Exec(cmd, envList)
+	return e.Env.Exec(ctx, cmd, envList)
 }
 
 // Destroy destroys all artifacts specific to the environment
@@ -388,7 +401,7 @@ func (e *Environment) ProcessPathMappings(pathMappings []transformertypes.PathMa
 			pathStr := path.String()
 			logrus.Debugf("Output of environment template: %s\n", pathStr)
 			if filepath.IsAbs(pathStr) {
-				tempOutputPath, err := os.MkdirTemp(e.TempPath, "*")
+				tempOutputPath, err := e.FileSystem.MkdirTemp(e.TempPath, "*")
 				if err != nil {
 					logrus.Errorf("Unable to create temp dir : %s", err)
 					continue
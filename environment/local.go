@@ -18,6 +18,7 @@ package environment
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -27,6 +28,7 @@ import (
 	"path/filepath"
 
 	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/filesystem"
 	"github.com/konveyor/move2kube-wasm/types"
 	environmenttypes "github.com/konveyor/move2kube-wasm/types/environment"
@@ -45,17 +47,20 @@ type Local struct {
 func NewLocal(envInfo EnvInfo, grpcQAReceiver net.Addr) (EnvironmentInstance, error) {
 	logrus.Trace("NewLocal start")
 	defer logrus.Trace("NewLocal end")
+	if envInfo.FileSystem == nil {
+		envInfo.FileSystem = vfs.Default()
+	}
 	local := &Local{
 		EnvInfo:        envInfo,
 		GRPCQAReceiver: grpcQAReceiver,
 	}
 	if envInfo.Isolated {
 		var err error
-		local.WorkspaceContext, err = os.MkdirTemp(local.TempPath, types.AppNameShort)
+		local.WorkspaceContext, err = local.FileSystem.MkdirTemp(local.TempPath, types.AppNameShort)
 		if err != nil {
 			return local, fmt.Errorf("failed to create the temp directory at path '%s' with pattern '%s' . Error: %w", local.TempPath, types.AppNameShort, err)
 		}
-		local.WorkspaceSource, err = os.MkdirTemp(local.TempPath, workspaceDir)
+		local.WorkspaceSource, err = local.FileSystem.MkdirTemp(local.TempPath, workspaceDir)
 		if err != nil {
 			return local, fmt.Errorf("failed to create the temp directory at path '%s' with pattern '%s' . Error: %w", local.TempPath, workspaceDir, err)
 		}
@@ -87,18 +92,24 @@ func (e *Local) Reset() error {
 
 // Stat returns stat info of the file/dir in the env
 func (e *Local) Stat(name string) (fs.FileInfo, error) {
-	return os.Stat(name)
+	return e.FileSystem.Stat(name)
 }
 
-// Exec executes an executable within the environment
-func (e *Local) Exec(cmd environmenttypes.Command, envList []string) (stdout string, stderr string, exitcode int, err error) {
+// Exec executes an executable within the environment. The process is killed when the context is done.
+func (e *Local) Exec(ctx context.Context, cmd environmenttypes.Command, envList []string) (stdout string, stderr string, exitcode int, err error) {
 	if common.DisableLocalExecution {
 		return "", "", 0, fmt.Errorf("local execution prevented by %s flag", common.DisableLocalExecutionFlag)
 	}
+	if err := common.CheckSupported("executing commands"); err != nil {
+		return "", "", 0, err
+	}
+	if e.FileSystem != vfs.OS {
+		return "", "", 0, fmt.Errorf("commands can only be executed when the environment uses the OS filesystem")
+	}
 	var outb, errb bytes.Buffer
 	var execcmd *exec.Cmd
 	if len(cmd) > 0 {
-		execcmd = exec.Command(cmd[0], cmd[1:]...)
+		execcmd = exec.CommandContext(ctx, cmd[0], cmd[1:]...)
 	} else {
 		return "", "", 0, fmt.Errorf("no command found to execute")
 	}
@@ -108,6 +119,9 @@ func (e *Local) Exec(cmd environmenttypes.Command, envList []string) (stdout str
 	execcmd.Env = e.getEnv()
 	execcmd.Env = append(execcmd.Env, envList...)
 	if err := execcmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return outb.String(), errb.String(), -1, fmt.Errorf("the command %+v was killed. Error: %w", cmd, ctxErr)
+		}
 		var ee *exec.ExitError
 		var pe *os.PathError
 		if errors.As(err, &ee) {
@@ -126,10 +140,10 @@ func (e *Local) Exec(cmd environmenttypes.Command, envList []string) (stdout str
 // Destroy destroys all artifacts specific to the environment
 func (e *Local) Destroy() error {
 	if e.Isolated {
-		if err := os.RemoveAll(e.WorkspaceSource); err != nil {
+		if err := e.FileSystem.RemoveAll(e.WorkspaceSource); err != nil {
 			return fmt.Errorf("failed to remove the workspace source directory '%s' . Error: %w", e.WorkspaceSource, err)
 		}
-		if err := os.RemoveAll(e.WorkspaceContext); err != nil {
+		if err := e.FileSystem.RemoveAll(e.WorkspaceContext); err != nil {
 			return fmt.Errorf("failed to remove the workspace context directory '%s' . Error: %w", e.WorkspaceContext, err)
 		}
 	}
@@ -138,11 +152,11 @@ func (e *Local) Destroy() error {
 
 // Download downloads the path to outside the environment
 func (e *Local) Download(sourcePath string) (string, error) {
-	destPath, err := os.MkdirTemp(e.TempPath, "*")
+	destPath, err := e.FileSystem.MkdirTemp(e.TempPath, "*")
 	if err != nil {
 		return sourcePath, fmt.Errorf("failed to create the temp dir at path '%s' with pattern '*' . Error: %w", e.TempPath, err)
 	}
-	ps, err := os.Stat(sourcePath)
+	ps, err := e.FileSystem.Stat(sourcePath)
 	if err != nil {
 		return sourcePath, fmt.Errorf("failed to stat source directory at path '%s' . Error: %w", sourcePath, err)
 	}
@@ -157,11 +171,11 @@ func (e *Local) Download(sourcePath string) (string, error) {
 
 // Upload uploads the path from outside the environment into it
 func (e *Local) Upload(sourcePath string) (string, error) {
-	destPath, err := os.MkdirTemp(e.TempPath, "*")
+	destPath, err := e.FileSystem.MkdirTemp(e.TempPath, "*")
 	if err != nil {
 		return destPath, fmt.Errorf("failed to create the temp dir at path '%s' with pattern '*' . Error: %w", e.TempPath, err)
 	}
-	ps, err := os.Stat(sourcePath)
+	ps, err := e.FileSystem.Stat(sourcePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to stat source '%s' . Error: %w", sourcePath, err)
 	}
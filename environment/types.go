@@ -17,6 +17,7 @@
 package environment
 
 import (
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	environmenttypes "github.com/konveyor/move2kube-wasm/types/environment"
 )
 
@@ -35,4 +36,6 @@ type EnvInfo struct {
 	TempPath              string
 	EnvPlatformConfig     environmenttypes.EnvPlatformConfig
 	SpawnContainers       bool
+	// FileSystem is the filesystem the source, output and temporary paths are in. It defaults to vfs.Default().
+	FileSystem vfs.FileSystem
 }
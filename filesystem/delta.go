@@ -17,9 +17,11 @@
 package filesystem
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/sirupsen/logrus"
 )
 
@@ -58,12 +60,12 @@ func generateDeltaProcessFileCallBack(sourceFilePath, destinationFilePath string
 		logrus.Errorf("Unable to resolve destination dir %s as rel path : %s", destinationFilePath, err)
 	}
 	modifiedFilePath := filepath.Join(gconfig.store, modificationsDir, destRel)
-	si, err := os.Stat(sourceFilePath)
+	si, err := vfs.Stat(sourceFilePath)
 	if err != nil {
 		logrus.Errorf("Unable to stat file %s : %s", sourceFilePath, err)
 		return err
 	}
-	di, err := os.Stat(destinationFilePath)
+	di, err := vfs.Stat(destinationFilePath)
 	if err == nil {
 		if err == nil && !(si.Mode().IsRegular() != di.Mode().IsRegular() || si.Size() != di.Size() || si.ModTime() != di.ModTime()) {
 			return nil
@@ -85,7 +87,7 @@ func generateDeltaAdditionCallBack(source, destination string, config interface{
 
 func generateDeltaDeletionCallBack(source, destination string, config interface{}) error {
 	gconfig := config.(generateDeltaConfig)
-	f, err := os.OpenFile(filepath.Join(gconfig.store, deletionsFile),
+	f, err := vfs.OpenFile(filepath.Join(gconfig.store, deletionsFile),
 		os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		logrus.Errorf("Unable to open modifications file to record deleteions : %s", gconfig.store)
@@ -96,7 +98,7 @@ func generateDeltaDeletionCallBack(source, destination string, config interface{
 	if err != nil {
 		logrus.Errorf("Unable to resolve destination dir %s as rel path : %s", destination, err)
 	}
-	if _, err := f.WriteString(destRel + "\n"); err != nil {
+	if _, err := io.WriteString(f, destRel+"\n"); err != nil {
 		logrus.Errorf("Unable to record deletions to file : %s", gconfig.store)
 		return err
 	}
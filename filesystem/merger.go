@@ -17,8 +17,7 @@
 package filesystem
 
 import (
-	"os"
-
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/sirupsen/logrus"
 )
 
@@ -35,12 +34,12 @@ func Merge(source, destination string, warnOnOverwrite bool) error {
 }
 
 func mergeProcessFileCallBack(sourceFilePath, destinationFilePath string, config interface{}) error {
-	si, err := os.Stat(sourceFilePath)
+	si, err := vfs.Stat(sourceFilePath)
 	if err != nil {
 		logrus.Errorf("Unable to stat file %s : %s", sourceFilePath, err)
 		return err
 	}
-	di, err := os.Stat(destinationFilePath)
+	di, err := vfs.Stat(destinationFilePath)
 	if err == nil {
 		if !(si.Mode().IsRegular() != di.Mode().IsRegular() || si.Size() != di.Size() || si.ModTime() != di.ModTime()) {
 			return nil
@@ -59,17 +58,17 @@ func mergeAdditionCallBack(source, destination string, config interface{}) error
 }
 
 func mergeDeletionCallBack(source, destination string, config interface{}) error {
-	si, err := os.Stat(source)
+	si, err := vfs.Stat(source)
 	if err != nil {
 		logrus.Errorf("Unable to stat %s : %s", source, err)
 		return err
 	}
-	err = os.MkdirAll(destination, si.Mode())
+	err = vfs.MkdirAll(destination, si.Mode())
 	if err != nil {
 		logrus.Errorf("Unable to create directory %s", destination)
 		return err
 	}
-	err = os.Chmod(destination, si.Mode())
+	err = vfs.Chmod(destination, si.Mode())
 	if err != nil {
 		logrus.Errorf("Unable to copy permissions in file %s : %s", destination, err)
 		return err
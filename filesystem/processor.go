@@ -21,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/sirupsen/logrus"
 )
 
@@ -43,7 +44,7 @@ func newProcessor(options options) *processor {
 }
 
 func (p *processor) process(source, destination string) error {
-	si, err := os.Stat(source)
+	si, err := vfs.Stat(source)
 	if err != nil {
 		return fmt.Errorf("failed to stat the source path '%s' . Error: %w", source, err)
 	}
@@ -57,7 +58,7 @@ func (p *processor) process(source, destination string) error {
 			return err
 		}
 	default:
-		di, err := os.Stat(destination)
+		di, err := vfs.Stat(destination)
 		if err == nil {
 			if di.IsDir() {
 				destination = filepath.Join(destination, filepath.Base(source))
@@ -85,12 +86,12 @@ func (p *processor) processFile(source, destination string) error {
 
 func (p *processor) processDirectory(source, destination string) error {
 	destEntryNames := map[string]bool{}
-	entries, err := os.ReadDir(source)
+	entries, err := vfs.ReadDir(source)
 	if err != nil {
 		return err
 	}
 
-	di, err := os.Stat(destination)
+	di, err := vfs.Stat(destination)
 	if err != nil {
 		if err := p.options.deletionCallBack(source, destination, p.options.config); err != nil {
 			logrus.Errorf("Error during deletion callback for %s, %s", source, destination)
@@ -100,7 +101,7 @@ func (p *processor) processDirectory(source, destination string) error {
 			logrus.Errorf("Error during mismatch callback for %s, %s", source, destination)
 		}
 	} else {
-		destEntries, err := os.ReadDir(destination)
+		destEntries, err := vfs.ReadDir(destination)
 		if err != nil {
 			logrus.Errorf("Unable to process directory %s : %s", destination, err)
 		} else {
@@ -130,9 +131,9 @@ func (p *processor) processDirectory(source, destination string) error {
 }
 
 func (p *processor) processSymLink(source, destination string) error {
-	link, err := os.Readlink(source)
+	link, err := vfs.Readlink(source)
 	if err != nil {
 		return err
 	}
-	return os.Symlink(link, destination)
+	return vfs.Symlink(link, destination)
 }
@@ -26,6 +26,7 @@ import (
 
 	"github.com/Masterminds/sprig"
 	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/sirupsen/logrus"
 )
 
@@ -62,7 +63,7 @@ func templateCopyProcessFileCallBack(sourceFilePath, destinationFilePath string,
 		logrus.Errorf("Unable to get addOnConfig : %s", err)
 		return err
 	}
-	si, err := os.Stat(sourceFilePath)
+	si, err := vfs.Stat(sourceFilePath)
 	if err != nil {
 		logrus.Errorf("Unable to stat file %s : %s", sourceFilePath, err)
 		return err
@@ -72,7 +73,7 @@ func templateCopyProcessFileCallBack(sourceFilePath, destinationFilePath string,
 		logrus.Errorf("Unable to fill the template of file path %s : %s", destinationFilePath, err)
 		return err
 	}
-	di, err := os.Stat(destinationFilePath)
+	di, err := vfs.Stat(destinationFilePath)
 	if err == nil {
 		if err == nil && !(si.Mode().IsRegular() != di.Mode().IsRegular() || si.Size() != di.Size() || si.ModTime() != di.ModTime()) {
 			return nil
@@ -80,20 +81,20 @@ func templateCopyProcessFileCallBack(sourceFilePath, destinationFilePath string,
 			logrus.Errorf("Unable to compare files to check if files are same %s and %s. Copying normally : %s", sourceFilePath, destinationFilePath, err)
 		}
 	}
-	src, err := os.ReadFile(sourceFilePath)
+	src, err := vfs.ReadFile(sourceFilePath)
 	if err != nil {
 		logrus.Errorf("Unable to open file %s : %s", sourceFilePath, err)
 		return err
 	}
-	destinationWriter, err := os.Create(destinationFilePath)
+	destinationWriter, err := vfs.Create(destinationFilePath)
 	if err != nil {
-		sdi, err := os.Stat(filepath.Dir(sourceFilePath))
+		sdi, err := vfs.Stat(filepath.Dir(sourceFilePath))
 		if err != nil {
 			logrus.Errorf("Unable to stat parent dir of %s : %s", sourceFilePath, err)
 			return err
 		}
-		if mderr := os.MkdirAll(filepath.Dir(destinationFilePath), sdi.Mode()); mderr == nil {
-			destinationWriter, err = os.Create(destinationFilePath)
+		if mderr := vfs.MkdirAll(filepath.Dir(destinationFilePath), sdi.Mode()); mderr == nil {
+			destinationWriter, err = vfs.Create(destinationFilePath)
 		}
 		if err != nil {
 			logrus.Errorf("Unable to create destination file %s : %s", destinationFilePath, err)
@@ -120,7 +121,7 @@ func templateCopyDeletionCallBack(source, destination string, addOnConfigAsIface
 	if err := common.GetObjFromInterface(addOnConfigAsIface, &addOnConfig); err != nil {
 		return fmt.Errorf("failed to get the addOnConfig object from the interface. Error: %w", err)
 	}
-	si, err := os.Stat(source)
+	si, err := vfs.Stat(source)
 	if err != nil {
 		return fmt.Errorf("failed to stat the file at source path '%s' . Error: %w", source, err)
 	}
@@ -128,13 +129,13 @@ func templateCopyDeletionCallBack(source, destination string, addOnConfigAsIface
 	if err != nil {
 		return fmt.Errorf("failed to fill the template file at path '%s' using the config: %+v . Error: %w", destination, addOnConfig.Config, err)
 	}
-	if err := os.RemoveAll(destination); err != nil {
+	if err := vfs.RemoveAll(destination); err != nil {
 		return fmt.Errorf("failed to remove the directory '%s' . Error: %w", destination, err)
 	}
-	if err := os.MkdirAll(destination, si.Mode()); err != nil {
+	if err := vfs.MkdirAll(destination, si.Mode()); err != nil {
 		return fmt.Errorf("failed to create the directory at path '%s' . Error: %w", destination, err)
 	}
-	if err := os.Chmod(destination, si.Mode()); err != nil {
+	if err := vfs.Chmod(destination, si.Mode()); err != nil {
 		return fmt.Errorf("failed to set the permissions of the destination file at path '%s' to be same as the source file. Error: %w", destination, err)
 	}
 	return nil
@@ -175,12 +176,12 @@ func writeTemplateToFile(tpl string, config interface{}, writepath string,
 	if err != nil {
 		return fmt.Errorf("unable to transform template to string using the data. Error: %q . Data: %+v Template: %q", err, config, tpl)
 	}
-	err = os.WriteFile(writepath, tplbuffer.Bytes(), filemode)
+	err = vfs.WriteFile(writepath, tplbuffer.Bytes(), filemode)
 	if err != nil {
 		logrus.Warnf("Error writing file at %s : %s", writepath, err)
 		return err
 	}
-	err = os.Chmod(writepath, filemode)
+	err = vfs.Chmod(writepath, filemode)
 	if err != nil {
 		logrus.Warnf("Error writing changing permissions at %s : %s", writepath, err)
 		return err
@@ -0,0 +1,173 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package filesystem
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
+)
+
+const (
+	// RejectFileExtension is the extension of the file containing the edited version when a binary file cannot be merged
+	RejectFileExtension = ".rej"
+	// ConflictMarkerOurs starts the edited lines of a conflict
+	ConflictMarkerOurs = "<<<<<<< edited"
+	// ConflictMarkerSeparator separates the edited and generated lines of a conflict
+	ConflictMarkerSeparator = "======="
+	// ConflictMarkerTheirs ends the generated lines of a conflict
+	ConflictMarkerTheirs = ">>>>>>> generated"
+)
+
+// ThreeWayMergeFiles merges the changes made to the base file in the ours file and the theirs file and writes it to the destination.
+// A missing base file is treated as empty. Text conflicts are written using conflict markers.
+// Binary files that cannot be merged are written as theirs, with ours written next to it with a .rej extension.
+// Returns true if there were conflicts.
+func ThreeWayMergeFiles(basePath, oursPath, theirsPath, destPath string) (bool, error) {
+	base, err := vfs.ReadFile(basePath)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read the base file at path %s . Error: %w", basePath, err)
+	}
+	ours, err := vfs.ReadFile(oursPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read the edited file at path %s . Error: %w", oursPath, err)
+	}
+	theirs, err := vfs.ReadFile(theirsPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read the generated file at path %s . Error: %w", theirsPath, err)
+	}
+	if bytes.Equal(ours, theirs) || bytes.Equal(base, ours) {
+		return false, vfs.WriteFile(destPath, theirs, common.DefaultFilePermission)
+	}
+	if bytes.Equal(base, theirs) {
+		return false, vfs.WriteFile(destPath, ours, common.DefaultFilePermission)
+	}
+	if bytes.IndexByte(base, 0) != -1 || bytes.IndexByte(ours, 0) != -1 || bytes.IndexByte(theirs, 0) != -1 {
+		if err := vfs.WriteFile(destPath+RejectFileExtension, ours, common.DefaultFilePermission); err != nil {
+			return true, fmt.Errorf("failed to write the rejected file at path %s . Error: %w", destPath+RejectFileExtension, err)
+		}
+		return true, vfs.WriteFile(destPath, theirs, common.DefaultFilePermission)
+	}
+	merged, conflict := ThreeWayMerge(string(base), string(ours), string(theirs))
+	return conflict, vfs.WriteFile(destPath, []byte(merged), common.DefaultFilePermission)
+}
+
+// ThreeWayMerge merges the line based changes made to base in ours and theirs.
+// Returns true if there were conflicts, which are surrounded by conflict markers in the result.
+func ThreeWayMerge(base, ours, theirs string) (string, bool) {
+	baseLines, oursLines, theirsLines := splitLines(base), splitLines(ours), splitLines(theirs)
+	oursMatches := getLCSMatches(baseLines, oursLines)
+	theirsMatches := getLCSMatches(baseLines, theirsLines)
+	merged := []string{}
+	conflict := false
+	i, a, b := 0, 0, 0
+	for {
+		// find the next base line that is unchanged in both ours and theirs
+		j := i
+		for ; j < len(baseLines); j++ {
+			if oursMatches[j] != -1 && theirsMatches[j] != -1 {
+				break
+			}
+		}
+		endA, endB := len(oursLines), len(theirsLines)
+		if j < len(baseLines) {
+			endA, endB = oursMatches[j], theirsMatches[j]
+		}
+		baseChunk, oursChunk, theirsChunk := baseLines[i:j], oursLines[a:endA], theirsLines[b:endB]
+		switch {
+		case equalLines(oursChunk, baseChunk):
+			merged = append(merged, theirsChunk...)
+		case equalLines(theirsChunk, baseChunk), equalLines(oursChunk, theirsChunk):
+			merged = append(merged, oursChunk...)
+		default:
+			conflict = true
+			merged = append(merged, ConflictMarkerOurs)
+			merged = append(merged, oursChunk...)
+			merged = append(merged, ConflictMarkerSeparator)
+			merged = append(merged, theirsChunk...)
+			merged = append(merged, ConflictMarkerTheirs)
+		}
+		if j == len(baseLines) {
+			break
+		}
+		merged = append(merged, baseLines[j])
+		i, a, b = j+1, endA+1, endB+1
+	}
+	result := strings.Join(merged, "\n")
+	if strings.HasSuffix(theirs, "\n") || (theirs == "" && strings.HasSuffix(ours, "\n")) {
+		result += "\n"
+	}
+	return result, conflict
+}
+
+// getLCSMatches returns for each line in xs the index of the matching line in ys in their longest common subsequence, or -1
+func getLCSMatches(xs, ys []string) []int {
+	lengths := make([][]int, len(xs)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(ys)+1)
+	}
+	for i := len(xs) - 1; i >= 0; i-- {
+		for j := len(ys) - 1; j >= 0; j-- {
+			if xs[i] == ys[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	matches := make([]int, len(xs))
+	for i := range matches {
+		matches[i] = -1
+	}
+	for i, j := 0, 0; i < len(xs) && j < len(ys); {
+		if xs[i] == ys[j] {
+			matches[i] = j
+			i++
+			j++
+		} else if lengths[i+1][j] >= lengths[i][j+1] {
+			i++
+		} else {
+			j++
+		}
+	}
+	return matches
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+func equalLines(xs, ys []string) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if xs[i] != ys[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,185 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestThreeWayMerge(t *testing.T) {
+	testcases := []struct {
+		name         string
+		base         string
+		ours         string
+		theirs       string
+		want         string
+		wantConflict bool
+	}{
+		{
+			name:   "no changes",
+			base:   "a\nb\nc\n",
+			ours:   "a\nb\nc\n",
+			theirs: "a\nb\nc\n",
+			want:   "a\nb\nc\n",
+		},
+		{
+			name:   "only edited",
+			base:   "a\nb\nc\n",
+			ours:   "a\nedited\nc\n",
+			theirs: "a\nb\nc\n",
+			want:   "a\nedited\nc\n",
+		},
+		{
+			name:   "only generated",
+			base:   "a\nb\nc\n",
+			ours:   "a\nb\nc\n",
+			theirs: "a\ngenerated\nc\n",
+			want:   "a\ngenerated\nc\n",
+		},
+		{
+			name:   "edited and generated different lines",
+			base:   "a\nb\nc\nd\ne\n",
+			ours:   "edited\nb\nc\nd\ne\n",
+			theirs: "a\nb\nc\nd\ngenerated\n",
+			want:   "edited\nb\nc\nd\ngenerated\n",
+		},
+		{
+			name:   "same change in both",
+			base:   "a\nb\nc\n",
+			ours:   "a\nchanged\nc\n",
+			theirs: "a\nchanged\nc\n",
+			want:   "a\nchanged\nc\n",
+		},
+		{
+			name:   "lines added in both at different places",
+			base:   "a\nb\nc\n",
+			ours:   "added\na\nb\nc\n",
+			theirs: "a\nb\nc\nappended\n",
+			want:   "added\na\nb\nc\nappended\n",
+		},
+		{
+			name:   "line removed in edited",
+			base:   "a\nb\nc\n",
+			ours:   "a\nc\n",
+			theirs: "a\nb\nc\n",
+			want:   "a\nc\n",
+		},
+		{
+			name:         "edited and generated the same line",
+			base:         "a\nb\nc\n",
+			ours:         "a\nedited\nc\n",
+			theirs:       "a\ngenerated\nc\n",
+			want:         "a\n" + ConflictMarkerOurs + "\nedited\n" + ConflictMarkerSeparator + "\ngenerated\n" + ConflictMarkerTheirs + "\nc\n",
+			wantConflict: true,
+		},
+		{
+			name:         "empty base",
+			base:         "",
+			ours:         "edited\n",
+			theirs:       "generated\n",
+			want:         ConflictMarkerOurs + "\nedited\n" + ConflictMarkerSeparator + "\ngenerated\n" + ConflictMarkerTheirs + "\n",
+			wantConflict: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			merged, conflict := ThreeWayMerge(tc.base, tc.ours, tc.theirs)
+			if conflict != tc.wantConflict {
+				t.Fatalf("expected conflict to be %t . Actual: %t", tc.wantConflict, conflict)
+			}
+			if merged != tc.want {
+				t.Fatalf("expected the merged content to be %q . Actual: %q", tc.want, merged)
+			}
+		})
+	}
+}
+
+func TestThreeWayMergeFiles(t *testing.T) {
+	writeFile := func(t *testing.T, path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write the file %s . Error: %q", path, err)
+		}
+	}
+	readFile := func(t *testing.T, path string) string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read the file %s . Error: %q", path, err)
+		}
+		return string(data)
+	}
+
+	t.Run("text files", func(t *testing.T) {
+		tempDir := t.TempDir()
+		basePath, oursPath, theirsPath, destPath := filepath.Join(tempDir, "base"), filepath.Join(tempDir, "ours"), filepath.Join(tempDir, "theirs"), filepath.Join(tempDir, "dest")
+		writeFile(t, basePath, "a\nb\nc\n")
+		writeFile(t, oursPath, "edited\nb\nc\n")
+		writeFile(t, theirsPath, "a\nb\ngenerated\n")
+		conflict, err := ThreeWayMergeFiles(basePath, oursPath, theirsPath, destPath)
+		if err != nil {
+			t.Fatalf("failed to merge the files. Error: %q", err)
+		}
+		if conflict {
+			t.Fatalf("expected no conflicts")
+		}
+		if merged, want := readFile(t, destPath), "edited\nb\ngenerated\n"; merged != want {
+			t.Fatalf("expected the merged file to contain %q . Actual: %q", want, merged)
+		}
+	})
+
+	t.Run("missing base file", func(t *testing.T) {
+		tempDir := t.TempDir()
+		oursPath, theirsPath, destPath := filepath.Join(tempDir, "ours"), filepath.Join(tempDir, "theirs"), filepath.Join(tempDir, "dest")
+		writeFile(t, oursPath, "edited\n")
+		writeFile(t, theirsPath, "generated\n")
+		conflict, err := ThreeWayMergeFiles(filepath.Join(tempDir, "base"), oursPath, theirsPath, destPath)
+		if err != nil {
+			t.Fatalf("failed to merge the files. Error: %q", err)
+		}
+		if !conflict {
+			t.Fatalf("expected a conflict")
+		}
+		if merged := readFile(t, destPath); !strings.Contains(merged, ConflictMarkerOurs) || !strings.Contains(merged, ConflictMarkerTheirs) {
+			t.Fatalf("expected the merged file to contain conflict markers. Actual: %q", merged)
+		}
+	})
+
+	t.Run("binary files", func(t *testing.T) {
+		tempDir := t.TempDir()
+		basePath, oursPath, theirsPath, destPath := filepath.Join(tempDir, "base"), filepath.Join(tempDir, "ours"), filepath.Join(tempDir, "theirs"), filepath.Join(tempDir, "dest")
+		writeFile(t, basePath, "base\x00")
+		writeFile(t, oursPath, "edited\x00")
+		writeFile(t, theirsPath, "generated\x00")
+		conflict, err := ThreeWayMergeFiles(basePath, oursPath, theirsPath, destPath)
+		if err != nil {
+			t.Fatalf("failed to merge the files. Error: %q", err)
+		}
+		if !conflict {
+			t.Fatalf("expected a conflict")
+		}
+		if merged, want := readFile(t, destPath), "generated\x00"; merged != want {
+			t.Fatalf("expected the merged file to contain the generated version %q . Actual: %q", want, merged)
+		}
+		if rejected, want := readFile(t, destPath+RejectFileExtension), "edited\x00"; rejected != want {
+			t.Fatalf("expected the rejected file to contain the edited version %q . Actual: %q", want, rejected)
+		}
+	})
+}
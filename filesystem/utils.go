@@ -17,17 +17,17 @@
 package filesystem
 
 import (
-	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/sirupsen/logrus"
 )
 
 // Copies file and sets mod time
 func copyFile(df, sf string, modTime time.Time) error {
-	err := os.MkdirAll(filepath.Dir(df), common.DefaultDirectoryPermission)
+	err := vfs.MkdirAll(filepath.Dir(df), common.DefaultDirectoryPermission)
 	if err != nil {
 		logrus.Errorf("Unable to make dir for %s : %s", filepath.Dir(df), err)
 		return err
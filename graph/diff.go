@@ -0,0 +1,250 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
+)
+
+// diffVertex is a vertex along with the key used to match it with the vertices of the other graph
+type diffVertex struct {
+	key         string
+	transformer string
+	class       string
+	vertex      graphtypes.Vertex
+}
+
+// diffEdge is an edge along with the keys of the vertices it connects
+type diffEdge struct {
+	key      string
+	artifact string
+	from     string
+	to       string
+}
+
+var diffNodeStyles = map[graphtypes.DiffStatus]map[string]string{
+	graphtypes.DiffAdded:   {"border": "2px solid #2e7d32", "background": "#e8f5e9"},
+	graphtypes.DiffRemoved: {"border": "2px dashed #c62828", "background": "#ffebee"},
+	graphtypes.DiffChanged: {"border": "2px solid #ef6c00", "background": "#fff3e0"},
+}
+
+var diffEdgeStyles = map[graphtypes.DiffStatus]map[string]string{
+	graphtypes.DiffAdded:   {"stroke": "#2e7d32", "strokeWidth": "2"},
+	graphtypes.DiffRemoved: {"stroke": "#c62828", "strokeWidth": "2", "strokeDasharray": "5 5"},
+	graphtypes.DiffChanged: {"stroke": "#ef6c00", "strokeWidth": "2"},
+}
+
+// getDiffVertices returns the vertices sorted by id and keyed by the transformer name, class and iteration.
+// A transformer that ran several times in the same iteration gets a numbered key for every run after the first.
+func getDiffVertices(graph graphtypes.Graph) ([]diffVertex, map[int]string) {
+	ids := []int{}
+	for id := range graph.Vertices {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	vertices := []diffVertex{}
+	keys := map[int]string{}
+	occurrences := map[string]int{}
+	for _, id := range ids {
+		vertex := graph.Vertices[id]
+		v := diffVertex{vertex: vertex}
+		v.transformer, _ = vertex.Data[graphtypes.GraphTransformerNameKey].(string)
+		if v.transformer == "" {
+			v.transformer = vertex.Name
+		}
+		v.class, _ = vertex.Data[graphtypes.GraphTransformerClassKey].(string)
+		if v.class == "" {
+			// graphs created before the class was stored in the vertex data only have it in the name
+			for _, line := range strings.Split(vertex.Name, "\n") {
+				if strings.HasPrefix(line, "class: ") {
+					v.class = strings.TrimPrefix(line, "class: ")
+				}
+			}
+		}
+		key := fmt.Sprintf("iteration %d/%s", vertex.Iteration, v.transformer)
+		if v.class != "" {
+			key += " (" + v.class + ")"
+		}
+		occurrences[key]++
+		if occurrences[key] > 1 {
+			key += fmt.Sprintf(" #%d", occurrences[key])
+		}
+		v.key = key
+		keys[id] = key
+		vertices = append(vertices, v)
+	}
+	return vertices, keys
+}
+
+// getDiffEdges returns the edges sorted by id and keyed by the artifact and the vertex consuming it
+func getDiffEdges(graph graphtypes.Graph, vertexKeys map[int]string) []diffEdge {
+	ids := []int{}
+	for id := range graph.Edges {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	edges := []diffEdge{}
+	occurrences := map[string]int{}
+	for _, id := range ids {
+		edge := graph.Edges[id]
+		e := diffEdge{from: vertexKeys[edge.From], to: vertexKeys[edge.To]}
+		if artifacts := getEdgeArtifacts(edge); len(artifacts) != 0 {
+			e.artifact = artifacts[0]
+		} else if strings.HasSuffix(edge.Name, "("+invokedByDefaultLabel+")") {
+			e.artifact = invokedByDefaultLabel
+		}
+		key := e.artifact + " -> " + e.to
+		occurrences[key]++
+		if occurrences[key] > 1 {
+			key += fmt.Sprintf(" #%d", occurrences[key])
+		}
+		e.key = key
+		edges = append(edges, e)
+	}
+	return edges
+}
+
+// getVertexFields returns the fields of the vertex that are compared. The path mappings are compared using their types and
+// destinations, since their sources contain the temporary directories of the run.
+func getVertexFields(vertex graphtypes.Vertex) [][2]string {
+	pathMappings := []string{}
+	if summary, ok := vertex.Data["pathMappings"].(string); ok {
+		for _, line := range strings.Split(summary, "\n") {
+			parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(line, "("), ")"), ", ")
+			if line == "" || len(parts) < 2 {
+				continue
+			}
+			pathMappings = common.AppendIfNotPresent(pathMappings, parts[0]+" "+parts[len(parts)-1])
+		}
+	}
+	sortedStrings := func(values []string) string {
+		values = append([]string{}, values...)
+		sort.Strings(values)
+		return strings.Join(values, ", ")
+	}
+	errorText, _ := vertex.Data[graphtypes.GraphErrorKey].(string)
+	return [][2]string{
+		{"consumedArtifacts", sortedStrings(getStrings(vertex.Data["consumedArtifacts"]))},
+		{"producedArtifacts", sortedStrings(getStrings(vertex.Data["producedArtifacts"]))},
+		{"pathMappings", sortedStrings(pathMappings)},
+		{"error", errorText},
+	}
+}
+
+// DiffGraphs compares the graphs of two runs. The result contains the vertices and edges of the new graph in order, followed by the removed ones.
+func DiffGraphs(oldGraph, newGraph graphtypes.Graph) graphtypes.GraphDiff {
+	diff := graphtypes.GraphDiff{Vertices: []graphtypes.VertexDiff{}, Edges: []graphtypes.EdgeDiff{}}
+	oldVertices, oldVertexKeys := getDiffVertices(oldGraph)
+	newVertices, newVertexKeys := getDiffVertices(newGraph)
+	oldVerticesByKey := map[string]diffVertex{}
+	for _, v := range oldVertices {
+		oldVerticesByKey[v.key] = v
+	}
+	newVertexKeySet := map[string]bool{}
+	for _, v := range newVertices {
+		newVertexKeySet[v.key] = true
+		vertexDiff := graphtypes.VertexDiff{Key: v.key, Status: graphtypes.DiffAdded, Transformer: v.transformer, Class: v.class, Iteration: v.vertex.Iteration}
+		if oldVertex, ok := oldVerticesByKey[v.key]; ok {
+			vertexDiff.Status = graphtypes.DiffUnchanged
+			oldFields := getVertexFields(oldVertex.vertex)
+			for i, field := range getVertexFields(v.vertex) {
+				if field[1] != oldFields[i][1] {
+					vertexDiff.Changes = append(vertexDiff.Changes, graphtypes.FieldChange{Field: field[0], Before: oldFields[i][1], After: field[1]})
+				}
+			}
+			if len(vertexDiff.Changes) != 0 {
+				vertexDiff.Status = graphtypes.DiffChanged
+			}
+		}
+		diff.Vertices = append(diff.Vertices, vertexDiff)
+	}
+	for _, v := range oldVertices {
+		if !newVertexKeySet[v.key] {
+			diff.Vertices = append(diff.Vertices, graphtypes.VertexDiff{Key: v.key, Status: graphtypes.DiffRemoved, Transformer: v.transformer, Class: v.class, Iteration: v.vertex.Iteration})
+		}
+	}
+	oldEdges := getDiffEdges(oldGraph, oldVertexKeys)
+	newEdges := getDiffEdges(newGraph, newVertexKeys)
+	oldEdgesByKey := map[string]diffEdge{}
+	for _, e := range oldEdges {
+		oldEdgesByKey[e.key] = e
+	}
+	newEdgeKeySet := map[string]bool{}
+	for _, e := range newEdges {
+		newEdgeKeySet[e.key] = true
+		edgeDiff := graphtypes.EdgeDiff{Artifact: e.artifact, Status: graphtypes.DiffAdded, From: e.from, To: e.to}
+		if oldEdge, ok := oldEdgesByKey[e.key]; ok {
+			edgeDiff.Status = graphtypes.DiffUnchanged
+			if oldEdge.from != e.from {
+				edgeDiff.Status = graphtypes.DiffChanged
+				edgeDiff.PreviousFrom = oldEdge.from
+			}
+		}
+		diff.Edges = append(diff.Edges, edgeDiff)
+	}
+	for _, e := range oldEdges {
+		if !newEdgeKeySet[e.key] {
+			diff.Edges = append(diff.Edges, graphtypes.EdgeDiff{Artifact: e.artifact, Status: graphtypes.DiffRemoved, From: e.from, To: e.to})
+		}
+	}
+	return diff
+}
+
+// GetDiffNodesAndEdges returns the nodes and edges of a graph combining both the graphs, where the differences are highlighted
+func GetDiffNodesAndEdges(oldGraph, newGraph graphtypes.Graph, diff graphtypes.GraphDiff) ([]graphtypes.Node, []graphtypes.EdgeT) {
+	oldVertices, _ := getDiffVertices(oldGraph)
+	newVertices, _ := getDiffVertices(newGraph)
+	vertices := map[string]graphtypes.Vertex{}
+	for _, v := range oldVertices {
+		vertices[v.key] = v.vertex
+	}
+	for _, v := range newVertices {
+		vertices[v.key] = v.vertex
+	}
+	combined := graphtypes.NewGraph()
+	vertexIds := map[string]int{}
+	vertexStatuses := map[string]graphtypes.DiffStatus{}
+	for _, vertexDiff := range diff.Vertices {
+		vertex := vertices[vertexDiff.Key]
+		name := vertex.Name
+		if vertexDiff.Status != graphtypes.DiffUnchanged {
+			name = fmt.Sprintf("[%s]\n%s", vertexDiff.Status, name)
+		}
+		id := combined.AddVertex(name, vertex.Iteration, map[string]interface{}{"pathMappings": vertex.Data["pathMappings"]})
+		vertexIds[vertexDiff.Key] = id
+		vertexStatuses[fmt.Sprintf("v-%d", id)] = vertexDiff.Status
+	}
+	edgeStatuses := map[string]graphtypes.DiffStatus{}
+	for _, edgeDiff := range diff.Edges {
+		from, to := vertexIds[edgeDiff.From], vertexIds[edgeDiff.To]
+		id := combined.AddEdge(from, to, fmt.Sprintf("%d -> %d", from, to), map[string]interface{}{"newArtifact": []string{edgeDiff.Artifact}})
+		edgeStatuses[fmt.Sprintf("e-%d", id)] = edgeDiff.Status
+	}
+	nodes, edges := GetNodesAndEdges(*combined)
+	for i, node := range nodes {
+		nodes[i].Style = diffNodeStyles[vertexStatuses[node.Id]]
+	}
+	for i, edge := range edges {
+		edges[i].Style = diffEdgeStyles[edgeStatuses[edge.Id]]
+	}
+	return nodes, edges
+}
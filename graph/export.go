@@ -0,0 +1,206 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
+)
+
+const (
+	// DOTFormat exports the graph in the Graphviz DOT language
+	DOTFormat = "dot"
+	// MermaidFormat exports the graph as a Mermaid flowchart
+	MermaidFormat = "mermaid"
+	// JSONFormat exports the graph as json without any layout information
+	JSONFormat = "json"
+	// invokedByDefaultLabel is the edge label used for the transformers that are invoked by default
+	invokedByDefaultLabel = "invoked by default"
+)
+
+// ExportFormats contains the formats the graph can be exported to
+var ExportFormats = []string{DOTFormat, MermaidFormat, JSONFormat}
+
+// GetExportedGraph returns the graph with its vertices sorted by id and the artifacts passed between each pair of vertices grouped into a single edge.
+func GetExportedGraph(graph graphtypes.Graph) graphtypes.ExportedGraph {
+	exported := graphtypes.ExportedGraph{Version: graph.Version, Vertices: []graphtypes.ExportedVertex{}, Edges: []graphtypes.ExportedEdge{}}
+	for _, vertex := range graph.Vertices {
+		exportedVertex := graphtypes.ExportedVertex{Id: vertex.Id, Iteration: vertex.Iteration, Name: vertex.Name}
+		exportedVertex.Transformer, _ = vertex.Data[graphtypes.GraphTransformerNameKey].(string)
+		exportedVertex.Duration, _ = vertex.Data[graphtypes.GraphDurationKey].(string)
+		exportedVertex.Error, _ = vertex.Data[graphtypes.GraphErrorKey].(string)
+		exported.Vertices = append(exported.Vertices, exportedVertex)
+	}
+	sort.Slice(exported.Vertices, func(i, j int) bool { return exported.Vertices[i].Id < exported.Vertices[j].Id })
+	edgeIds := []int{}
+	for id := range graph.Edges {
+		edgeIds = append(edgeIds, id)
+	}
+	sort.Ints(edgeIds)
+	edgeIdxs := map[[2]int]int{}
+	for _, id := range edgeIds {
+		edge := graph.Edges[id]
+		key := [2]int{edge.From, edge.To}
+		idx, ok := edgeIdxs[key]
+		if !ok {
+			idx = len(exported.Edges)
+			edgeIdxs[key] = idx
+			exported.Edges = append(exported.Edges, graphtypes.ExportedEdge{From: edge.From, To: edge.To, Artifacts: []string{}})
+		}
+		exportedEdge := exported.Edges[idx]
+		if strings.HasSuffix(edge.Name, "("+invokedByDefaultLabel+")") {
+			exportedEdge.InvokedByDefault = true
+		}
+		exportedEdge.Artifacts = append(exportedEdge.Artifacts, getEdgeArtifacts(edge)...)
+		exported.Edges[idx] = exportedEdge
+	}
+	return exported
+}
+
+// getEdgeArtifacts returns the artifacts summarized in the edge data
+func getEdgeArtifacts(edge graphtypes.Edge) []string {
+	return getStrings(edge.Data["newArtifact"])
+}
+
+// getStrings returns the list of strings stored in the vertex or edge data. It handles both the graph that was just created and the one decoded from json.
+func getStrings(value interface{}) []string {
+	switch values := value.(type) {
+	case []string:
+		return values
+	case []interface{}:
+		strs := []string{}
+		for _, v := range values {
+			if str, ok := v.(string); ok {
+				strs = append(strs, str)
+			}
+		}
+		return strs
+	}
+	return nil
+}
+
+// getEdgeLabels returns the lines of the label of an exported edge
+func getEdgeLabels(edge graphtypes.ExportedEdge) []string {
+	labels := append([]string{}, edge.Artifacts...)
+	if edge.InvokedByDefault {
+		labels = append(labels, invokedByDefaultLabel)
+	}
+	return labels
+}
+
+// Export returns the graph in the given format
+func Export(graph graphtypes.Graph, format string) ([]byte, error) {
+	exported := GetExportedGraph(graph)
+	switch format {
+	case DOTFormat:
+		return []byte(ToDOT(exported)), nil
+	case MermaidFormat:
+		return []byte(ToMermaid(exported)), nil
+	case JSONFormat:
+		exportedBytes, err := json.MarshalIndent(exported, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal the graph to json. Error: %w", err)
+		}
+		return append(exportedBytes, '\n'), nil
+	}
+	return nil, fmt.Errorf("the graph format '%s' is not supported. Supported formats: %+v", format, ExportFormats)
+}
+
+// ToDOT returns the graph in the Graphviz DOT language. The transformers that ran in the same iteration are placed in the same rank.
+func ToDOT(graph graphtypes.ExportedGraph) string {
+	var sb strings.Builder
+	sb.WriteString("digraph move2kube {\n")
+	sb.WriteString("  rankdir=TB;\n")
+	sb.WriteString("  node [shape=box, fontname=\"Helvetica\"];\n")
+	sb.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n")
+	iterations := map[int][]string{}
+	for _, vertex := range graph.Vertices {
+		id := fmt.Sprintf("v%d", vertex.Id)
+		attrs := ""
+		if vertex.Id == 0 {
+			attrs += ", shape=ellipse"
+		}
+		if vertex.Error != "" {
+			attrs += ", color=red, tooltip=" + quoteDOT(vertex.Error)
+		}
+		fmt.Fprintf(&sb, "  %s [label=%s%s];\n", id, quoteDOT(vertex.Name), attrs)
+		iterations[vertex.Iteration] = append(iterations[vertex.Iteration], id)
+	}
+	iterationNumbers := []int{}
+	for iteration := range iterations {
+		iterationNumbers = append(iterationNumbers, iteration)
+	}
+	sort.Ints(iterationNumbers)
+	for _, iteration := range iterationNumbers {
+		fmt.Fprintf(&sb, "  { rank=same; %s; }\n", strings.Join(iterations[iteration], "; "))
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&sb, "  v%d -> v%d", edge.From, edge.To)
+		if labels := getEdgeLabels(edge); len(labels) != 0 {
+			fmt.Fprintf(&sb, " [label=%s]", quoteDOT(strings.Join(labels, "\n")))
+		}
+		sb.WriteString(";\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// quoteDOT returns the string as a DOT quoted string
+func quoteDOT(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// ToMermaid returns the graph as a Mermaid flowchart
+func ToMermaid(graph graphtypes.ExportedGraph) string {
+	var sb strings.Builder
+	sb.WriteString("flowchart TB\n")
+	for _, vertex := range graph.Vertices {
+		if vertex.Id == 0 {
+			fmt.Fprintf(&sb, "  v%d([%s])\n", vertex.Id, quoteMermaid(vertex.Name))
+			continue
+		}
+		fmt.Fprintf(&sb, "  v%d[%s]\n", vertex.Id, quoteMermaid(vertex.Name))
+	}
+	for _, edge := range graph.Edges {
+		if labels := getEdgeLabels(edge); len(labels) != 0 {
+			fmt.Fprintf(&sb, "  v%d -->|%s| v%d\n", edge.From, quoteMermaid(strings.Join(labels, "\n")), edge.To)
+			continue
+		}
+		fmt.Fprintf(&sb, "  v%d --> v%d\n", edge.From, edge.To)
+	}
+	for _, vertex := range graph.Vertices {
+		if vertex.Error != "" {
+			fmt.Fprintf(&sb, "  style v%d stroke:#d00,stroke-width:2px\n", vertex.Id)
+		}
+	}
+	return sb.String()
+}
+
+// quoteMermaid returns the string as a Mermaid quoted string, the characters that Mermaid does not allow in labels are replaced with entity codes
+func quoteMermaid(s string) string {
+	s = strings.ReplaceAll(s, `"`, "#quot;")
+	s = strings.ReplaceAll(s, "|", "#124;")
+	s = strings.ReplaceAll(s, "\n", "<br/>")
+	return `"` + s + `"`
+}
@@ -18,9 +18,10 @@ package graph
 
 import (
 	"fmt"
+	"math"
 	"sort"
+	"strings"
 
-	"github.com/konveyor/move2kube-wasm/common"
 	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
 	"github.com/sirupsen/logrus"
 )
@@ -48,8 +49,8 @@ func GetNodesAndEdges(graph graphtypes.Graph) ([]graphtypes.Node, []graphtypes.E
 	edges := []graphtypes.EdgeT{}
 	for _, edge := range graph.Edges {
 		label := edge.Name
-		if xs, ok := edge.Data["newArtifact"].([]interface{}); ok && len(xs) != 0 {
-			label += " (" + xs[0].(string) + ")"
+		if artifacts := getEdgeArtifacts(edge); len(artifacts) != 0 {
+			label += " (" + artifacts[0] + ")"
 		}
 		edges = append(edges, graphtypes.EdgeT{
 			Id:        fmt.Sprintf("e-%d", edge.Id),
@@ -62,141 +63,292 @@ func GetNodesAndEdges(graph graphtypes.Graph) ([]graphtypes.Node, []graphtypes.E
 	return nodes, edges
 }
 
-type graphIterAndSubIter struct {
-	Iter int
-	Sub  int
+const (
+	// layoutHorizontalSpacing is the minimum distance between two nodes in the same layer
+	layoutHorizontalSpacing = 200
+	// layoutVerticalSpacing is the distance between two layers
+	layoutVerticalSpacing = 200
+	// layoutOrderingSweeps is the maximum number of barycenter sweeps used to reduce the edge crossings
+	layoutOrderingSweeps = 24
+	// layoutPositioningSweeps is the number of sweeps used to straighten the edges after the nodes are ordered
+	layoutPositioningSweeps = 8
+)
+
+// layoutNode is a node in the layered graph. Edges that span several layers are split using dummy nodes that are not displayed.
+type layoutNode struct {
+	// nodeIdx is the index of the node in the list of nodes, -1 for dummy nodes
+	nodeIdx   int
+	vertexId  int
+	iteration int
+	// service is the name of the service whose artifacts the node consumes, it groups the nodes of a service together
+	service string
+	layer   int
+	order   int
+	x       float64
+	up      []*layoutNode
+	down    []*layoutNode
 }
 
-type graphSortIters struct {
-	Items []graphIterAndSubIter
+// LayeredUpdatePositions updates the positions of the nodes using a layered (Sugiyama style) layout.
+// The nodes are assigned to layers in the order of the iterations, the edge crossings between the layers are reduced using the
+// barycenter heuristic, starting from an order that groups the nodes of each service together, and the nodes are then
+// moved towards the nodes they are connected to. The Y coordinate of the nodes must contain their iteration.
+func LayeredUpdatePositions(nodes []graphtypes.Node, edges []graphtypes.EdgeT) {
+	layoutNodes := map[string]*layoutNode{}
+	sortedNodes := []*layoutNode{}
+	for i, node := range nodes {
+		vertexId := i
+		if _, err := fmt.Sscanf(node.Id, "v-%d", &vertexId); err != nil {
+			logrus.Debugf("failed to get the vertex id of the node '%s' . Error: %q", node.Id, err)
+		}
+		layoutNodes[node.Id] = &layoutNode{nodeIdx: i, vertexId: vertexId, iteration: node.Position.Y}
+		sortedNodes = append(sortedNodes, layoutNodes[node.Id])
+	}
+	// the vertices are created in the order the transformers run, so sorting them gives a topological order
+	sort.Slice(sortedNodes, func(i, j int) bool {
+		if sortedNodes[i].iteration != sortedNodes[j].iteration {
+			return sortedNodes[i].iteration < sortedNodes[j].iteration
+		}
+		return sortedNodes[i].vertexId < sortedNodes[j].vertexId
+	})
+	rank := map[*layoutNode]int{}
+	for i, n := range sortedNodes {
+		rank[n] = i
+	}
+	type layoutEdge struct {
+		from    *layoutNode
+		to      *layoutNode
+		service string
+	}
+	layoutEdges := []layoutEdge{}
+	incoming := map[*layoutNode][]layoutEdge{}
+	for _, edge := range edges {
+		from, to := layoutNodes[edge.Source], layoutNodes[edge.Target]
+		if from == nil || to == nil {
+			logrus.Errorf("failed to find the nodes of the edge %+v", edge)
+			continue
+		}
+		if from == to {
+			continue
+		}
+		if rank[from] > rank[to] {
+			// reverse the edges that go back in time so that the layered graph has no cycles
+			from, to = to, from
+		}
+		layoutEdge := layoutEdge{from: from, to: to, service: getEdgeServiceName(edge.Label)}
+		layoutEdges = append(layoutEdges, layoutEdge)
+		incoming[to] = append(incoming[to], layoutEdge)
+	}
+
+	// assign the layers, a node is placed below the nodes it consumes artifacts from and below all the nodes of the previous iterations
+	layers := [][]*layoutNode{}
+	maxLayer, iterationBase, lastIteration := -1, 0, 0
+	for i, n := range sortedNodes {
+		if i == 0 || n.iteration != lastIteration {
+			iterationBase = maxLayer + 1
+			lastIteration = n.iteration
+		}
+		n.layer = iterationBase
+		for _, e := range incoming[n] {
+			if e.from.layer+1 > n.layer {
+				n.layer = e.from.layer + 1
+			}
+			if n.service == "" || (e.service != "" && e.service < n.service) {
+				n.service = e.service
+			}
+		}
+		if n.layer > maxLayer {
+			maxLayer = n.layer
+		}
+		for len(layers) <= n.layer {
+			layers = append(layers, []*layoutNode{})
+		}
+		layers[n.layer] = append(layers[n.layer], n)
+	}
+
+	// split the long edges using dummy nodes
+	for _, e := range layoutEdges {
+		prev := e.from
+		for layer := e.from.layer + 1; layer < e.to.layer; layer++ {
+			dummy := &layoutNode{nodeIdx: -1, vertexId: e.from.vertexId, service: e.service, layer: layer}
+			layers[layer] = append(layers[layer], dummy)
+			prev.down = append(prev.down, dummy)
+			dummy.up = append(dummy.up, prev)
+			prev = dummy
+		}
+		prev.down = append(prev.down, e.to)
+		e.to.up = append(e.to.up, prev)
+	}
+
+	// order the nodes in each layer
+	for _, layer := range layers {
+		sort.SliceStable(layer, func(i, j int) bool {
+			if layer[i].service != layer[j].service {
+				return layer[i].service < layer[j].service
+			}
+			return layer[i].vertexId < layer[j].vertexId
+		})
+		setLayoutOrder(layer)
+	}
+	bestOrders := saveLayoutOrders(layers)
+	bestCrossings := countLayoutCrossings(layers)
+	for sweep := 0; sweep < layoutOrderingSweeps && bestCrossings > 0; sweep++ {
+		if sweep%2 == 0 {
+			for l := 1; l < len(layers); l++ {
+				orderByBarycenter(layers[l], func(n *layoutNode) []*layoutNode { return n.up })
+			}
+		} else {
+			for l := len(layers) - 2; l >= 0; l-- {
+				orderByBarycenter(layers[l], func(n *layoutNode) []*layoutNode { return n.down })
+			}
+		}
+		if crossings := countLayoutCrossings(layers); crossings < bestCrossings {
+			bestCrossings = crossings
+			bestOrders = saveLayoutOrders(layers)
+		}
+	}
+	restoreLayoutOrders(layers, bestOrders)
+
+	// assign the X coordinates
+	for _, layer := range layers {
+		for i, n := range layer {
+			n.x = float64(i * layoutHorizontalSpacing)
+		}
+	}
+	for sweep := 0; sweep < layoutPositioningSweeps; sweep++ {
+		if sweep%2 == 0 {
+			for l := 1; l < len(layers); l++ {
+				placeAtBarycenter(layers[l], func(n *layoutNode) []*layoutNode { return n.up })
+			}
+		} else {
+			for l := len(layers) - 2; l >= 0; l-- {
+				placeAtBarycenter(layers[l], func(n *layoutNode) []*layoutNode { return n.down })
+			}
+		}
+	}
+	minX := 0.0
+	for i, n := range sortedNodes {
+		if i == 0 || n.x < minX {
+			minX = n.x
+		}
+	}
+	for _, n := range sortedNodes {
+		nodes[n.nodeIdx].Position = graphtypes.Position{
+			X: int(math.Round(n.x - minX)),
+			Y: n.layer * layoutVerticalSpacing,
+		}
+	}
 }
 
-// Len is the number of elements in the collection.
-func (x *graphSortIters) Len() int {
-	return len(x.Items)
+// getEdgeServiceName returns the name of the artifact in an edge label like "0 -> 1 (nodeapp - Service)"
+func getEdgeServiceName(label string) string {
+	start := strings.LastIndex(label, " (")
+	if start < 0 || !strings.HasSuffix(label, ")") {
+		return ""
+	}
+	artifact := label[start+2 : len(label)-1]
+	end := strings.LastIndex(artifact, " - ")
+	if end < 0 {
+		return ""
+	}
+	return artifact[:end]
 }
 
-// Less reports whether the element with index i
-// must sort before the element with index j.
-func (x *graphSortIters) Less(i, j int) bool {
-	return x.Items[i].Iter < x.Items[j].Iter || (x.Items[i].Iter == x.Items[j].Iter && x.Items[i].Sub < x.Items[j].Sub)
+func setLayoutOrder(layer []*layoutNode) {
+	for i, n := range layer {
+		n.order = i
+	}
 }
 
-// Swap swaps the elements with indexes i and j.
-func (x *graphSortIters) Swap(i, j int) {
-	x.Items[i], x.Items[j] = x.Items[j], x.Items[i]
+func saveLayoutOrders(layers [][]*layoutNode) [][]*layoutNode {
+	orders := [][]*layoutNode{}
+	for _, layer := range layers {
+		orders = append(orders, append([]*layoutNode{}, layer...))
+	}
+	return orders
 }
 
-// BfsUpdatePositions updates the positions of the nodes using a layered layout algorithm that utilizes Breadth First Search.
-func BfsUpdatePositions(nodes []graphtypes.Node, edges []graphtypes.EdgeT) {
-	adjMat := map[string]map[string]struct{}{}
-	for _, edge := range edges {
-		if adjMat[edge.Source] == nil {
-			adjMat[edge.Source] = map[string]struct{}{}
-		}
-		adjMat[edge.Source][edge.Target] = struct{}{}
-	}
-
-	iterSubIterToY := map[graphIterAndSubIter]int{}
-	{
-		// depth first search to calculate sub-iterations
-		subIters := map[graphIterAndSubIter]struct{}{}
-		visited := map[string]struct{}{"v-0": {}}
-		dfsRecursive(nodes, subIters, visited, adjMat, "v-0", -1)
-		sortIters := graphSortIters{}
-		for subIter := range subIters {
-			sortIters.Items = append(sortIters.Items, subIter)
-		}
-		sort.Stable(&sortIters)
-		for i, x := range sortIters.Items {
-			iterSubIterToY[x] = i * 200
-		}
-	}
-
-	visited := map[string]struct{}{"v-0": {}}
-	iterSubIterToX := map[graphIterAndSubIter]int{}
-	{
-		// the bread first search algorithm
-		queue := []string{"v-0"}
-		for len(queue) > 0 {
-			// pop a vertex from the queue
-			current := queue[0]
-			queue = queue[1:]
-			// add its neighbours to the queue for processing later
-			neighbours := adjMat[current]
-			sortedNotVisitedNeighbours := []string{}
-			for neighbour := range neighbours {
-				if _, ok := visited[neighbour]; !ok {
-					sortedNotVisitedNeighbours = append(sortedNotVisitedNeighbours, neighbour)
-					visited[neighbour] = struct{}{}
-				}
-			}
-			sort.StringSlice(sortedNotVisitedNeighbours).Sort()
-			queue = append(queue, sortedNotVisitedNeighbours...)
-			// calculate the new position for the current node
-			idx := common.FindIndex(nodes, func(n graphtypes.Node) bool { return n.Id == current })
-			if idx < 0 {
-				logrus.Errorf("failed to find a node for the vertex with id '%s'", current)
-				continue
+func restoreLayoutOrders(layers [][]*layoutNode, orders [][]*layoutNode) {
+	for l, order := range orders {
+		copy(layers[l], order)
+		setLayoutOrder(layers[l])
+	}
+}
+
+// orderByBarycenter sorts the layer by the average order of the neighbours of each node in the adjacent layer.
+// Nodes without neighbours keep their current order.
+func orderByBarycenter(layer []*layoutNode, neighbours func(*layoutNode) []*layoutNode) {
+	barycenters := map[*layoutNode]float64{}
+	for _, n := range layer {
+		barycenters[n] = float64(n.order)
+		if ns := neighbours(n); len(ns) != 0 {
+			sum := 0.0
+			for _, neighbour := range ns {
+				sum += float64(neighbour.order)
 			}
-			node := nodes[idx]
-			ii := graphIterAndSubIter{Iter: node.Position.Y, Sub: node.Position.X}
-			newX := iterSubIterToX[ii]
-			iterSubIterToX[ii] = newX + 200
-			node.Position.X = newX
-			node.Position.Y = iterSubIterToY[ii]
-			nodes[idx] = node
+			barycenters[n] = sum / float64(len(ns))
 		}
 	}
+	sort.SliceStable(layer, func(i, j int) bool { return barycenters[layer[i]] < barycenters[layer[j]] })
+	setLayoutOrder(layer)
+}
 
-	// handle islands
-	for i, node := range nodes {
-		if _, ok := visited[node.Id]; ok {
-			continue
+// countLayoutCrossings returns the number of edges that cross each other between all the adjacent layers
+func countLayoutCrossings(layers [][]*layoutNode) int {
+	crossings := 0
+	for _, layer := range layers {
+		type segment struct{ from, to int }
+		segments := []segment{}
+		for _, n := range layer {
+			for _, d := range n.down {
+				segments = append(segments, segment{from: n.order, to: d.order})
+			}
+		}
+		for i := range segments {
+			for j := i + 1; j < len(segments); j++ {
+				a, b := segments[i], segments[j]
+				if (a.from < b.from && a.to > b.to) || (a.from > b.from && a.to < b.to) {
+					crossings++
+				}
+			}
 		}
-		logrus.Errorf("found an unvisited node: %+v", node)
-		visited[node.Id] = struct{}{}
-		// calculate the new position for the current node
-		ii := graphIterAndSubIter{Iter: node.Position.Y, Sub: node.Position.X}
-		newX := iterSubIterToX[ii]
-		iterSubIterToX[ii] = newX + 200
-		node.Position.X = newX
-		node.Position.Y = iterSubIterToY[ii]
-		nodes[i] = node
 	}
+	return crossings
 }
 
-func dfsRecursive(
-	nodes []graphtypes.Node,
-	subIters map[graphIterAndSubIter]struct{},
-	visited map[string]struct{},
-	adjMat map[string]map[string]struct{},
-	current string,
-	parentIdx int,
-) {
-	visited[current] = struct{}{}
-	currentIdx := common.FindIndex(nodes, func(n graphtypes.Node) bool { return n.Id == current })
-	if currentIdx < 0 {
-		logrus.Errorf("failed to find the vertex with id '%s' in the list of nodes", current)
+// placeAtBarycenter moves the nodes in the layer towards the average X coordinate of their neighbours,
+// keeping their order and the minimum spacing between them
+func placeAtBarycenter(layer []*layoutNode, neighbours func(*layoutNode) []*layoutNode) {
+	if len(layer) == 0 {
 		return
 	}
-	// calculate sub-iteration
-	currentNode := nodes[currentIdx]
-	if parentIdx >= 0 {
-		parentNode := nodes[parentIdx]
-		parentIteration := parentNode.Position.Y
-		currentIteration := currentNode.Position.Y
-		if parentIteration == currentIteration {
-			// store the sub iteration in the X coordinate
-			currentNode.Position.X = parentNode.Position.X + 1
-			nodes[currentIdx] = currentNode
+	desired := make([]float64, len(layer))
+	for i, n := range layer {
+		desired[i] = n.x
+		if ns := neighbours(n); len(ns) != 0 {
+			sum := 0.0
+			for _, neighbour := range ns {
+				sum += neighbour.x
+			}
+			desired[i] = sum / float64(len(ns))
+		}
+	}
+	// the average of the leftmost and the rightmost placements that keep the spacing is as close to the desired positions as possible on both sides
+	left := make([]float64, len(layer))
+	right := make([]float64, len(layer))
+	for i := range layer {
+		left[i] = desired[i]
+		if i > 0 && left[i-1]+layoutHorizontalSpacing > left[i] {
+			left[i] = left[i-1] + layoutHorizontalSpacing
 		}
 	}
-	subIters[graphIterAndSubIter{Iter: currentNode.Position.Y, Sub: currentNode.Position.X}] = struct{}{}
-	neighbours := adjMat[current]
-	for neighbour := range neighbours {
-		if _, ok := visited[neighbour]; !ok {
-			dfsRecursive(nodes, subIters, visited, adjMat, neighbour, currentIdx)
+	for i := len(layer) - 1; i >= 0; i-- {
+		right[i] = desired[i]
+		if i < len(layer)-1 && right[i+1]-layoutHorizontalSpacing < right[i] {
+			right[i] = right[i+1] - layoutHorizontalSpacing
 		}
 	}
+	for i, n := range layer {
+		n.x = (left[i] + right[i]) / 2
+	}
 }
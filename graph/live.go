@@ -0,0 +1,239 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/types/event"
+	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// liveSubscriberBufferSize is the number of events that can be queued for a client before it is disconnected
+	liveSubscriberBufferSize = 1024
+	// liveKeepAliveInterval is how often a comment is sent to the clients to keep the connections open
+	liveKeepAliveInterval = 15 * time.Second
+)
+
+// liveGraph contains the events published so far and the graph built from them
+type liveGraph struct {
+	mutex       sync.Mutex
+	events      []event.Event
+	graph       *graphtypes.Graph
+	subscribers map[chan event.Event]bool
+}
+
+func newLiveGraph() *liveGraph {
+	return &liveGraph{graph: graphtypes.NewGraph(), subscribers: map[chan event.Event]bool{}}
+}
+
+// add records the event, adds its vertex and edges to the graph and sends it to the subscribers
+func (l *liveGraph) add(e event.Event) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.events = append(l.events, e)
+	if e.Vertex != nil {
+		l.graph.Vertices[e.Vertex.Id] = *e.Vertex
+		if l.graph.SourceVertexId == -1 {
+			l.graph.SourceVertexId = e.Vertex.Id
+		}
+	}
+	for _, edge := range e.Edges {
+		l.graph.Edges[edge.Id] = edge
+	}
+	for subscriber := range l.subscribers {
+		select {
+		case subscriber <- e:
+		default:
+			// the client is too slow, it can reconnect and continue from the last event it received
+			delete(l.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+}
+
+// subscribe returns the events after the given sequence number and a channel for the events published later
+func (l *liveGraph) subscribe(lastSequence int) ([]event.Event, chan event.Event) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	missed := []event.Event{}
+	for _, e := range l.events {
+		if e.Sequence > lastSequence {
+			missed = append(missed, e)
+		}
+	}
+	subscriber := make(chan event.Event, liveSubscriberBufferSize)
+	l.subscribers[subscriber] = true
+	return missed, subscriber
+}
+
+// closeSubscribers ends the event streams after the events already queued for the clients have been sent
+func (l *liveGraph) closeSubscribers() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for subscriber := range l.subscribers {
+		delete(l.subscribers, subscriber)
+		close(subscriber)
+	}
+}
+
+func (l *liveGraph) unsubscribe(subscriber chan event.Event) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.subscribers[subscriber] {
+		delete(l.subscribers, subscriber)
+		close(subscriber)
+	}
+}
+
+// getWebGraph returns the nodes and edges of the graph built so far
+func (l *liveGraph) getWebGraph() graphtypes.GraphT {
+	l.mutex.Lock()
+	nodes, edges := GetNodesAndEdges(*l.graph)
+	l.mutex.Unlock()
+	LayeredUpdatePositions(nodes, edges)
+	return graphtypes.GraphT{Nodes: nodes, Edges: edges}
+}
+
+// serveEvents streams the events as server-sent events. The sequence number of the event is used as the event id,
+// so clients that reconnect with the Last-Event-ID header only receive the events they missed.
+func (l *liveGraph) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	lastSequence := 0
+	lastEventId := r.Header.Get("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = r.URL.Query().Get("lastEventId")
+	}
+	if lastEventId != "" {
+		seq, err := strconv.Atoi(lastEventId)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("the last event id '%s' is not a number", lastEventId), http.StatusBadRequest)
+			return
+		}
+		lastSequence = seq
+	}
+	missed, subscriber := l.subscribe(lastSequence)
+	defer l.unsubscribe(subscriber)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	for _, e := range missed {
+		if err := writeServerSentEvent(w, e); err != nil {
+			logrus.Debugf("failed to send the event to the client. Error: %q", err)
+			return
+		}
+	}
+	flusher.Flush()
+	keepAlive := time.NewTicker(liveKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case e, ok := <-subscriber:
+			if !ok {
+				return
+			}
+			if err := writeServerSentEvent(w, e); err != nil {
+				logrus.Debugf("failed to send the event to the client. Error: %q", err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeServerSentEvent(w http.ResponseWriter, e event.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the event to json. Error: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Sequence, e.Type, data)
+	return err
+}
+
+// StartLiveServer starts a server that streams the plan and transform events as server-sent events on /events
+// and serves the graph built so far on /graph.json, along with the web UI.
+// The returned function stops the server.
+func StartLiveServer(port int32) (func(), error) {
+	if err := common.CheckSupported("the live graph server"); err != nil {
+		return nil, err
+	}
+	sub, err := fs.Sub(content, "web/build")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a filesystem from the embedded static files. Error: %w", err)
+	}
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on the address %s . Error: %w", addr, err)
+	}
+	live := newLiveGraph()
+	removeListener := event.AddListener(live.add)
+	router := mux.NewRouter()
+	router.Path("/events").Methods("GET").HandlerFunc(live.serveEvents)
+	router.Path("/graph.json").Methods("GET").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(live.getWebGraph()); err != nil {
+			logrus.Errorf("failed to write the graph json out to the response. Error: %q", err)
+		}
+	})
+	router.PathPrefix("/").Handler(http.StripPrefix("/", http.FileServer(http.FS(sub))))
+	// no write timeout since the event streams stay open until the end of the run
+	server := &http.Server{
+		Handler:     router,
+		ReadTimeout: 15 * time.Second,
+	}
+	go func() {
+		if err := server.Serve(listener); err != http.ErrServerClosed {
+			logrus.Errorf("the live graph server stopped. Error: %q", err)
+		}
+	}()
+	logrus.Infof("Streaming the events on http://%s/events and the graph on http://%s/", addr, addr)
+	return func() {
+		removeListener()
+		live.closeSubscribers()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			logrus.Debugf("failed to shutdown the live graph server gracefully. Error: %q", err)
+		}
+	}, nil
+}
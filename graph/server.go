@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/konveyor/move2kube-wasm/common"
 	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
 	"github.com/sirupsen/logrus"
 )
@@ -36,6 +37,9 @@ var content embed.FS
 
 // StartServer starts the graph server and web UI to display the nodes and edges.
 func StartServer(graph graphtypes.GraphT, port int32) error {
+	if err := common.CheckSupported("the graph server"); err != nil {
+		return err
+	}
 	jsonBytes, err := json.Marshal(graph)
 	if err != nil {
 		return fmt.Errorf("failed to marshal the graph to json. Error: %w", err)
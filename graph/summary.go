@@ -0,0 +1,83 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package graph
+
+import (
+	"sort"
+	"time"
+
+	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
+	"github.com/sirupsen/logrus"
+)
+
+// TransformerRun is a single run of a transformer recorded in the graph
+type TransformerRun struct {
+	VertexId        int
+	Transformer     string
+	Iteration       int
+	Duration        time.Duration
+	Error           string
+	EnvironmentType string
+	QAProblemIds    []string
+}
+
+// GetTransformerRuns returns the transformer runs in the graph sorted by vertex id.
+// Graphs created before the timing was recorded have a zero duration for every run.
+func GetTransformerRuns(graph graphtypes.Graph) []TransformerRun {
+	runs := []TransformerRun{}
+	for _, vertex := range graph.Vertices {
+		transformerName, ok := vertex.Data[graphtypes.GraphTransformerNameKey].(string)
+		if !ok {
+			continue
+		}
+		run := TransformerRun{VertexId: vertex.Id, Transformer: transformerName, Iteration: vertex.Iteration}
+		if duration, ok := vertex.Data[graphtypes.GraphDurationKey].(string); ok {
+			d, err := time.ParseDuration(duration)
+			if err != nil {
+				logrus.Warnf("failed to parse the duration '%s' of the vertex %d . Error: %q", duration, vertex.Id, err)
+			}
+			run.Duration = d
+		}
+		run.Error, _ = vertex.Data[graphtypes.GraphErrorKey].(string)
+		run.EnvironmentType, _ = vertex.Data[graphtypes.GraphEnvironmentTypeKey].(string)
+		run.QAProblemIds = getStrings(vertex.Data[graphtypes.GraphQAProblemIdsKey])
+		runs = append(runs, run)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].VertexId < runs[j].VertexId })
+	return runs
+}
+
+// GetSlowestTransformerRuns returns at most n of the runs, from the longest to the shortest
+func GetSlowestTransformerRuns(runs []TransformerRun, n int) []TransformerRun {
+	slowest := append([]TransformerRun{}, runs...)
+	sort.SliceStable(slowest, func(i, j int) bool { return slowest[i].Duration > slowest[j].Duration })
+	if len(slowest) > n {
+		slowest = slowest[:n]
+	}
+	return slowest
+}
+
+// GetFailedTransformerRuns returns the runs where the transformer returned an error
+func GetFailedTransformerRuns(runs []TransformerRun) []TransformerRun {
+	failed := []TransformerRun{}
+	for _, run := range runs {
+		if run.Error != "" {
+			failed = append(failed, run)
+		}
+	}
+	return failed
+}
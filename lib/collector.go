@@ -27,6 +27,9 @@ import (
 
 // Collect gets the metadata from multiple sources, filters it and dumps it into files within source directory
 func Collect(inputPath string, outputPath string, annotations []string) {
+	if err := common.CheckSupported("collecting the metadata from the cluster and the images"); err != nil {
+		logrus.Fatalf("Failed to collect. Error: %q", err)
+	}
 	collectors, err := collector.GetCollectors()
 	if err != nil {
 		logrus.Fatalf("Failed to get the collectors. Error: %q", err)
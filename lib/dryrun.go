@@ -0,0 +1,147 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package lib
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
+	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
+	plantypes "github.com/konveyor/move2kube-wasm/types/plan"
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+	"github.com/sirupsen/logrus"
+)
+
+// DryRunReport describes the effect a transformation would have on the output directory
+type DryRunReport struct {
+	OutputPath string `json:"outputPath"`
+	// PathMappings contains all the path mappings that were processed, grouped by type
+	PathMappings map[transformertypes.PathMappingType][]transformertypes.PathMapping `json:"pathMappings"`
+	// Files contains the paths of all the files that would be written, relative to the output directory
+	Files []string `json:"files"`
+	// OverwrittenFiles contains the files that already exist in the output directory and would be overwritten
+	OverwrittenFiles []string `json:"overwrittenFiles"`
+}
+
+// DryRunTransform runs the full transformation into a temporary directory and reports
+// what would have been written to the output directory, without modifying it.
+func DryRunTransform(ctx context.Context, plan plantypes.Plan, preExistingPlan bool, outputPath string, transformerSelector string, maxIterations int) (DryRunReport, error) {
+	report, _, _, err := dryRunTransform(ctx, plan, preExistingPlan, outputPath, transformerSelector, maxIterations, graphtypes.GraphFileName)
+	return report, err
+}
+
+// dryRunTransform is DryRunTransform which also returns the path mappings and the graph.
+// The graph is not written to a file if the graph path is empty.
+func dryRunTransform(ctx context.Context, plan plantypes.Plan, preExistingPlan bool, outputPath string, transformerSelector string, maxIterations int, graphPath string) (DryRunReport, []transformertypes.PathMapping, *graphtypes.Graph, error) {
+	report := DryRunReport{
+		OutputPath:       outputPath,
+		PathMappings:     map[transformertypes.PathMappingType][]transformertypes.PathMapping{},
+		Files:            []string{},
+		OverwrittenFiles: []string{},
+	}
+	dryRunOutputPath, err := vfs.MkdirTemp(common.TempPath, "dryrun-*")
+	if err != nil {
+		return report, nil, nil, fmt.Errorf("failed to create a temporary directory for the dry run. Error: %w", err)
+	}
+	defer vfs.RemoveAll(dryRunOutputPath)
+	logrus.Infof("Dry run: the output will be written to the temporary directory '%s' instead of '%s'", dryRunOutputPath, outputPath)
+	pathMappings, graph, err := transform(ctx, plan, preExistingPlan, dryRunOutputPath, transformerSelector, maxIterations, "", graphPath, false)
+	for _, pathMapping := range pathMappings {
+		pathMappingType := pathMapping.Type
+		if pathMappingType == "" {
+			pathMappingType = transformertypes.DefaultPathMappingType
+		}
+		if filepath.IsAbs(pathMapping.DestPath) && common.IsParent(pathMapping.DestPath, dryRunOutputPath) {
+			if relDestPath, err := filepath.Rel(dryRunOutputPath, pathMapping.DestPath); err == nil {
+				pathMapping.DestPath = relDestPath
+			}
+		}
+		report.PathMappings[pathMappingType] = append(report.PathMappings[pathMappingType], pathMapping)
+	}
+	if err != nil {
+		return report, pathMappings, graph, err
+	}
+	if err := vfs.WalkDir(dryRunOutputPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(dryRunOutputPath, path)
+		if err != nil {
+			return err
+		}
+		report.Files = append(report.Files, relPath)
+		if _, err := vfs.Stat(filepath.Join(outputPath, relPath)); err == nil {
+			report.OverwrittenFiles = append(report.OverwrittenFiles, relPath)
+		}
+		return nil
+	}); err != nil {
+		return report, pathMappings, graph, fmt.Errorf("failed to walk the dry run output directory '%s' . Error: %w", dryRunOutputPath, err)
+	}
+	sort.Strings(report.Files)
+	sort.Strings(report.OverwrittenFiles)
+	return report, pathMappings, graph, nil
+}
+
+// String returns a human readable summary of the report
+func (report DryRunReport) String() string {
+	sb := strings.Builder{}
+	pathMappingTypes := []string{}
+	for pathMappingType := range report.PathMappings {
+		pathMappingTypes = append(pathMappingTypes, string(pathMappingType))
+	}
+	sort.Strings(pathMappingTypes)
+	sb.WriteString("Path mappings:\n")
+	for _, pathMappingType := range pathMappingTypes {
+		pathMappings := report.PathMappings[transformertypes.PathMappingType(pathMappingType)]
+		sb.WriteString(fmt.Sprintf("  %s (%d):\n", pathMappingType, len(pathMappings)))
+		for _, pathMapping := range pathMappings {
+			sb.WriteString(fmt.Sprintf("    %s -> %s\n", pathMapping.SrcPath, pathMapping.DestPath))
+		}
+	}
+	sb.WriteString(fmt.Sprintf("Destination tree (%s):\n", report.OutputPath))
+	lastParts := []string{}
+	for _, file := range report.Files {
+		parts := strings.Split(file, string(os.PathSeparator))
+		numCommonDirs := 0
+		for numCommonDirs < len(parts)-1 && numCommonDirs < len(lastParts)-1 && parts[numCommonDirs] == lastParts[numCommonDirs] {
+			numCommonDirs++
+		}
+		for i := numCommonDirs; i < len(parts); i++ {
+			sb.WriteString(strings.Repeat("  ", i+1))
+			sb.WriteString(parts[i])
+			if i < len(parts)-1 {
+				sb.WriteString(string(os.PathSeparator))
+			} else if common.IsPresent(report.OverwrittenFiles, file) {
+				sb.WriteString(" (overwrite)")
+			}
+			sb.WriteString("\n")
+		}
+		lastParts = parts
+	}
+	sb.WriteString(fmt.Sprintf("%d files would be written, %d existing files would be overwritten.\n", len(report.Files), len(report.OverwrittenFiles)))
+	return sb.String()
+}
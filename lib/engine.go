@@ -0,0 +1,325 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package lib
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/konveyor/move2kube-wasm/assets"
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
+	"github.com/konveyor/move2kube-wasm/qaengine"
+	"github.com/konveyor/move2kube-wasm/transformer"
+	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
+	plantypes "github.com/konveyor/move2kube-wasm/types/plan"
+	"github.com/konveyor/move2kube-wasm/types/report"
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// EngineOptions contains the settings used by all the plans and transformations of an engine
+type EngineOptions struct {
+	// Configs contains the paths of the config files used to answer the questions
+	Configs []string
+	// SetConfigs contains key-value configs used to answer the questions
+	SetConfigs []string
+	// PreSets contains the names of the preset configurations to use
+	PreSets []string
+	// QAEngine answers the questions that are not answered by the configs, by default the default answers are used
+	QAEngine qaengine.Engine
+	// ConfigOutPath is the path of the config file the answers are written to, if any
+	ConfigOutPath string
+	// QACacheOutPath is the path of the cache file the questions and answers are written to, if any
+	QACacheOutPath string
+	// PersistPasswords stores the passwords in the config and cache files
+	PersistPasswords bool
+	// DisableLocalExecution stops the transformers from executing files locally
+	DisableLocalExecution bool
+	// IgnoreEnvironment ignores the data collected from the local machine
+	IgnoreEnvironment bool
+	// FileSystem is the filesystem containing the sources, the outputs and the temporary directories, by default it is vfs.OS.
+	// With an in-memory filesystem the transformers that execute commands or use containers cannot be used.
+	FileSystem vfs.FileSystem
+}
+
+// PlanOptions contains the settings for planning a project
+type PlanOptions struct {
+	// SourcePath is the directory containing the source code of the project
+	SourcePath string
+	// OutputPath is the directory the project will be transformed into, if it is known already
+	OutputPath string
+	// ProjectName is the name of the project, by default it is common.DefaultProjectName
+	ProjectName string
+	// CustomizationsPath is the directory containing the customizations, if any
+	CustomizationsPath string
+	// TransformerSelector is a Kubernetes style selector for the transformers to use
+	TransformerSelector string
+}
+
+// TransformOptions contains the settings for transforming a project
+type TransformOptions struct {
+	// OutputPath is the directory the output is written to
+	OutputPath string
+	// TransformerSelector is a Kubernetes style selector for the transformers to use, in addition to the one in the plan
+	TransformerSelector string
+	// MaxIterations is the maximum number of iterations to allow, zero or negative means no limit
+	MaxIterations int
+	// PreExistingPlan is true if the plan was read from a plan file instead of being created by Plan for this transformation
+	PreExistingPlan bool
+	// GraphPath is the path of the file the graph is written to, if any. The graph is also returned in the result.
+	GraphPath string
+	// CheckpointPath is the path of the file the checkpoint is written to after every iteration, if any.
+	// It is removed when the transformation completes. It is not used for dry runs and when preserving edits.
+	CheckpointPath string
+	// Resume continues the transformation from the checkpoint at CheckpointPath
+	Resume bool
+	// PreserveEdits merges the edits made to the previous output in the output directory into the new output
+	PreserveEdits bool
+	// DryRun transforms into a temporary directory and reports what would have been written to the output directory
+	DryRun bool
+}
+
+// TransformResult is the outcome of a transformation
+type TransformResult struct {
+	// PathMappings contains all the path mappings that were processed
+	PathMappings []transformertypes.PathMapping `json:"pathMappings"`
+	// Graph contains the transformer runs and the artifacts passed between them
+	Graph *graphtypes.Graph `json:"graph,omitempty"`
+	// DryRunReport describes what would have been written to the output directory, only for dry runs
+	DryRunReport *DryRunReport `json:"dryRunReport,omitempty"`
+	// Errors contains the failures that did not stop the transformation
+	Errors []report.RunError `json:"errors,omitempty"`
+}
+
+// Engine plans and transforms projects using the built-in assets extracted to its own temporary directory.
+// It owns the transformer registry and the QA session, which contains the QA engines and the config and cache files the answers are written to.
+// The QA session is set up once when the engine is created, so the answers are shared by all the plans and transformations of the engine.
+// The transformers are initialized again for every plan and transformation, so an engine can be used for any number of projects.
+//
+// The package level functions like CreatePlan and transformer.Transform still use the current registry, QA session, default filesystem
+// and paths in the common package. Plan and Transform install the ones of the engine for the duration of the call and restore the previous
+// ones afterwards. Because of this the calls of all the engines in a process are serialized, two engines can exist at the same time
+// but only one of them plans or transforms at any moment.
+type Engine struct {
+	options        EngineOptions
+	fileSystem     vfs.FileSystem
+	assetsPath     string
+	tempPath       string
+	remoteTempPath string
+	registry       *transformer.Registry
+	qaSession      *qaengine.Session
+	// closedMutex protects closed. It is separate from engineMutex so that the engine can be closed while a call is aborting.
+	closedMutex sync.Mutex
+	closed      bool
+}
+
+// engineMutex allows only one engine to plan or transform at a time,
+// since the transformers, the QA engines and the paths they use are package level state.
+// It does not protect the package level functions like CreatePlan and Transform that are used without an engine.
+var engineMutex sync.Mutex
+
+// NewEngine creates an engine, extracts the built-in assets and sets up the QA session. Close should be called to remove the assets.
+func NewEngine(options EngineOptions) (*Engine, error) {
+	engineMutex.Lock()
+	defer engineMutex.Unlock()
+	assetsFilePermissions := map[string]int{}
+	if err := yaml.Unmarshal([]byte(assets.AssetFilePermissions), &assetsFilePermissions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the assets permissions file as YAML. Error: %w", err)
+	}
+	fileSystem := options.FileSystem
+	if fileSystem == nil {
+		fileSystem = vfs.OS
+	}
+	previousFileSystem := vfs.SetDefault(fileSystem)
+	assetsPath, tempPath, remoteTempPath, err := common.CreateAssetsData(assets.AssetsDir, assetsFilePermissions)
+	vfs.SetDefault(previousFileSystem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the assets directory. Error: %w", err)
+	}
+	e := &Engine{
+		options:        options,
+		fileSystem:     fileSystem,
+		assetsPath:     assetsPath,
+		tempPath:       tempPath,
+		remoteTempPath: remoteTempPath,
+		registry:       transformer.NewRegistry(),
+		qaSession:      qaengine.NewSession(),
+	}
+	restore := e.install()
+	defer restore()
+	qaEngine := options.QAEngine
+	if qaEngine == nil {
+		qaEngine = qaengine.NewDefaultEngine()
+	}
+	qaengine.AddEngine(qaEngine)
+	qaengine.SetupConfigFile(
+		options.ConfigOutPath,
+		append([]string{}, options.SetConfigs...),
+		append([]string{}, options.Configs...),
+		append([]string{}, options.PreSets...),
+		options.PersistPasswords,
+	)
+	if options.QACacheOutPath != "" {
+		qaengine.SetupWriteCacheFile(options.QACacheOutPath, options.PersistPasswords)
+	}
+	if err := qaengine.WriteStoresToDisk(); err != nil {
+		logrus.Warnf("Failed to write the stores to disk. Error: %q", err)
+	}
+	return e, nil
+}
+
+// Activate makes the engine the one used by the package level functions like CreatePlan and Transform,
+// until another engine plans or transforms. Unlike Plan and Transform it leaves it in place.
+// It is only meant for the CLI commands that use the assets, the QA session or the transformers without planning or transforming.
+// Library users should use Plan and Transform instead.
+func (e *Engine) Activate() {
+	engineMutex.Lock()
+	defer engineMutex.Unlock()
+	e.install()
+}
+
+// install makes the registry, the QA session, the filesystem and the paths of the engine the current ones.
+// The returned function restores the ones that were in use before.
+func (e *Engine) install() func() {
+	previousFileSystem := vfs.SetDefault(e.fileSystem)
+	previousRegistry := transformer.SetRegistry(e.registry)
+	previousQASession := qaengine.SetSession(e.qaSession)
+	previousAssetsPath, previousTempPath, previousRemoteTempPath := common.AssetsPath, common.TempPath, common.RemoteTempPath
+	previousDisableLocalExecution, previousIgnoreEnvironment := common.DisableLocalExecution, common.IgnoreEnvironment
+	common.AssetsPath = e.assetsPath
+	common.TempPath = e.tempPath
+	common.RemoteTempPath = e.remoteTempPath
+	common.DisableLocalExecution = e.options.DisableLocalExecution
+	common.IgnoreEnvironment = e.options.IgnoreEnvironment
+	return func() {
+		vfs.SetDefault(previousFileSystem)
+		transformer.SetRegistry(previousRegistry)
+		qaengine.SetSession(previousQASession)
+		common.AssetsPath, common.TempPath, common.RemoteTempPath = previousAssetsPath, previousTempPath, previousRemoteTempPath
+		common.DisableLocalExecution, common.IgnoreEnvironment = previousDisableLocalExecution, previousIgnoreEnvironment
+	}
+}
+
+// setup installs the engine and sets up the transformer registry and the customizations for a new plan or transformation.
+// The returned function destroys the transformers and restores the registry, the QA session, the filesystem and the paths that were in use before.
+func (e *Engine) setup(customizationsPath string) (func(), error) {
+	e.closedMutex.Lock()
+	closed := e.closed
+	e.closedMutex.Unlock()
+	if closed {
+		return func() {}, fmt.Errorf("the engine has already been closed")
+	}
+	restore := e.install()
+	transformer.Reset()
+	report.ResetErrors()
+	cleanup := func() {
+		transformer.Reset()
+		restore()
+	}
+	customizationsAssetsPath := filepath.Join(e.assetsPath, customizationsAssetsDir)
+	if err := vfs.RemoveAll(customizationsAssetsPath); err != nil {
+		return cleanup, fmt.Errorf("failed to remove the customizations of the previous project at path %s . Error: %w", customizationsAssetsPath, err)
+	}
+	if err := CheckAndCopyCustomizations(customizationsPath); err != nil {
+		return cleanup, fmt.Errorf("failed to check and copy the customizations. Error: %w", err)
+	}
+	return cleanup, nil
+}
+
+// Plan detects the services in the source directory and returns the plan
+func (e *Engine) Plan(ctx context.Context, options PlanOptions) (plantypes.Plan, error) {
+	engineMutex.Lock()
+	defer engineMutex.Unlock()
+	cleanup, err := e.setup("")
+	defer cleanup()
+	if err != nil {
+		return plantypes.Plan{}, err
+	}
+	projectName := options.ProjectName
+	if projectName == "" {
+		projectName = common.DefaultProjectName
+	}
+	sourcePath := options.SourcePath
+	if sourcePath != "" {
+		if sourcePath, err = filepath.Abs(sourcePath); err != nil {
+			return plantypes.Plan{}, fmt.Errorf("failed to make the source directory path '%s' absolute. Error: %w", options.SourcePath, err)
+		}
+	}
+	return CreatePlan(ctx, sourcePath, options.OutputPath, options.CustomizationsPath, options.TransformerSelector, projectName)
+}
+
+// Transform transforms the project using a plan and writes the output to the output directory.
+// Unlike the CLI it does not write a graph file or a checkpoint unless their paths are given, the graph is returned instead.
+func (e *Engine) Transform(ctx context.Context, plan plantypes.Plan, options TransformOptions) (TransformResult, error) {
+	engineMutex.Lock()
+	defer engineMutex.Unlock()
+	cleanup, err := e.setup(plan.Spec.CustomizationsDir)
+	defer cleanup()
+	if err != nil {
+		return TransformResult{}, err
+	}
+	outputPath, err := filepath.Abs(options.OutputPath)
+	if err != nil {
+		return TransformResult{}, fmt.Errorf("failed to make the output directory path '%s' absolute. Error: %w", options.OutputPath, err)
+	}
+	maxIterations := options.MaxIterations
+	if maxIterations <= 0 {
+		maxIterations = -1
+	}
+	result := TransformResult{}
+	switch {
+	case options.DryRun:
+		var dryRunReport DryRunReport
+		dryRunReport, result.PathMappings, result.Graph, err = dryRunTransform(ctx, plan, options.PreExistingPlan, outputPath, options.TransformerSelector, maxIterations, options.GraphPath)
+		result.DryRunReport = &dryRunReport
+	case options.PreserveEdits:
+		result.PathMappings, result.Graph, err = transformPreservingEdits(ctx, plan, options.PreExistingPlan, outputPath, options.TransformerSelector, maxIterations, options.GraphPath)
+	default:
+		if err := vfs.MkdirAll(outputPath, common.DefaultDirectoryPermission); err != nil {
+			return TransformResult{}, fmt.Errorf("failed to create the output directory at path %s . Error: %w", outputPath, err)
+		}
+		result.PathMappings, result.Graph, err = transform(ctx, plan, options.PreExistingPlan, outputPath, options.TransformerSelector, maxIterations, options.CheckpointPath, options.GraphPath, options.Resume)
+		if err == nil {
+			// keep the unedited output so that the edits can be preserved by the next transformation
+			err = savePristineOutput(outputPath, filepath.Join(outputPath, PristineOutputDir))
+		}
+	}
+	result.Errors = report.GetErrors()
+	return result, err
+}
+
+// Close removes the assets and the temporary directories of the engine. The engine cannot be used after it is closed.
+// It should not be called while a plan or a transformation of the engine is running, except when the process is exiting.
+func (e *Engine) Close() error {
+	e.closedMutex.Lock()
+	defer e.closedMutex.Unlock()
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	for _, path := range []string{e.tempPath, e.remoteTempPath} {
+		if err := e.fileSystem.RemoveAll(path); err != nil {
+			return fmt.Errorf("failed to remove the temporary directory at path %s . Error: %w", path, err)
+		}
+	}
+	logrus.Debugf("removed the temporary directories of the engine")
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package lib
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/konveyor/move2kube-wasm/common/vfs"
+)
+
+func TestEngineWithMemFileSystem(t *testing.T) {
+	fileSystem := vfs.NewMemFileSystem()
+	src := fstest.MapFS{
+		"goapp/go.mod":  {Data: []byte("module example.com/goapp\n\ngo 1.21\n")},
+		"goapp/main.go": {Data: []byte("package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n")},
+	}
+	if err := vfs.CopyFS(fileSystem, "/src", src); err != nil {
+		t.Fatalf("failed to copy the source into the in-memory filesystem. Error: %q", err)
+	}
+	engine, err := NewEngine(EngineOptions{
+		FileSystem:            fileSystem,
+		SetConfigs:            []string{"move2kube.target.imageregistry.url=\"quay.io\"", "move2kube.target.imageregistry.namespace=\"test\""},
+		DisableLocalExecution: true,
+		IgnoreEnvironment:     true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create the engine. Error: %q", err)
+	}
+	defer engine.Close()
+
+	plan, err := engine.Plan(context.Background(), PlanOptions{SourcePath: "/src", ProjectName: "myproject"})
+	if err != nil {
+		t.Fatalf("failed to plan. Error: %q", err)
+	}
+	if len(plan.Spec.Services) == 0 {
+		t.Fatalf("expected the plan to contain at least one service")
+	}
+
+	result, err := engine.Transform(context.Background(), plan, TransformOptions{OutputPath: "/out"})
+	if err != nil {
+		t.Fatalf("failed to transform. Error: %q", err)
+	}
+	if len(result.PathMappings) == 0 {
+		t.Fatalf("expected the transformation to produce path mappings")
+	}
+	dockerfiles := []string{}
+	if err := vfs.WalkDirFS(fileSystem, "/out", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == PristineOutputDir {
+			return filepath.SkipDir
+		}
+		if d.Name() == "Dockerfile" {
+			dockerfiles = append(dockerfiles, path)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to walk the output directory. Error: %q", err)
+	}
+	if len(dockerfiles) == 0 {
+		t.Fatalf("expected the output to contain a Dockerfile")
+	}
+	if _, err := fileSystem.Stat(filepath.Join("/out", PristineOutputDir)); err != nil {
+		t.Fatalf("expected the output to contain the pristine copy. Error: %q", err)
+	}
+	if _, err := os.Stat("/out"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected nothing to be written to the disk. Actual: %v", err)
+	}
+}
@@ -0,0 +1,324 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package lib
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/deepcopy"
+	"github.com/konveyor/move2kube-wasm/qaengine"
+	plantypes "github.com/konveyor/move2kube-wasm/types/plan"
+	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// PlanEditOperationType is the type of an edit to the services in a plan
+type PlanEditOperationType string
+
+const (
+	// MergePlanEditOperation merges several services into one pod with a container for each service
+	MergePlanEditOperation PlanEditOperationType = "merge"
+	// SplitPlanEditOperation splits a service into one service for each of its child modules
+	SplitPlanEditOperation PlanEditOperationType = "split"
+	// RenamePlanEditOperation renames a service
+	RenamePlanEditOperation PlanEditOperationType = "rename"
+)
+
+// PlanEditOperation is a single edit to the services in a plan
+type PlanEditOperation struct {
+	Type     PlanEditOperationType
+	Services []string
+	NewName  string
+}
+
+// ParsePlanEditOperation parses an operation in one of the formats:
+// "merge <service1>,<service2>=<new name>", "split <service>" and "rename <service>=<new name>"
+func ParsePlanEditOperation(op string) (PlanEditOperation, error) {
+	op = strings.TrimSpace(op)
+	parts := strings.SplitN(op, " ", 2)
+	if len(parts) != 2 {
+		return PlanEditOperation{}, fmt.Errorf("the operation '%s' is missing the services to operate on", op)
+	}
+	opType := PlanEditOperationType(strings.ToLower(parts[0]))
+	args := strings.TrimSpace(parts[1])
+	switch opType {
+	case SplitPlanEditOperation:
+		return PlanEditOperation{Type: opType, Services: []string{args}}, nil
+	case MergePlanEditOperation, RenamePlanEditOperation:
+		idx := strings.LastIndex(args, "=")
+		if idx == -1 {
+			return PlanEditOperation{}, fmt.Errorf("the operation '%s' is missing the new name. Expected the format '%s <services>=<new name>'", op, opType)
+		}
+		services := []string{}
+		for _, service := range strings.Split(args[:idx], ",") {
+			if service = strings.TrimSpace(service); service != "" {
+				services = append(services, service)
+			}
+		}
+		newName := strings.TrimSpace(args[idx+1:])
+		if opType == RenamePlanEditOperation && len(services) != 1 {
+			return PlanEditOperation{}, fmt.Errorf("the operation '%s' must rename exactly one service", op)
+		}
+		if opType == MergePlanEditOperation && len(services) < 2 {
+			return PlanEditOperation{}, fmt.Errorf("the operation '%s' must merge at least two services", op)
+		}
+		return PlanEditOperation{Type: opType, Services: services, NewName: newName}, nil
+	}
+	return PlanEditOperation{}, fmt.Errorf("the operation type '%s' is not supported. Supported types are: %s, %s, %s", opType, MergePlanEditOperation, SplitPlanEditOperation, RenamePlanEditOperation)
+}
+
+// ParsePlanEditOperations parses one operation per line, skipping empty lines
+func ParsePlanEditOperations(ops string) ([]PlanEditOperation, error) {
+	operations := []PlanEditOperation{}
+	for _, line := range strings.Split(ops, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		operation, err := ParsePlanEditOperation(line)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, operation)
+	}
+	return operations, nil
+}
+
+// GetPlanEditOperations asks for the operations to perform on the services in the plan
+func GetPlanEditOperations(ctx context.Context, plan plantypes.Plan) []PlanEditOperation {
+	serviceNames := []string{}
+	for serviceName := range plan.Spec.Services {
+		serviceNames = append(serviceNames, serviceName)
+	}
+	sort.Strings(serviceNames)
+	ops := qaengine.FetchMultilineInputAnswer(
+		ctx,
+		common.ConfigPlanEditOperationsKey,
+		"Specify the operations to perform on the detected services:",
+		[]string{
+			"One operation per line. Leave empty to use the services as detected.",
+			"merge <service1>,<service2>=<new name> : deploy the services as containers in a single pod",
+			"split <service> : create a service for each child module of the service",
+			"rename <service>=<new name> : rename the service",
+			"Detected services: " + strings.Join(serviceNames, ", "),
+		},
+		"",
+		func(ans interface{}) error {
+			_, err := ParsePlanEditOperations(cast.ToString(ans))
+			return err
+		},
+	)
+	operations, err := ParsePlanEditOperations(ops)
+	if err != nil {
+		logrus.Errorf("failed to parse the plan edit operations. Ignoring them. Error: %q", err)
+		return nil
+	}
+	return operations
+}
+
+// EditPlan performs the operations on the services in the plan and validates the result
+func EditPlan(plan plantypes.Plan, operations []PlanEditOperation) (plantypes.Plan, error) {
+	newPlan := deepcopy.DeepCopy(plan).(plantypes.Plan)
+	for _, operation := range operations {
+		var err error
+		switch operation.Type {
+		case MergePlanEditOperation:
+			err = MergePlanServices(&newPlan, operation.Services, operation.NewName)
+		case SplitPlanEditOperation:
+			_, err = SplitPlanService(&newPlan, operation.Services[0])
+		case RenamePlanEditOperation:
+			err = RenamePlanService(&newPlan, operation.Services[0], operation.NewName)
+		default:
+			err = fmt.Errorf("the operation type '%s' is not supported", operation.Type)
+		}
+		if err != nil {
+			return plan, fmt.Errorf("failed to %s the services %+v . Error: %w", operation.Type, operation.Services, err)
+		}
+	}
+	if err := ValidatePlan(newPlan); err != nil {
+		return plan, fmt.Errorf("the edited plan is invalid. Error: %w", err)
+	}
+	return newPlan, nil
+}
+
+// MergePlanServices merges the services into a single service.
+// Each of the merged services becomes a container in the same pod.
+func MergePlanServices(plan *plantypes.Plan, serviceNames []string, newServiceName string) error {
+	if err := checkNewServiceName(plan, newServiceName, serviceNames...); err != nil {
+		return err
+	}
+	mergedOptions := []plantypes.PlanArtifact{}
+	for _, serviceName := range serviceNames {
+		options, ok := plan.Spec.Services[serviceName]
+		if !ok {
+			return fmt.Errorf("the service '%s' does not exist in the plan", serviceName)
+		}
+		for _, option := range options {
+			if option.ContainerName == "" {
+				option.ContainerName = serviceName
+			}
+			mergedOptions = append(mergedOptions, option)
+		}
+	}
+	for _, serviceName := range serviceNames {
+		delete(plan.Spec.Services, serviceName)
+	}
+	plan.Spec.Services[newServiceName] = mergedOptions
+	logrus.Infof("Merged the services %+v into the service '%s'", serviceNames, newServiceName)
+	return nil
+}
+
+// SplitPlanService splits a service that has more than one service directory (for example a
+// Maven multi-module project) into one service per directory. It returns the names of the new services.
+func SplitPlanService(plan *plantypes.Plan, serviceName string) ([]string, error) {
+	options, ok := plan.Spec.Services[serviceName]
+	if !ok {
+		return nil, fmt.Errorf("the service '%s' does not exist in the plan", serviceName)
+	}
+	newServices := map[string][]plantypes.PlanArtifact{}
+	for _, option := range options {
+		serviceDirs := option.Paths[artifacts.ServiceDirPathType]
+		if len(serviceDirs) < 2 {
+			logrus.Debugf("the transformation option '%s' of the service '%s' has no child modules", option.TransformerName, serviceName)
+			continue
+		}
+		rootDir := common.CleanAndFindCommonDirectory(serviceDirs)
+		if rootDirs := option.Paths[artifacts.ServiceRootDirPathType]; len(rootDirs) > 0 {
+			rootDir = rootDirs[0]
+		}
+		mavenConfig := artifacts.MavenConfig{}
+		hasMavenConfig := option.GetConfig(artifacts.MavenConfigType, &mavenConfig) == nil
+		for _, serviceDir := range serviceDirs {
+			childName := filepath.Base(serviceDir)
+			childOption := deepcopy.DeepCopy(option).(plantypes.PlanArtifact)
+			childOption.Paths[artifacts.ServiceDirPathType] = []string{serviceDir}
+			if hasMavenConfig {
+				// keep only the child module whose pom.xml is in this directory
+				childMavenConfig := mavenConfig
+				childMavenConfig.ChildModules = []artifacts.ChildModule{}
+				for _, childModule := range mavenConfig.ChildModules {
+					if filepath.Join(rootDir, filepath.Dir(childModule.RelPomPath)) == filepath.Clean(serviceDir) {
+						childMavenConfig.ChildModules = append(childMavenConfig.ChildModules, childModule)
+						childName = childModule.Name
+					}
+				}
+				childOption.Configs[artifacts.MavenConfigType] = childMavenConfig
+			}
+			newServiceName := common.MakeStringK8sServiceNameCompliant(serviceName + "-" + childName)
+			childOption.Name = ""
+			newServices[newServiceName] = append(newServices[newServiceName], childOption)
+		}
+	}
+	if len(newServices) == 0 {
+		return nil, fmt.Errorf("the service '%s' does not have any child modules to split into", serviceName)
+	}
+	delete(plan.Spec.Services, serviceName)
+	newServiceNames := []string{}
+	for newServiceName, newOptions := range newServices {
+		if err := checkNewServiceName(plan, newServiceName); err != nil {
+			return nil, err
+		}
+		plan.Spec.Services[newServiceName] = newOptions
+		newServiceNames = append(newServiceNames, newServiceName)
+	}
+	sort.Strings(newServiceNames)
+	logrus.Infof("Split the service '%s' into the services %+v", serviceName, newServiceNames)
+	return newServiceNames, nil
+}
+
+// RenamePlanService renames a service in the plan
+func RenamePlanService(plan *plantypes.Plan, serviceName, newServiceName string) error {
+	options, ok := plan.Spec.Services[serviceName]
+	if !ok {
+		return fmt.Errorf("the service '%s' does not exist in the plan", serviceName)
+	}
+	if err := checkNewServiceName(plan, newServiceName, serviceName); err != nil {
+		return err
+	}
+	delete(plan.Spec.Services, serviceName)
+	plan.Spec.Services[newServiceName] = options
+	logrus.Infof("Renamed the service '%s' to '%s'", serviceName, newServiceName)
+	return nil
+}
+
+// checkNewServiceName checks that the name is valid and not used by a service other than the ones being replaced
+func checkNewServiceName(plan *plantypes.Plan, newServiceName string, replacedServiceNames ...string) error {
+	if errs := validation.IsDNS1123Label(newServiceName); len(errs) > 0 {
+		return fmt.Errorf("the service name '%s' is invalid. Errors: %s", newServiceName, strings.Join(errs, ", "))
+	}
+	if _, ok := plan.Spec.Services[newServiceName]; ok && !common.IsPresent(replacedServiceNames, newServiceName) {
+		return fmt.Errorf("a service with the name '%s' already exists in the plan", newServiceName)
+	}
+	return nil
+}
+
+// ValidatePlan checks that the services in the plan have valid names and transformation options
+func ValidatePlan(plan plantypes.Plan) error {
+	errs := []string{}
+	for serviceName, options := range plan.Spec.Services {
+		if validationErrs := validation.IsDNS1123Label(serviceName); len(validationErrs) > 0 {
+			errs = append(errs, fmt.Sprintf("the service name '%s' is invalid: %s", serviceName, strings.Join(validationErrs, ", ")))
+		}
+		if len(options) == 0 {
+			errs = append(errs, fmt.Sprintf("the service '%s' has no transformation options", serviceName))
+		}
+		containerNames := map[string]bool{}
+		for _, option := range options {
+			if option.TransformerName == "" {
+				errs = append(errs, fmt.Sprintf("a transformation option of the service '%s' is missing the transformer name", serviceName))
+			} else if _, ok := plan.Spec.Transformers[option.TransformerName]; !ok && len(plan.Spec.Transformers) > 0 {
+				errs = append(errs, fmt.Sprintf("the transformer '%s' used by the service '%s' is not in the plan", option.TransformerName, serviceName))
+			}
+			containerNames[option.ContainerName] = true
+		}
+		if len(containerNames) > 1 && containerNames[""] {
+			errs = append(errs, fmt.Sprintf("the service '%s' was merged but some of its transformation options are missing a container name", serviceName))
+		}
+		for containerName := range containerNames {
+			if containerName == "" {
+				continue
+			}
+			if validationErrs := validation.IsDNS1123Label(containerName); len(validationErrs) > 0 {
+				errs = append(errs, fmt.Sprintf("the container name '%s' of the service '%s' is invalid: %s", containerName, serviceName, strings.Join(validationErrs, ", ")))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// groupOptionsByContainer groups the transformation options of a service by container.
+// Services that were not merged have a single group.
+func groupOptionsByContainer(options []plantypes.PlanArtifact) (containerNames []string, containerOptions map[string][]plantypes.PlanArtifact) {
+	containerOptions = map[string][]plantypes.PlanArtifact{}
+	for _, option := range options {
+		if _, ok := containerOptions[option.ContainerName]; !ok {
+			containerNames = append(containerNames, option.ContainerName)
+		}
+		containerOptions[option.ContainerName] = append(containerOptions[option.ContainerName], option)
+	}
+	return containerNames, containerOptions
+}
@@ -50,7 +50,7 @@ func CreatePlan(ctx context.Context, inputPath, outputPath string, customization
 	if err != nil {
 		return plan, fmt.Errorf("failed to convert the label selector to a selector. Error: %w", err)
 	}
-	deselectedTransformers, err := transformer.Init(common.AssetsPath, inputFSPath, lblSelector, outputFSPath, plan.Name)
+	deselectedTransformers, err := transformer.Init(ctx, common.AssetsPath, inputFSPath, lblSelector, outputFSPath, plan.Name)
 	if err != nil {
 		return plan, fmt.Errorf("failed to initialize the transformers. Error: %w", err)
 	}
@@ -69,10 +69,16 @@ func CreatePlan(ctx context.Context, inputPath, outputPath string, customization
 	logrus.Info("Start planning")
 	if inputFSPath != "" {
 		var err error
-		plan.Spec.Services, err = transformer.GetServices(plan.Name, inputFSPath, nil)
+		plan.Spec.ServiceNaming = transformer.GetServiceNaming(ctx)
+		plan.Spec.Services, err = transformer.GetServices(ctx, plan.Name, inputFSPath, nil, plan.Spec.ServiceNaming)
 		if err != nil {
 			return plan, fmt.Errorf("failed to get services from the input directory '%s' . Error: %w", inputFSPath, err)
 		}
+		if operations := GetPlanEditOperations(ctx, plan); len(operations) > 0 {
+			if plan, err = EditPlan(plan, operations); err != nil {
+				return plan, fmt.Errorf("failed to edit the services in the plan. Error: %w", err)
+			}
+		}
 	}
 	logrus.Infof("Planning done. Number of services identified: %d", len(plan.Spec.Services))
 	return plan, nil
@@ -0,0 +1,245 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package lib
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
+	"github.com/konveyor/move2kube-wasm/filesystem"
+	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
+	plantypes "github.com/konveyor/move2kube-wasm/types/plan"
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// PristineOutputDir is the directory inside the output directory where the unedited output of the last transformation is kept
+	PristineOutputDir = ".m2k-pristine"
+	// deltaModificationsDir is the directory filesystem.GenerateDelta stores the modified files in
+	deltaModificationsDir = "modifications"
+)
+
+// TransformPreservingEdits transforms into a temporary directory and performs a three-way merge between the
+// pristine output of the previous transformation, the edited output directory and the new output.
+// Files that could not be merged cleanly are left with conflict markers or a .rej file next to them.
+// If the output directory is missing or empty it transforms directly into it.
+func TransformPreservingEdits(ctx context.Context, plan plantypes.Plan, preExistingPlan bool, outputPath string, transformerSelector string, maxIterations int) error {
+	_, _, err := transformPreservingEdits(ctx, plan, preExistingPlan, outputPath, transformerSelector, maxIterations, graphtypes.GraphFileName)
+	return err
+}
+
+// transformPreservingEdits is TransformPreservingEdits which also returns the path mappings and the graph of the new output.
+// The graph is not written to a file if the graph path is empty.
+func transformPreservingEdits(ctx context.Context, plan plantypes.Plan, preExistingPlan bool, outputPath string, transformerSelector string, maxIterations int, graphPath string) ([]transformertypes.PathMapping, *graphtypes.Graph, error) {
+	pristinePath := filepath.Join(outputPath, PristineOutputDir)
+	isEmpty, err := isMissingOrEmptyDir(outputPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if isEmpty {
+		pathMappings, graph, err := transform(ctx, plan, preExistingPlan, outputPath, transformerSelector, maxIterations, "", graphPath, false)
+		if err != nil {
+			return pathMappings, graph, err
+		}
+		return pathMappings, graph, savePristineOutput(outputPath, pristinePath)
+	}
+	if _, err := vfs.Stat(pristinePath); err != nil {
+		logrus.Warnf("No pristine copy of the previous output was found at path %s . All the existing files will be treated as edits.", pristinePath)
+	}
+	newOutputPath, err := vfs.MkdirTemp(common.TempPath, "regenerate-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create a temporary directory for the new output. Error: %w", err)
+	}
+	defer vfs.RemoveAll(newOutputPath)
+	pathMappings, graph, err := transform(ctx, plan, preExistingPlan, newOutputPath, transformerSelector, maxIterations, "", graphPath, false)
+	if err != nil {
+		return pathMappings, graph, err
+	}
+	deltaPath, err := vfs.MkdirTemp(common.TempPath, "delta-*")
+	if err != nil {
+		return pathMappings, graph, fmt.Errorf("failed to create a temporary directory for the edits. Error: %w", err)
+	}
+	defer vfs.RemoveAll(deltaPath)
+	if err := vfs.MkdirAll(pristinePath, common.DefaultDirectoryPermission); err != nil {
+		return pathMappings, graph, fmt.Errorf("failed to create the pristine output directory at path %s . Error: %w", pristinePath, err)
+	}
+	if err := filesystem.GenerateDelta(outputPath, pristinePath, deltaPath); err != nil {
+		return pathMappings, graph, fmt.Errorf("failed to generate the edits made to the output directory %s . Error: %w", outputPath, err)
+	}
+	mergedPath, err := vfs.MkdirTemp(common.TempPath, "merged-*")
+	if err != nil {
+		return pathMappings, graph, fmt.Errorf("failed to create a temporary directory for the merged output. Error: %w", err)
+	}
+	defer vfs.RemoveAll(mergedPath)
+	if err := filesystem.Merge(newOutputPath, mergedPath, false); err != nil {
+		return pathMappings, graph, fmt.Errorf("failed to copy the new output to the directory %s . Error: %w", mergedPath, err)
+	}
+	conflicts, err := mergeEdits(filepath.Join(deltaPath, deltaModificationsDir), pristinePath, newOutputPath, mergedPath)
+	if err != nil {
+		return pathMappings, graph, err
+	}
+	if err := removeDeletedFiles(outputPath, pristinePath, newOutputPath, mergedPath); err != nil {
+		return pathMappings, graph, err
+	}
+	entries, err := vfs.ReadDir(outputPath)
+	if err != nil {
+		return pathMappings, graph, fmt.Errorf("failed to read the output directory %s . Error: %w", outputPath, err)
+	}
+	for _, entry := range entries {
+		if entry.Name() == PristineOutputDir {
+			continue
+		}
+		if err := vfs.RemoveAll(filepath.Join(outputPath, entry.Name())); err != nil {
+			return pathMappings, graph, fmt.Errorf("failed to remove the old output at path %s . Error: %w", filepath.Join(outputPath, entry.Name()), err)
+		}
+	}
+	if err := filesystem.Merge(mergedPath, outputPath, false); err != nil {
+		return pathMappings, graph, fmt.Errorf("failed to copy the merged output to the output directory %s . Error: %w", outputPath, err)
+	}
+	if len(conflicts) != 0 {
+		logrus.Warnf("The edits to the following %d files conflicted with the new output. Look for conflict markers or %s files:", len(conflicts), filesystem.RejectFileExtension)
+		for _, conflict := range conflicts {
+			logrus.Warnf("  %s", conflict)
+		}
+	}
+	return pathMappings, graph, savePristineOutput(newOutputPath, pristinePath)
+}
+
+// mergeEdits three-way merges every edited file into the merged output
+func mergeEdits(modificationsPath, pristinePath, newOutputPath, mergedPath string) ([]string, error) {
+	conflicts := []string{}
+	if _, err := vfs.Stat(modificationsPath); os.IsNotExist(err) {
+		return conflicts, nil
+	}
+	err := vfs.WalkDir(modificationsPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(modificationsPath, path)
+		if err != nil {
+			return err
+		}
+		if relPath == PristineOutputDir {
+			return filepath.SkipDir
+		}
+		if d.IsDir() {
+			return nil
+		}
+		basePath := filepath.Join(pristinePath, relPath)
+		theirsPath := filepath.Join(newOutputPath, relPath)
+		destPath := filepath.Join(mergedPath, relPath)
+		if _, err := vfs.Stat(theirsPath); os.IsNotExist(err) {
+			if same, _ := isSameFile(path, basePath); same {
+				return nil
+			}
+			logrus.Warnf("The edited file %s is no longer generated. Keeping the edited version.", relPath)
+			if err := vfs.MkdirAll(filepath.Dir(destPath), common.DefaultDirectoryPermission); err != nil {
+				return err
+			}
+			return common.CopyFile(destPath, path)
+		}
+		conflict, err := filesystem.ThreeWayMergeFiles(basePath, path, theirsPath, destPath)
+		if err != nil {
+			return err
+		}
+		if conflict {
+			conflicts = append(conflicts, relPath)
+		}
+		return nil
+	})
+	if err != nil {
+		return conflicts, fmt.Errorf("failed to merge the edits into the new output. Error: %w", err)
+	}
+	return conflicts, nil
+}
+
+// removeDeletedFiles removes the files that were deleted from the output directory, unless the new output changed them
+func removeDeletedFiles(outputPath, pristinePath, newOutputPath, mergedPath string) error {
+	if _, err := vfs.Stat(pristinePath); os.IsNotExist(err) {
+		return nil
+	}
+	return vfs.WalkDir(pristinePath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(pristinePath, path)
+		if err != nil {
+			return err
+		}
+		if _, err := vfs.Stat(filepath.Join(outputPath, relPath)); !os.IsNotExist(err) {
+			return nil
+		}
+		if same, _ := isSameFile(path, filepath.Join(newOutputPath, relPath)); !same {
+			logrus.Warnf("The deleted file %s has changed in the new output. Keeping the new version.", relPath)
+			return nil
+		}
+		return vfs.RemoveAll(filepath.Join(mergedPath, relPath))
+	})
+}
+
+// savePristineOutput keeps a copy of the unedited output for the next transformation
+func savePristineOutput(outputPath, pristinePath string) error {
+	if err := vfs.RemoveAll(pristinePath); err != nil {
+		return fmt.Errorf("failed to remove the old pristine output at path %s . Error: %w", pristinePath, err)
+	}
+	tempPristinePath, err := vfs.MkdirTemp(common.TempPath, "pristine-*")
+	if err != nil {
+		return fmt.Errorf("failed to create a temporary directory for the pristine output. Error: %w", err)
+	}
+	defer vfs.RemoveAll(tempPristinePath)
+	if err := filesystem.Replicate(outputPath, tempPristinePath); err != nil {
+		return fmt.Errorf("failed to copy the output to the directory %s . Error: %w", tempPristinePath, err)
+	}
+	if err := filesystem.Replicate(tempPristinePath, pristinePath); err != nil {
+		return fmt.Errorf("failed to copy the output to the pristine output directory %s . Error: %w", pristinePath, err)
+	}
+	return nil
+}
+
+// isMissingOrEmptyDir returns true if there is nothing at the path or if it is an empty directory
+func isMissingOrEmptyDir(path string) (bool, error) {
+	entries, err := vfs.ReadDir(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to read the directory %s . Error: %w", path, err)
+	}
+	return len(entries) == 0, nil
+}
+
+func isSameFile(path1, path2 string) (bool, error) {
+	content1, err := vfs.ReadFile(path1)
+	if err != nil {
+		return false, err
+	}
+	content2, err := vfs.ReadFile(path2)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(content1, content2), nil
+}
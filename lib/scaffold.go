@@ -0,0 +1,277 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package lib
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"text/template"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
+	"github.com/konveyor/move2kube-wasm/transformer"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// StarlarkScaffoldClass scaffolds a transformer implemented in Starlark
+	StarlarkScaffoldClass = "Starlark"
+	// ExecutableScaffoldClass scaffolds a transformer implemented as local executables
+	ExecutableScaffoldClass = "Executable"
+	// scaffoldMarkerFile is the file the scaffolded transformer looks for to detect services
+	scaffoldMarkerFile = "m2k-sample.txt"
+)
+
+// ScaffoldClasses contains the transformer classes that can be scaffolded
+var ScaffoldClasses = []string{StarlarkScaffoldClass, ExecutableScaffoldClass}
+
+// GetAvailableScaffoldClasses returns the scaffold classes whose transformers can be run by this build
+func GetAvailableScaffoldClasses() []string {
+	classes := []string{}
+	for _, class := range ScaffoldClasses {
+		if transformer.IsTransformerClassAvailable(class) {
+			classes = append(classes, class)
+		}
+	}
+	return classes
+}
+
+// TransformerScaffold contains the options used to generate a new custom transformer
+type TransformerScaffold struct {
+	Name     string
+	Class    string
+	Consumes []string
+	Produces []string
+	// MarkerFile is the file whose presence in a directory makes it a service
+	MarkerFile string
+	// TemplatesDir is the directory containing the templates, relative to the transformer yaml
+	TemplatesDir string
+}
+
+var scaffoldFiles = map[string]map[string]string{
+	StarlarkScaffoldClass: {
+		"transformer.star": `# {{ .Name }} transformer
+# directory_detect is called for every directory in the source. It returns the services found in the directory.
+# transform is called with the artifacts consumed by the transformer. It returns the path mappings and new artifacts.
+
+MARKER_FILE = "{{ .MarkerFile }}"
+
+def directory_detect(dir):
+    marker_path = fs.path_join(dir, MARKER_FILE)
+    if not fs.exists(marker_path):
+        return {}
+    name = fs.read_as_string(marker_path).strip()
+    return {name: [{"paths": {"ServiceDirectories": [dir]}}]}
+
+def transform(new_artifacts, old_artifacts):
+    path_mappings = []
+    artifacts = []
+    for artifact in new_artifacts:
+        name = artifact["name"]
+        path_mappings.append({
+            "type": "Template",
+            "sourcePath": "",
+            "destinationPath": fs.path_join("{{ .Name }}", name),
+            "templateConfig": {"ServiceName": name},
+        })
+    return {"pathMappings": path_mappings, "artifacts": artifacts}
+`,
+	},
+	ExecutableScaffoldClass: {
+		"detect.py": `# {{ .Name }} transformer: detects the services in the directory given in the input.
+# The input {"InputDirectory": dir} is read from the json file in M2K_DETECT_INPUT_PATH
+# and the services found in the directory are written as json to the file in M2K_DETECT_OUTPUT_PATH.
+import json
+import os
+
+MARKER_FILE = "{{ .MarkerFile }}"
+
+
+def detect(input_dir):
+    marker_path = os.path.join(input_dir, MARKER_FILE)
+    if not os.path.isfile(marker_path):
+        return {}
+    with open(marker_path) as f:
+        name = f.read().strip()
+    return {name: [{"paths": {"ServiceDirectories": [input_dir]}}]}
+
+
+def main():
+    with open(os.environ["M2K_DETECT_INPUT_PATH"]) as f:
+        detect_input = json.load(f)
+    with open(os.environ["M2K_DETECT_OUTPUT_PATH"], "w") as f:
+        json.dump(detect(detect_input["InputDirectory"]), f)
+
+
+if __name__ == "__main__":
+    main()
+`,
+		"transform.py": `# {{ .Name }} transformer: transforms the artifacts given in the input.
+# The input {"newArtifacts": [...], "oldArtifacts": [...]} is read from the json file in M2K_TRANSFORM_INPUT_PATH
+# and the path mappings and created artifacts are written as json to the file in M2K_TRANSFORM_OUTPUT_PATH.
+import json
+import os
+
+
+def transform(new_artifacts):
+    path_mappings = []
+    artifacts = []
+    for artifact in new_artifacts:
+        name = artifact["name"]
+        path_mappings.append({
+            "type": "Template",
+            "sourcePath": "",
+            "destinationPath": os.path.join("{{ .Name }}", name),
+            "templateConfig": {"ServiceName": name},
+        })
+    return {"pathMappings": path_mappings, "artifacts": artifacts}
+
+
+def main():
+    with open(os.environ["M2K_TRANSFORM_INPUT_PATH"]) as f:
+        transform_input = json.load(f)
+    with open(os.environ["M2K_TRANSFORM_OUTPUT_PATH"], "w") as f:
+        json.dump(transform(transform_input["newArtifacts"]), f)
+
+
+if __name__ == "__main__":
+    main()
+`,
+	},
+}
+
+const scaffoldTransformerYaml = `apiVersion: move2kube.konveyor.io/v1alpha1
+kind: Transformer
+metadata:
+  name: {{ .Name }}
+  labels:
+    move2kube.konveyor.io/built-in: false
+spec:
+  class: "{{ .Class }}"
+  templates: "{{ .TemplatesDir }}"
+  directoryDetect:
+    levels: -1
+  consumes:
+{{- range .Consumes }}
+    {{ . }}:
+      merge: false
+{{- end }}
+  produces:
+{{- if not .Produces }} {}{{ end }}
+{{- range .Produces }}
+    {{ . }}:
+      disabled: false
+{{- end }}
+  config:
+{{- if eq .Class "Starlark" }}
+    starFile: "transformer.star"
+{{- else }}
+    platforms:
+      - "linux"
+      - "darwin"
+    directoryDetectCMD: ["python3", "detect.py"]
+    transformCMD: ["python3", "transform.py"]
+{{- end }}
+`
+
+// scaffoldTemplate is copied verbatim since it is a template that is filled in during the transformation
+const scaffoldTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .ServiceName }}-config
+data:
+  generatedBy: "{{ .ServiceName }} transformer"
+`
+
+const scaffoldReadme = `# {{ .Name }}
+
+A custom {{ .Class }} transformer generated by move2kube.
+
+- customizations/{{ .Name }}/transformer.yaml : the transformer configuration
+- customizations/{{ .Name }}/{{ .TemplatesDir }} : the templates used in the Template path mappings
+- source : a sample source directory with a service the transformer detects using the file {{ .MarkerFile }}
+
+Try it out with:
+
+    move2kube transformers check -c customizations
+    move2kube plan -s source -c customizations
+    move2kube transform -s source -c customizations --qa-skip
+`
+
+// ScaffoldTransformer generates a customization directory containing a new transformer and a sample source directory to try it on
+func ScaffoldTransformer(scaffold TransformerScaffold, outputPath string) error {
+	if !common.IsPresent(ScaffoldClasses, scaffold.Class) {
+		return fmt.Errorf("the transformer class '%s' cannot be scaffolded. Supported classes: %+v", scaffold.Class, ScaffoldClasses)
+	}
+	if !transformer.IsTransformerClassAvailable(scaffold.Class) {
+		return fmt.Errorf("the transformer class '%s' is not available in this build, so the generated transformer could not be run. Available classes: %+v", scaffold.Class, GetAvailableScaffoldClasses())
+	}
+	if scaffold.MarkerFile == "" {
+		scaffold.MarkerFile = scaffoldMarkerFile
+	}
+	if scaffold.TemplatesDir == "" {
+		scaffold.TemplatesDir = "templates"
+	}
+	transformerPath := filepath.Join(outputPath, "customizations", scaffold.Name)
+	if _, err := vfs.Stat(transformerPath); err == nil {
+		return fmt.Errorf("the directory %s already exists", transformerPath)
+	}
+	files := map[string]string{
+		filepath.Join(transformerPath, "transformer.yaml"): scaffoldTransformerYaml,
+		filepath.Join(outputPath, "README.md"):             scaffoldReadme,
+	}
+	for name, content := range scaffoldFiles[scaffold.Class] {
+		files[filepath.Join(transformerPath, name)] = content
+	}
+	for path, content := range files {
+		tmpl, err := template.New(filepath.Base(path)).Parse(content)
+		if err != nil {
+			return fmt.Errorf("failed to parse the scaffold template for %s . Error: %w", path, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, scaffold); err != nil {
+			return fmt.Errorf("failed to fill the scaffold template for %s . Error: %w", path, err)
+		}
+		if err := writeScaffoldFile(path, buf.String()); err != nil {
+			return err
+		}
+	}
+	verbatimFiles := map[string]string{
+		filepath.Join(transformerPath, scaffold.TemplatesDir, "configmap.yaml"):     scaffoldTemplate,
+		filepath.Join(outputPath, "source", "sample-app", scaffold.MarkerFile):      "sample-app\n",
+		filepath.Join(outputPath, "source", "sample-app", "index.html"):             "<h1>sample-app</h1>\n",
+		filepath.Join(outputPath, "source", "not-a-service", "this-is-ignored.txt"): "directories without the marker file are not detected\n",
+	}
+	for path, content := range verbatimFiles {
+		if err := writeScaffoldFile(path, content); err != nil {
+			return err
+		}
+	}
+	logrus.Infof("Generated the transformer '%s' in the directory %s", scaffold.Name, transformerPath)
+	return nil
+}
+
+func writeScaffoldFile(path, content string) error {
+	if err := vfs.MkdirAll(filepath.Dir(path), common.DefaultDirectoryPermission); err != nil {
+		return fmt.Errorf("failed to create the directory %s . Error: %w", filepath.Dir(path), err)
+	}
+	if err := vfs.WriteFile(path, []byte(content), common.DefaultFilePermission); err != nil {
+		return fmt.Errorf("failed to write the file %s . Error: %w", path, err)
+	}
+	return nil
+}
@@ -20,32 +20,69 @@ package lib
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/qaengine"
 	"github.com/konveyor/move2kube-wasm/transformer"
 	"github.com/konveyor/move2kube-wasm/transformer/external"
+	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
 	plantypes "github.com/konveyor/move2kube-wasm/types/plan"
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"os"
 	"sort"
 )
 
-// Transform transforms the artifacts and writes output
+// Transform transforms the artifacts and writes output.
+// A checkpoint is written to the current directory after every iteration and removed when the transformation completes.
 func Transform(ctx context.Context, plan plantypes.Plan, preExistingPlan bool, outputPath string, transformerSelector string, maxIterations int) error {
+	_, _, err := transform(ctx, plan, preExistingPlan, outputPath, transformerSelector, maxIterations, transformer.CheckpointFileName, graphtypes.GraphFileName, false)
+	return err
+}
+
+// ResumeTransform continues the transformation from the checkpoint in the current directory.
+// It fails if the plan or the transformers have changed since the checkpoint was created.
+func ResumeTransform(ctx context.Context, plan plantypes.Plan, preExistingPlan bool, outputPath string, transformerSelector string, maxIterations int) error {
+	_, _, err := transform(ctx, plan, preExistingPlan, outputPath, transformerSelector, maxIterations, transformer.CheckpointFileName, graphtypes.GraphFileName, true)
+	return err
+}
+
+// transform runs the transformation using the plan. The checkpoint is not written if the checkpoint path is empty
+// and the graph is only returned, not written to a file, if the graph path is empty.
+func transform(ctx context.Context, plan plantypes.Plan, preExistingPlan bool, outputPath string, transformerSelector string, maxIterations int, checkpointPath, graphPath string, resume bool) ([]transformertypes.PathMapping, *graphtypes.Graph, error) {
 	logrus.Infof("Starting transformation")
 
 	common.ProjectName = plan.Name
 	logrus.Debugf("common.TempPath: '%s'", common.TempPath)
 
+	checkpoint := transformer.CheckpointOptions{Path: checkpointPath}
+	if checkpointPath != "" {
+		planHash, err := getPlanHash(plan)
+		if err != nil {
+			return nil, nil, err
+		}
+		checkpoint.PlanHash = planHash
+		if resume {
+			if checkpoint.Resume, err = transformer.ReadCheckpoint(checkpointPath, planHash); err != nil {
+				return nil, nil, fmt.Errorf("failed to resume the transformation. Error: %w", err)
+			}
+			if err := qaengine.AddSolutions(checkpoint.Resume.QASolutions); err != nil {
+				return nil, nil, fmt.Errorf("failed to restore the answers from the checkpoint. Error: %w", err)
+			}
+		}
+	}
+
 	transformerSelectorObj, err := common.ConvertStringSelectorsToSelectors(transformerSelector)
 	if err != nil {
-		return fmt.Errorf("failed to parse the transformer selector string. Error: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse the transformer selector string. Error: %w", err)
 	}
 	selectorsInPlan, err := metav1.LabelSelectorAsSelector(&plan.Spec.TransformerSelector)
 	if err != nil {
-		return fmt.Errorf("failed to convert label selector to selector. Error: %w", err)
+		return nil, nil, fmt.Errorf("failed to convert label selector to selector. Error: %w", err)
 	}
 	requirements, _ := selectorsInPlan.Requirements()
 	transformerSelectorObj = transformerSelectorObj.Add(requirements...)
@@ -58,6 +95,7 @@ func Transform(ctx context.Context, plan plantypes.Plan, preExistingPlan bool, o
 	//}
 
 	if _, err := transformer.InitTransformers(
+		ctx,
 		plan.Spec.Transformers,
 		transformerSelectorObj,
 		plan.Spec.SourceDir,
@@ -66,7 +104,7 @@ func Transform(ctx context.Context, plan plantypes.Plan, preExistingPlan bool, o
 		true,
 		preExistingPlan,
 	); err != nil {
-		return fmt.Errorf("failed to initialize the transformers. Error: %w", err)
+		return nil, nil, fmt.Errorf("failed to initialize the transformers. Error: %w", err)
 	}
 
 	// select only the services the user is interested in
@@ -76,6 +114,7 @@ func Transform(ctx context.Context, plan plantypes.Plan, preExistingPlan bool, o
 	}
 	sort.Strings(serviceNames)
 	selectedServiceNames := qaengine.FetchMultiSelectAnswer(
+		ctx,
 		common.ConfigServicesNamesKey,
 		"Select all services that are needed:",
 		[]string{"The services unselected here will be ignored."},
@@ -83,33 +122,48 @@ func Transform(ctx context.Context, plan plantypes.Plan, preExistingPlan bool, o
 		serviceNames,
 		nil,
 	)
-	// select the first valid transformation option for each selected service
+	// select a transformation option for each selected service, ranked by the detection confidence
 	selectedTransformationOptions := []plantypes.PlanArtifact{}
 	for _, selectedServiceName := range selectedServiceNames {
-		options := plan.Spec.Services[selectedServiceName]
-		found := false
-		if len(options) > 1 {
-			logrus.Infof("Found multiple transformation options for the service '%s'. Selecting the first valid option.", selectedServiceName)
-		}
-		for _, option := range options {
-			if _, err := transformer.GetTransformerByName(option.TransformerName); err != nil {
-				logrus.Errorf("failed to get the transformer named '%s' for the service '%s' . Error: %q", option.TransformerName, selectedServiceName, err)
+		containerNames, containerOptions := groupOptionsByContainer(plan.Spec.Services[selectedServiceName])
+		for _, containerName := range containerNames {
+			validOptions := []plantypes.PlanArtifact{}
+			for _, option := range containerOptions[containerName] {
+				if _, err := transformer.GetTransformerByName(option.TransformerName); err != nil {
+					logrus.Errorf("failed to get the transformer named '%s' for the service '%s' . Error: %q", option.TransformerName, selectedServiceName, err)
+					continue
+				}
+				validOptions = append(validOptions, option)
+			}
+			serviceOrContainerName := selectedServiceName
+			if containerName != "" {
+				serviceOrContainerName = selectedServiceName + "/" + containerName
+			}
+			if len(validOptions) == 0 {
+				logrus.Warnf("No valid transformers were found for the service '%s'. Skipping.", serviceOrContainerName)
 				continue
 			}
+			plantypes.SortPlanArtifacts(validOptions)
+			option := validOptions[0]
+			if len(validOptions) > 1 {
+				logrus.Infof("Found %d transformation options for the service '%s'.", len(validOptions), serviceOrContainerName)
+				option = selectTransformationOption(ctx, serviceOrContainerName, validOptions)
+			}
 			option.ServiceName = selectedServiceName
 			selectedTransformationOptions = append(selectedTransformationOptions, option)
-			logrus.Infof("Using the transformation option '%s' for the service '%s'.", option.TransformerName, selectedServiceName)
-			found = true
-			break
-		}
-		if !found {
-			logrus.Warnf("No valid transformers were found for the service '%s'. Skipping.", selectedServiceName)
+			logrus.Infof("Using the transformation option '%s' for the service '%s'.", option.TransformerName, serviceOrContainerName)
 		}
 	}
 
 	// transform the selected services using the selected transformation options
-	if err := transformer.Transform(selectedTransformationOptions, plan.Spec.SourceDir, outputFSPath, maxIterations); err != nil {
-		return fmt.Errorf("failed to transform using the plan. Error: %w", err)
+	pathMappings, graph, err := transformer.Transform(ctx, selectedTransformationOptions, plan.Spec.SourceDir, outputFSPath, maxIterations, checkpoint)
+	if err != nil {
+		return pathMappings, graph, fmt.Errorf("failed to transform using the plan. Error: %w", err)
+	}
+	if graphPath != "" {
+		if err := writeGraph(graph, graphPath); err != nil {
+			logrus.Errorf("failed to write the graph. Error: %q", err)
+		}
 	}
 
 	logrus.Infof("Transformation done")
@@ -121,14 +175,72 @@ func Transform(ctx context.Context, plan plantypes.Plan, preExistingPlan bool, o
 	//	}
 	//	logrus.Infof("move2kube generated artifcats are commited and pushed")
 	//}
+	return pathMappings, graph, nil
+}
+
+// writeGraph writes the graph of the transformer runs to a json file
+func writeGraph(graph *graphtypes.Graph, graphPath string) error {
+	graphFile, err := vfs.Create(graphPath)
+	if err != nil {
+		return fmt.Errorf("failed to create a %s file to write to the graph. Error: %w", graphPath, err)
+	}
+	defer graphFile.Close()
+	enc := json.NewEncoder(graphFile)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(graph); err != nil {
+		return fmt.Errorf("failed to encode the graph as json. Error: %w", err)
+	}
 	return nil
 }
 
+// getPlanHash returns a hash that changes whenever the plan changes
+func getPlanHash(plan plantypes.Plan) (string, error) {
+	planBytes, err := common.ObjectToYamlBytes(plan)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the plan to yaml. Error: %w", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(planBytes)), nil
+}
+
+// selectTransformationOption asks which of the ranked transformation options should be used for the service.
+// The option with the highest confidence is the default.
+func selectTransformationOption(ctx context.Context, serviceName string, options []plantypes.PlanArtifact) plantypes.PlanArtifact {
+	optionDescs := []string{}
+	descToOption := map[string]plantypes.PlanArtifact{}
+	for _, option := range options {
+		desc := fmt.Sprintf("%s (confidence: %.2f)", option.TransformerName, option.Confidence)
+		if option.Reason != "" {
+			desc = fmt.Sprintf("%s (confidence: %.2f, %s)", option.TransformerName, option.Confidence, option.Reason)
+		}
+		if _, ok := descToOption[desc]; ok {
+			// the same transformer detected the service more than once, keep the higher ranked one
+			continue
+		}
+		optionDescs = append(optionDescs, desc)
+		descToOption[desc] = option
+	}
+	quesKey := fmt.Sprintf(common.ConfigServicesTransformationOptionKey, `"`+serviceName+`"`)
+	selectedDesc := qaengine.FetchSelectAnswer(
+		ctx,
+		quesKey,
+		fmt.Sprintf("Select the transformation option to use for the service '%s':", serviceName),
+		[]string{"The options are sorted by the confidence of the detection."},
+		optionDescs[0],
+		optionDescs,
+		nil,
+	)
+	if option, ok := descToOption[selectedDesc]; ok {
+		return option
+	}
+	logrus.Warnf("the selected transformation option '%s' for the service '%s' is invalid. Using '%s' instead.", selectedDesc, serviceName, optionDescs[0])
+	return options[0]
+}
+
 // Destroy destroys the tranformers
 func Destroy() {
 	logrus.Debugf("Cleaning up!")
 	transformer.Destroy()
-	err := os.RemoveAll(common.TempPath)
+	err := vfs.RemoveAll(common.TempPath)
 	if err != nil {
 		logrus.Debug("failed to delete temp directory. Error : ", err)
 	}
@@ -22,9 +22,13 @@ import (
 	"path/filepath"
 
 	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/filesystem"
 )
 
+// customizationsAssetsDir is the directory in the assets directory where the customizations are copied to
+const customizationsAssetsDir = "custom"
+
 // CheckAndCopyCustomizations checks if the customizations path is an existing directory and copies to assets
 func CheckAndCopyCustomizations(customizationsPath string) error {
 	//remoteCustomizationsPath := vcs.GetClonedPath(customizationsPath, common.RemoteCustomizationsFolder, true)
@@ -39,7 +43,7 @@ func CheckAndCopyCustomizations(customizationsPath string) error {
 	if err != nil {
 		return fmt.Errorf("failed to make the customizations directory path '%s' absolute. Error: %w", customizationsFSPath, err)
 	}
-	fi, err := os.Stat(customizationsFSPath)
+	fi, err := vfs.Stat(customizationsFSPath)
 	if os.IsNotExist(err) {
 		return fmt.Errorf("the given customizations directory '%s' does not exist. Error: %w", customizationsFSPath, err)
 	}
@@ -71,10 +75,10 @@ func CopyCustomizationsAssetsData(customizationsPath string) (err error) {
 	if err != nil {
 		return fmt.Errorf("failed to make the assets path '%s' absolute. Error: %w", assetsPath, err)
 	}
-	customizationsAssetsPath := filepath.Join(assetsPath, "custom")
+	customizationsAssetsPath := filepath.Join(assetsPath, customizationsAssetsDir)
 
 	// Create the subdirectory and copy the assets into it.
-	if err = os.MkdirAll(customizationsAssetsPath, common.DefaultDirectoryPermission); err != nil {
+	if err = vfs.MkdirAll(customizationsAssetsPath, common.DefaultDirectoryPermission); err != nil {
 		return fmt.Errorf("failed to create the customization assets directory at path '%s' . Error: %w", customizationsAssetsPath, err)
 	}
 	if err = filesystem.Replicate(customizationsPath, customizationsAssetsPath); err != nil {
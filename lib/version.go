@@ -1,16 +1,32 @@
 package lib
 
 import (
+	"reflect"
+
+	"github.com/konveyor/move2kube-wasm/collector"
+	"github.com/konveyor/move2kube-wasm/transformer"
 	"github.com/konveyor/move2kube-wasm/types/info"
 	"gopkg.in/yaml.v3"
 )
 
+// buildInfo is the version info along with the transformer classes and the collectors selected by the build tags
+type buildInfo struct {
+	info.VersionInfo   `yaml:",inline"`
+	TransformerClasses []string `yaml:"transformerClasses,omitempty"`
+	Collectors         []string `yaml:"collectors,omitempty"`
+}
+
 // GetVersion returns the version
 func GetVersion(long bool) string {
 	if !long {
 		return info.GetVersion()
 	}
-	v := info.GetVersionInfo()
+	v := buildInfo{VersionInfo: info.GetVersionInfo(), TransformerClasses: transformer.GetTransformerClasses()}
+	if collectors, err := collector.GetCollectors(); err == nil {
+		for _, c := range collectors {
+			v.Collectors = append(v.Collectors, reflect.TypeOf(c).Elem().Name())
+		}
+	}
 	ver, _ := yaml.Marshal(v)
 	return string(ver)
 }
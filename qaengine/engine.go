@@ -17,12 +17,16 @@
 package qaengine
 
 import (
+	"context"
 	"fmt"
 	"github.com/konveyor/move2kube-wasm/common"
 	"github.com/konveyor/move2kube-wasm/common/download"
+	"github.com/konveyor/move2kube-wasm/types/event"
 	qatypes "github.com/konveyor/move2kube-wasm/types/qaengine"
+	"github.com/konveyor/move2kube-wasm/types/report"
 	"github.com/sirupsen/logrus"
 	"path/filepath"
+	"sync"
 )
 
 // Engine defines interface for qa engines
@@ -33,23 +37,58 @@ type Engine interface {
 }
 
 var (
-	engines       []Engine
-	stores        []qatypes.Store
 	defaultEngine = NewDefaultEngine()
+	// session contains the engines and stores used by the package level functions
+	session = NewSession()
+	// fetchAnswerMutex allows transformers running concurrently to ask questions one at a time
+	fetchAnswerMutex sync.Mutex
 )
 
-// StartEngine starts the QA Engines
-func StartEngine(qaskip bool, qaport int, qadisablecli bool) {
-	var e Engine
+// Session contains the engines that answer the questions of a plan or a transformation and the stores the answers are written to
+type Session struct {
+	engines []Engine
+	stores  []qatypes.Store
+	// solutions contains the serialized problems that were answered in this session
+	solutions []qatypes.Problem
+}
+
+// NewSession returns a session without any engines or stores
+func NewSession() *Session {
+	return &Session{}
+}
+
+// SetSession makes the session the one used by the package level functions like FetchAnswer and AddEngine.
+// It returns the session that was used before.
+func SetSession(s *Session) *Session {
+	fetchAnswerMutex.Lock()
+	defer fetchAnswerMutex.Unlock()
+	previous := session
+	session = s
+	return previous
+}
+
+// NewEngine returns the engine that answers the questions that are not answered by the configs and caches.
+// It returns nil if no such engine is available in this build.
+func NewEngine(qaskip bool, qaport int, qadisablecli bool) Engine {
 	if qaskip {
-		e = NewDefaultEngine()
+		return NewDefaultEngine()
 	}
 	//TODO: WASI
 	// else if !qadisablecli {
-	//	e = NewCliEngine()
+	//	return NewCliEngine()
 	//} else {
-	//	e = NewHTTPRESTEngine(qaport)
+	//	return NewHTTPRESTEngine(qaport)
 	//}
+	return nil
+}
+
+// StartEngine starts the QA Engines
+func StartEngine(qaskip bool, qaport int, qadisablecli bool) {
+	e := NewEngine(qaskip, qaport, qadisablecli)
+	if e == nil {
+		logrus.Warnf("the interactive QA engines are not available in this build. Using the default answers.")
+		e = NewDefaultEngine()
+	}
 	AddEngine(e)
 }
 
@@ -58,7 +97,7 @@ func AddEngine(e Engine) {
 	if err := e.StartEngine(); err != nil {
 		logrus.Errorf("Ignoring engine %T due to error : %s", e, err)
 	} else {
-		engines = append(engines, e)
+		session.engines = append(session.engines, e)
 	}
 }
 
@@ -67,7 +106,7 @@ func AddEngineHighestPriority(e Engine) error {
 	if err := e.StartEngine(); err != nil {
 		return fmt.Errorf("failed to start the engine: %T\n%v\nError: %s", e, e, err)
 	}
-	engines = append([]Engine{e}, engines...)
+	session.engines = append([]Engine{e}, session.engines...)
 	return nil
 }
 
@@ -89,7 +128,7 @@ func AddCaches(cacheFiles ...string) {
 func SetupWriteCacheFile(writeCachePath string, persistPasswords bool) {
 	cache := qatypes.NewCache(writeCachePath, persistPasswords)
 	cache.Write()
-	stores = append(stores, cache)
+	session.stores = append(session.stores, cache)
 	AddCaches(writeCachePath)
 }
 
@@ -111,7 +150,7 @@ func SetupConfigFile(writeConfigFile string, configStrings, configFiles, presets
 	configFiles = append(presetPaths, configFiles...)
 	writeConfig := qatypes.NewConfig(writeConfigFile, configStrings, configFiles, persistPasswords)
 	if writeConfigFile != "" {
-		stores = append(stores, writeConfig)
+		session.stores = append(session.stores, writeConfig)
 	}
 	e := &StoreEngine{store: writeConfig}
 	if err := AddEngineHighestPriority(e); err != nil {
@@ -119,18 +158,23 @@ func SetupConfigFile(writeConfigFile string, configStrings, configFiles, presets
 	}
 }
 
-// FetchAnswer fetches the answer for the question
-func FetchAnswer(prob qatypes.Problem) (qatypes.Problem, error) {
+// FetchAnswer fetches the answer for the question.
+// The problem is recorded by the problem recorder carried by the context, if there is one.
+func FetchAnswer(ctx context.Context, prob qatypes.Problem) (qatypes.Problem, error) {
 	logrus.Trace("FetchAnswer start")
 	defer logrus.Trace("FetchAnswer end")
+	fetchAnswerMutex.Lock()
+	defer fetchAnswerMutex.Unlock()
 	logrus.Debugf("Fetching answer for the problem: %#v", prob)
 	if prob.Answer != nil {
 		logrus.Debugf("Problem already solved.")
 		return prob, nil
 	}
+	recordAskedProblem(ctx, prob.ID)
+	event.Publish(event.Event{Type: event.QuestionAsked, QuestionID: prob.ID})
 	var err error
 	logrus.Debug("looping through the engines to try and fetch the answer")
-	for _, engine := range engines {
+	for _, engine := range session.engines {
 		logrus.Debugf("engine '%T'", engine)
 		if prob.Desc == "" && engine.IsInteractiveEngine() {
 			return defaultEngine.FetchAnswer(prob)
@@ -139,6 +183,7 @@ func FetchAnswer(prob qatypes.Problem) (qatypes.Problem, error) {
 		if err != nil {
 			if _, ok := err.(*qatypes.ValidationError); ok {
 				logrus.Errorf("failed to fetch the answer using the engine '%T' . Error: %q", engine, err)
+				report.AddError(report.QAValidationFailure, err, report.RunError{QuestionID: prob.ID})
 				continue
 			}
 			logrus.Debugf("failed to fetch the answer using the engine '%T' . Error: %q", engine, err)
@@ -152,10 +197,11 @@ func FetchAnswer(prob qatypes.Problem) (qatypes.Problem, error) {
 	if err != nil || prob.Answer == nil {
 		logrus.Debugf("the answer is nil: '%+v' or there was an error: %q , checking if the problem is valid", prob.Answer, err)
 		if err := ValidateProblem(prob); err != nil {
+			report.AddError(report.QAValidationFailure, err, report.RunError{QuestionID: prob.ID})
 			return prob, fmt.Errorf("the QA problem object is invalid: %+v . Error: %w", prob, err)
 		}
 		logrus.Debug("loop using interactive engine until we get an answer")
-		lastEngine := engines[len(engines)-1]
+		lastEngine := session.engines[len(session.engines)-1]
 		if !lastEngine.IsInteractiveEngine() {
 			logrus.Debug("there is no interactive engine")
 			return prob, fmt.Errorf("failed to fetch the answer for problem: %+v . Error: %w", prob, err)
@@ -171,16 +217,48 @@ func FetchAnswer(prob qatypes.Problem) (qatypes.Problem, error) {
 			}
 		}
 	}
-	for _, store := range stores {
+	for _, store := range session.stores {
 		store.AddSolution(prob)
 	}
+	if err == nil && prob.Answer != nil && prob.Type != qatypes.PasswordSolutionFormType {
+		if serializedProb, err := qatypes.Serialize(prob); err == nil {
+			session.solutions = append(session.solutions, serializedProb)
+		}
+	}
 	return prob, err
 }
 
+// ResetEngines removes all the engines and stores and forgets the problems answered so far, so that the engines can be set up again for another project
+func ResetEngines() {
+	fetchAnswerMutex.Lock()
+	defer fetchAnswerMutex.Unlock()
+	*session = *NewSession()
+}
+
+// GetSolutions returns the problems that were answered in this run
+func GetSolutions() []qatypes.Problem {
+	return append([]qatypes.Problem{}, session.solutions...)
+}
+
+// AddSolutions adds previously answered problems with the highest priority
+func AddSolutions(problems []qatypes.Problem) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	cacheFile := filepath.Join(common.TempPath, "restored-"+common.QACacheFile)
+	cache := qatypes.NewCache(cacheFile, false)
+	cache.Spec.Problems = problems
+	if err := cache.Write(); err != nil {
+		return fmt.Errorf("failed to write the restored answers to a cache file. Error: %w", err)
+	}
+	AddCaches(cacheFile)
+	return nil
+}
+
 // WriteStoresToDisk forces all the stores to write their contents out to disk
 func WriteStoresToDisk() error {
 	var err error
-	for _, store := range stores {
+	for _, store := range session.stores {
 		cerr := store.Write()
 		if cerr != nil {
 			if err == nil {
@@ -208,12 +286,12 @@ func changeSelectToInputForOther(prob qatypes.Problem) qatypes.Problem {
 // Convenience functions
 
 // FetchStringAnswer asks a input type question and gets a string as the answer
-func FetchStringAnswer(probid, desc string, context []string, def string, validator func(interface{}) error) string {
+func FetchStringAnswer(ctx context.Context, probid, desc string, context []string, def string, validator func(interface{}) error) string {
 	problem, err := qatypes.NewInputProblem(probid, desc, context, def, validator)
 	if err != nil {
 		logrus.Fatalf("Unable to create problem. Error: %q", err)
 	}
-	problem, err = FetchAnswer(problem)
+	problem, err = FetchAnswer(ctx, problem)
 	if err != nil {
 		logrus.Fatalf("Unable to fetch answer. Error: %q", err)
 	}
@@ -225,12 +303,12 @@ func FetchStringAnswer(probid, desc string, context []string, def string, valida
 }
 
 // FetchBoolAnswer asks a confirm type question and gets a boolean as the answer
-func FetchBoolAnswer(probid, desc string, context []string, def bool, validator func(interface{}) error) bool {
+func FetchBoolAnswer(ctx context.Context, probid, desc string, context []string, def bool, validator func(interface{}) error) bool {
 	problem, err := qatypes.NewConfirmProblem(probid, desc, context, def, validator)
 	if err != nil {
 		logrus.Fatalf("Unable to create problem. Error: %q", err)
 	}
-	problem, err = FetchAnswer(problem)
+	problem, err = FetchAnswer(ctx, problem)
 	if err != nil {
 		logrus.Fatalf("Unable to fetch answer. Error: %q", err)
 	}
@@ -242,12 +320,12 @@ func FetchBoolAnswer(probid, desc string, context []string, def bool, validator
 }
 
 // FetchSelectAnswer asks a select type question and gets a string as the answer
-func FetchSelectAnswer(probid, desc string, context []string, def string, options []string, validator func(interface{}) error) string {
+func FetchSelectAnswer(ctx context.Context, probid, desc string, context []string, def string, options []string, validator func(interface{}) error) string {
 	problem, err := qatypes.NewSelectProblem(probid, desc, context, def, options, validator)
 	if err != nil {
 		logrus.Fatalf("Unable to create problem. Error: %q", err)
 	}
-	problem, err = FetchAnswer(problem)
+	problem, err = FetchAnswer(ctx, problem)
 	if err != nil {
 		logrus.Fatalf("Unable to fetch answer. Error: %q", err)
 	}
@@ -259,12 +337,12 @@ func FetchSelectAnswer(probid, desc string, context []string, def string, option
 }
 
 // FetchMultiSelectAnswer asks a multi-select type question and gets a slice of strings as the answer
-func FetchMultiSelectAnswer(probid, desc string, context, def, options []string, validator func(interface{}) error) []string {
+func FetchMultiSelectAnswer(ctx context.Context, probid, desc string, context, def, options []string, validator func(interface{}) error) []string {
 	problem, err := qatypes.NewMultiSelectProblem(probid, desc, context, def, options, validator)
 	if err != nil {
 		logrus.Fatalf("Unable to create problem. Error: %q", err)
 	}
-	problem, err = FetchAnswer(problem)
+	problem, err = FetchAnswer(ctx, problem)
 	if err != nil {
 		logrus.Fatalf("Unable to fetch answer. Error: %q", err)
 	}
@@ -276,12 +354,12 @@ func FetchMultiSelectAnswer(probid, desc string, context, def, options []string,
 }
 
 // FetchPasswordAnswer asks a password type question and gets a string as the answer
-func FetchPasswordAnswer(probid, desc string, context []string, validator func(interface{}) error) string {
+func FetchPasswordAnswer(ctx context.Context, probid, desc string, context []string, validator func(interface{}) error) string {
 	problem, err := qatypes.NewPasswordProblem(probid, desc, context, validator)
 	if err != nil {
 		logrus.Fatalf("Unable to create problem. Error: %q", err)
 	}
-	problem, err = FetchAnswer(problem)
+	problem, err = FetchAnswer(ctx, problem)
 	if err != nil {
 		logrus.Fatalf("Unable to fetch answer. Error: %q", err)
 	}
@@ -293,12 +371,12 @@ func FetchPasswordAnswer(probid, desc string, context []string, validator func(i
 }
 
 // FetchMultilineInputAnswer asks a multi-line type question and gets a string as the answer
-func FetchMultilineInputAnswer(probid, desc string, context []string, def string, validator func(interface{}) error) string {
+func FetchMultilineInputAnswer(ctx context.Context, probid, desc string, context []string, def string, validator func(interface{}) error) string {
 	problem, err := qatypes.NewMultilineInputProblem(probid, desc, context, def, validator)
 	if err != nil {
 		logrus.Fatalf("Unable to create problem. Error: %q", err)
 	}
-	problem, err = FetchAnswer(problem)
+	problem, err = FetchAnswer(ctx, problem)
 	if err != nil {
 		logrus.Fatalf("Unable to fetch answer. Error: %q", err)
 	}
@@ -0,0 +1,67 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package qaengine
+
+import (
+	"context"
+	"sync"
+)
+
+// ProblemRecorder collects the ids of the problems asked during a single call, like a run of a transformer.
+// It is carried by the context passed to the FetchAnswer functions.
+type ProblemRecorder struct {
+	mutex      sync.Mutex
+	problemIDs []string
+}
+
+type problemRecorderKey struct{}
+
+// NewProblemRecorder returns a recorder that has not recorded any problems
+func NewProblemRecorder() *ProblemRecorder {
+	return &ProblemRecorder{}
+}
+
+// GetProblemIDs returns the ids of the problems recorded so far, in order
+func (r *ProblemRecorder) GetProblemIDs() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string{}, r.problemIDs...)
+}
+
+func (r *ProblemRecorder) add(problemID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.problemIDs = append(r.problemIDs, problemID)
+}
+
+// WithProblemRecorder returns a context carrying the recorder, the problems asked using the context are recorded by it
+func WithProblemRecorder(ctx context.Context, r *ProblemRecorder) context.Context {
+	return context.WithValue(ctx, problemRecorderKey{}, r)
+}
+
+// GetProblemRecorder returns the recorder carried by the context, or nil if there is none
+func GetProblemRecorder(ctx context.Context) *ProblemRecorder {
+	r, _ := ctx.Value(problemRecorderKey{}).(*ProblemRecorder)
+	return r
+}
+
+// recordAskedProblem adds the problem to the recorder carried by the context, if there is one
+func recordAskedProblem(ctx context.Context, problemID string) {
+	if r := GetProblemRecorder(ctx); r != nil {
+		r.add(problemID)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"sort"
+
+	"github.com/konveyor/move2kube-wasm/common"
+
+	"github.com/konveyor/move2kube-wasm/transformer/containerimage"
+	"github.com/konveyor/move2kube-wasm/transformer/dockerfile"
+	"github.com/konveyor/move2kube-wasm/transformer/dockerfilegenerator/java"
+	"github.com/konveyor/move2kube-wasm/transformer/external"
+	"github.com/konveyor/move2kube-wasm/transformer/kubernetes"
+)
+
+// getBuiltinTransformers returns the transformer classes that are compiled in and cannot be cancelled using a context
+func getBuiltinTransformers() []Transformer {
+	return []Transformer{
+		//new(external.Starlark),
+		//
+		//new(Router),
+		//
+		//new(dockerfile.DockerfileDetector),
+		//new(dockerfile.DockerfileParser),
+		new(java.JarAnalyser),
+		new(java.WarAnalyser),
+		new(java.EarAnalyser),
+		new(java.Tomcat),
+		new(java.Liberty),
+		new(java.Jboss),
+		new(java.ZuulAnalyser),
+		//new(CNBContainerizer),
+		//new(compose.ComposeAnalyser),
+		//new(compose.ComposeGenerator),
+		//
+		//new(CloudFoundry),
+
+		//new(kubernetes.Knative),
+		//new(kubernetes.Tekton),
+		// new(kubernetes.ArgoCD),
+		//new(kubernetes.BuildConfig),
+		//new(kubernetes.KubernetesVersionChanger),
+		//new(kubernetes.OperatorTransformer),
+
+		new(ReadMeGenerator),
+		//new(InvokeDetect),
+	}
+}
+
+// getBuiltinContextTransformers returns the transformer classes that are compiled in and can be cancelled using a context.
+// They stop before the next artifact once the context is done.
+// The optional sets are selected using build tags:
+//   - m2k_java_only leaves out the Dockerfile generators for languages other than Java
+func getBuiltinContextTransformers() []ContextTransformer {
+	transformerObjs := []ContextTransformer{
+		new(external.Executable),
+		new(dockerfile.DockerfileImageBuildScript),
+		new(java.MavenAnalyser),
+		new(java.GradleAnalyser),
+		new(containerimage.ContainerImagesPushScript),
+		new(kubernetes.ClusterSelectorTransformer),
+		new(kubernetes.Kubernetes),
+		new(kubernetes.Parameterizer),
+	}
+	return append(transformerObjs, languageTransformers...)
+}
+
+// isExcludedTransformerClass returns true if the transformer class was left out of the build using a build tag
+func isExcludedTransformerClass(class string) bool {
+	return common.IsPresent(excludedLanguageTransformerClasses, class)
+}
+
+// GetTransformerClasses returns the names of the transformer classes that can be used, sorted by name
+func GetTransformerClasses() []string {
+	classes := []string{}
+	for class := range transformerTypes {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+	return classes
+}
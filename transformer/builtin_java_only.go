@@ -0,0 +1,39 @@
+//go:build m2k_java_only
+// +build m2k_java_only
+
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+var (
+	// languageTransformers is empty since only the Java transformers are compiled in
+	languageTransformers = []ContextTransformer{}
+	// excludedLanguageTransformerClasses are the classes of the built-in transformers that are left out,
+	// the transformer configs using them are skipped without an error
+	excludedLanguageTransformerClasses = []string{
+		"NodejsDockerfileGenerator",
+		"GolangDockerfileGenerator",
+		"PHPDockerfileGenerator",
+		"PythonDockerfileGenerator",
+		"RubyDockerfileGenerator",
+		"RustDockerfileGenerator",
+		"DotNetCoreDockerfileGenerator",
+		"WinConsoleAppDockerfileGenerator",
+		"WinSilverLightWebAppDockerfileGenerator",
+		"WinWebAppDockerfileGenerator",
+	}
+)
@@ -0,0 +1,42 @@
+//go:build !m2k_java_only
+// +build !m2k_java_only
+
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"github.com/konveyor/move2kube-wasm/transformer/dockerfilegenerator"
+	"github.com/konveyor/move2kube-wasm/transformer/dockerfilegenerator/windows"
+)
+
+var (
+	// languageTransformers are the Dockerfile generators for the languages other than Java
+	languageTransformers = []ContextTransformer{
+		new(dockerfilegenerator.NodejsDockerfileGenerator),
+		new(dockerfilegenerator.GolangDockerfileGenerator),
+		new(dockerfilegenerator.PHPDockerfileGenerator),
+		new(dockerfilegenerator.PythonDockerfileGenerator),
+		new(dockerfilegenerator.RubyDockerfileGenerator),
+		new(dockerfilegenerator.RustDockerfileGenerator),
+		new(dockerfilegenerator.DotNetCoreDockerfileGenerator),
+		new(windows.WinConsoleAppDockerfileGenerator),
+		new(windows.WinSilverLightWebAppDockerfileGenerator),
+		new(windows.WinWebAppDockerfileGenerator),
+	}
+	excludedLanguageTransformerClasses = []string{}
+)
@@ -0,0 +1,147 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
+	"github.com/konveyor/move2kube-wasm/qaengine"
+	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
+	provenancetypes "github.com/konveyor/move2kube-wasm/types/provenance"
+	qatypes "github.com/konveyor/move2kube-wasm/types/qaengine"
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// CheckpointFileVersion is the version of the checkpoint file that is generated.
+	CheckpointFileVersion = "1.0.0"
+	// CheckpointFileName is the default file name used to save the checkpoint.
+	CheckpointFileName = "m2k-checkpoint.yaml"
+)
+
+// Checkpoint is the state of the transformation after an iteration has completed
+type Checkpoint struct {
+	Version               string                          `yaml:"version"`
+	PlanHash              string                          `yaml:"planHash"`
+	Transformers          []string                        `yaml:"transformers"`
+	Iteration             int                             `yaml:"iteration"`
+	AllArtifacts          []transformertypes.Artifact     `yaml:"allArtifacts"`
+	NewArtifactsToProcess []transformertypes.Artifact     `yaml:"newArtifactsToProcess"`
+	PathMappings          []transformertypes.PathMapping  `yaml:"pathMappings"`
+	QASolutions           []qatypes.Problem               `yaml:"qaSolutions"`
+	Graph                 *graphtypes.Graph               `yaml:"graph"`
+	Manifest              *provenancetypes.OutputManifest `yaml:"manifest"`
+}
+
+// CheckpointOptions configures the checkpoints of a transformation
+type CheckpointOptions struct {
+	// Path is where the checkpoint is written after every iteration. Checkpoints are disabled if it is empty.
+	Path string
+	// PlanHash identifies the plan that is being transformed
+	PlanHash string
+	// Resume is the checkpoint to continue the transformation from
+	Resume *Checkpoint
+}
+
+// ReadCheckpoint reads the checkpoint at the path and checks that it can be used to resume a transformation of the plan
+func ReadCheckpoint(path, planHash string) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{}
+	if err := common.ReadYaml(path, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to read the checkpoint file at path %s . Error: %w", path, err)
+	}
+	if checkpoint.Version != CheckpointFileVersion {
+		return nil, fmt.Errorf("the checkpoint file has version '%s' but only version '%s' is supported", checkpoint.Version, CheckpointFileVersion)
+	}
+	if checkpoint.PlanHash != planHash {
+		return nil, fmt.Errorf("the plan has changed since the checkpoint at path %s was created. Please transform from scratch", path)
+	}
+	return checkpoint, nil
+}
+
+// getTransformerNames returns the sorted names of the initialized transformers
+func getTransformerNames() []string {
+	names := []string{}
+	for _, t := range registry.transformers {
+		tConfig, _ := t.GetConfig()
+		names = append(names, tConfig.Name)
+	}
+	for _, t := range registry.invokedByDefaultTransformers {
+		tConfig, _ := t.GetConfig()
+		if !common.IsPresent(names, tConfig.Name) {
+			names = append(names, tConfig.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkCheckpointTransformers returns an error if the checkpoint was created using a different set of transformers
+func checkCheckpointTransformers(checkpoint *Checkpoint) error {
+	names := getTransformerNames()
+	if !reflect.DeepEqual(names, checkpoint.Transformers) {
+		return fmt.Errorf("the transformers have changed since the checkpoint was created. Expected %+v Actual %+v . Please transform from scratch", checkpoint.Transformers, names)
+	}
+	return nil
+}
+
+// restorePathMappings drops the path mappings whose source no longer exists.
+// Their output was already written to the output directory before the checkpoint was created.
+func restorePathMappings(pathMappings []transformertypes.PathMapping) []transformertypes.PathMapping {
+	restoredPathMappings := []transformertypes.PathMapping{}
+	for _, pathMapping := range pathMappings {
+		if filepath.IsAbs(pathMapping.SrcPath) && !strings.EqualFold(string(pathMapping.Type), string(transformertypes.DeletePathMappingType)) {
+			if _, err := vfs.Stat(pathMapping.SrcPath); os.IsNotExist(err) {
+				logrus.Debugf("the source of the path mapping %+v no longer exists. Using the existing output.", pathMapping)
+				continue
+			}
+		}
+		restoredPathMappings = append(restoredPathMappings, pathMapping)
+	}
+	return restoredPathMappings
+}
+
+// writeCheckpoint writes the state of the transformation after an iteration has completed
+func writeCheckpoint(options CheckpointOptions, iteration int, allArtifacts, newArtifactsToProcess []transformertypes.Artifact, pathMappings []transformertypes.PathMapping, graph *graphtypes.Graph, manifest *provenancetypes.OutputManifest) {
+	if options.Path == "" {
+		return
+	}
+	checkpoint := Checkpoint{
+		Version:               CheckpointFileVersion,
+		PlanHash:              options.PlanHash,
+		Transformers:          getTransformerNames(),
+		Iteration:             iteration,
+		AllArtifacts:          allArtifacts,
+		NewArtifactsToProcess: newArtifactsToProcess,
+		PathMappings:          pathMappings,
+		QASolutions:           qaengine.GetSolutions(),
+		Graph:                 graph,
+		Manifest:              manifest,
+	}
+	if err := common.WriteYaml(options.Path, checkpoint); err != nil {
+		logrus.Errorf("failed to write the checkpoint to a file at path %s . Error: %q", options.Path, err)
+		return
+	}
+	logrus.Debugf("wrote the checkpoint for iteration %d to the file at path %s", iteration, options.Path)
+}
@@ -0,0 +1,276 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
+	"github.com/konveyor/move2kube-wasm/qaengine"
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+	"github.com/sirupsen/logrus"
+)
+
+// pathMappingConflict describes multiple path mappings that write different files to the same destination
+type pathMappingConflict struct {
+	DestPath     string
+	Transformers []string
+	Policy       transformertypes.ConflictPolicy
+	Resolution   string
+}
+
+// getGlobalConflictPolicy returns the policy used for transformers that do not specify one in their transformer.yaml
+func getGlobalConflictPolicy(ctx context.Context) transformertypes.ConflictPolicy {
+	options := []string{}
+	for _, policy := range transformertypes.ConflictPolicies {
+		options = append(options, string(policy))
+	}
+	return transformertypes.ConflictPolicy(qaengine.FetchSelectAnswer(
+		ctx,
+		common.ConfigTransformersConflictPolicyKey,
+		"Select the policy to use when multiple transformers write to the same output file",
+		[]string{"Transformers can override this using spec.conflictPolicy in their transformer.yaml"},
+		string(transformertypes.LastWinsConflictPolicy),
+		options,
+		nil,
+	))
+}
+
+// getConflictPolicy returns the conflict policy of the transformer that created the path mapping
+func getConflictPolicy(pathMapping transformertypes.PathMapping, globalPolicy transformertypes.ConflictPolicy) transformertypes.ConflictPolicy {
+	t, ok := registry.transformerMap[pathMapping.TransformerName]
+	if !ok {
+		return globalPolicy
+	}
+	tConfig, _ := t.GetConfig()
+	if tConfig.Spec.ConflictPolicy == "" {
+		return globalPolicy
+	}
+	for _, policy := range transformertypes.ConflictPolicies {
+		if strings.EqualFold(string(policy), string(tConfig.Spec.ConflictPolicy)) {
+			return policy
+		}
+	}
+	logrus.Warnf("the transformer '%s' has an invalid conflict policy '%s' . Valid policies are %+v . Using '%s' instead.", tConfig.Name, tConfig.Spec.ConflictPolicy, transformertypes.ConflictPolicies, globalPolicy)
+	return globalPolicy
+}
+
+// isFileWritingPathMapping returns true for path mappings that write the file at the source path to the destination path.
+// Directories are merged into each other, so they never collide.
+func isFileWritingPathMapping(pathMapping transformertypes.PathMapping) bool {
+	switch strings.ToLower(string(pathMapping.Type)) {
+	case "", strings.ToLower(string(transformertypes.DefaultPathMappingType)),
+		strings.ToLower(string(transformertypes.TemplatePathMappingType)),
+		strings.ToLower(string(transformertypes.SpecialTemplatePathMappingType)):
+	default:
+		return false
+	}
+	fi, err := vfs.Stat(pathMapping.SrcPath)
+	return err == nil && !fi.IsDir()
+}
+
+// getPathMappingType returns the lower case type of the path mapping, with the empty type treated as the default type
+func getPathMappingType(pathMapping transformertypes.PathMapping) string {
+	if pathMapping.Type == "" {
+		return strings.ToLower(string(transformertypes.DefaultPathMappingType))
+	}
+	return strings.ToLower(string(pathMapping.Type))
+}
+
+// isDuplicatePathMapping returns true if both path mappings write the same content, so they do not conflict.
+// Templates are the same if they use the same template with the same config, other files if they have the same contents.
+func isDuplicatePathMapping(pathMapping1, pathMapping2 transformertypes.PathMapping) bool {
+	pathMappingType := getPathMappingType(pathMapping1)
+	if pathMappingType != getPathMappingType(pathMapping2) {
+		return false
+	}
+	if pathMappingType != strings.ToLower(string(transformertypes.DefaultPathMappingType)) {
+		return pathMapping1.SrcPath == pathMapping2.SrcPath && reflect.DeepEqual(pathMapping1.TemplateConfig, pathMapping2.TemplateConfig)
+	}
+	if pathMapping1.SrcPath == pathMapping2.SrcPath {
+		return true
+	}
+	content1, err := vfs.ReadFile(pathMapping1.SrcPath)
+	if err != nil {
+		return false
+	}
+	content2, err := vfs.ReadFile(pathMapping2.SrcPath)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(content1, content2)
+}
+
+// resolvePathMappingConflicts detects path mappings that write different files to the same destination
+// and resolves them using the conflict policies. The order of the remaining path mappings is preserved.
+func resolvePathMappingConflicts(pathMappings []transformertypes.PathMapping, globalPolicy transformertypes.ConflictPolicy) ([]transformertypes.PathMapping, []pathMappingConflict, error) {
+	destPaths := map[string]bool{}
+	dests := []string{}
+	collisions := map[string][]int{}
+	for i, pathMapping := range pathMappings {
+		destPath := filepath.Clean(pathMapping.DestPath)
+		destPaths[destPath] = true
+		if !isFileWritingPathMapping(pathMapping) {
+			continue
+		}
+		duplicate := false
+		for _, j := range collisions[destPath] {
+			if isDuplicatePathMapping(pathMappings[j], pathMapping) {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+		if _, ok := collisions[destPath]; !ok {
+			dests = append(dests, destPath)
+		}
+		collisions[destPath] = append(collisions[destPath], i)
+	}
+	dropped := map[int]bool{}
+	replaced := map[int]transformertypes.PathMapping{}
+	conflicts := []pathMappingConflict{}
+	errs := []string{}
+	for _, destPath := range dests {
+		idxs := collisions[destPath]
+		if len(idxs) < 2 {
+			continue
+		}
+		first, last := idxs[0], idxs[len(idxs)-1]
+		conflict := pathMappingConflict{DestPath: destPath, Policy: getConflictPolicy(pathMappings[last], globalPolicy)}
+		for _, idx := range idxs {
+			conflict.Transformers = append(conflict.Transformers, pathMappings[idx].TransformerName)
+		}
+		switch conflict.Policy {
+		case transformertypes.ErrorConflictPolicy:
+			conflict.Resolution = "failed"
+			errs = append(errs, fmt.Sprintf("'%s' is written by the transformers %+v", destPath, conflict.Transformers))
+		case transformertypes.FirstWinsConflictPolicy:
+			for _, idx := range idxs[1:] {
+				dropped[idx] = true
+			}
+			conflict.Resolution = fmt.Sprintf("kept the file from the transformer '%s'", pathMappings[first].TransformerName)
+		case transformertypes.RenameConflictPolicy:
+			renamed := []string{}
+			for _, idx := range idxs[1:] {
+				pathMapping := pathMappings[idx]
+				pathMapping.DestPath = getUniqueDestPath(destPath, destPaths)
+				destPaths[pathMapping.DestPath] = true
+				replaced[idx] = pathMapping
+				renamed = append(renamed, pathMapping.DestPath)
+			}
+			conflict.Resolution = fmt.Sprintf("renamed the later files to %+v", renamed)
+		case transformertypes.MergeYamlConflictPolicy:
+			mergedPathMapping, err := mergeYamlPathMappings(pathMappings, idxs)
+			if err != nil {
+				logrus.Warnf("failed to merge the yaml files written to '%s' . Falling back to %s . Error: %q", destPath, transformertypes.LastWinsConflictPolicy, err)
+				for _, idx := range idxs[:len(idxs)-1] {
+					dropped[idx] = true
+				}
+				conflict.Resolution = fmt.Sprintf("kept the file from the transformer '%s'", pathMappings[last].TransformerName)
+				break
+			}
+			replaced[first] = mergedPathMapping
+			for _, idx := range idxs[1:] {
+				dropped[idx] = true
+			}
+			conflict.Resolution = "merged the yaml documents"
+		default:
+			for _, idx := range idxs[:len(idxs)-1] {
+				dropped[idx] = true
+			}
+			conflict.Resolution = fmt.Sprintf("kept the file from the transformer '%s'", pathMappings[last].TransformerName)
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	if len(errs) != 0 {
+		return pathMappings, conflicts, fmt.Errorf("found conflicting path mappings: %s", strings.Join(errs, ", "))
+	}
+	resolvedPathMappings := []transformertypes.PathMapping{}
+	for i, pathMapping := range pathMappings {
+		if dropped[i] {
+			continue
+		}
+		if replacedPathMapping, ok := replaced[i]; ok {
+			pathMapping = replacedPathMapping
+		}
+		resolvedPathMappings = append(resolvedPathMappings, pathMapping)
+	}
+	return resolvedPathMappings, conflicts, nil
+}
+
+// getUniqueDestPath adds a numeric suffix to the file name until it does not collide with any of the existing paths
+func getUniqueDestPath(destPath string, existingPaths map[string]bool) string {
+	ext := filepath.Ext(destPath)
+	stem := strings.TrimSuffix(destPath, ext)
+	for i := 1; ; i++ {
+		newDestPath := fmt.Sprintf("%s-%d%s", stem, i, ext)
+		if !existingPaths[newDestPath] {
+			return newDestPath
+		}
+	}
+}
+
+// mergeYamlPathMappings concatenates the yaml files of the path mappings into a single multi document yaml file
+func mergeYamlPathMappings(pathMappings []transformertypes.PathMapping, idxs []int) (transformertypes.PathMapping, error) {
+	contents := []string{}
+	for _, idx := range idxs {
+		pathMapping := pathMappings[idx]
+		if pathMapping.Type != "" && !strings.EqualFold(string(pathMapping.Type), string(transformertypes.DefaultPathMappingType)) {
+			return pathMapping, fmt.Errorf("the path mapping of type '%s' from the transformer '%s' is not a plain copy", pathMapping.Type, pathMapping.TransformerName)
+		}
+		ext := strings.ToLower(filepath.Ext(pathMapping.SrcPath))
+		if ext != ".yaml" && ext != ".yml" {
+			return pathMapping, fmt.Errorf("the file '%s' from the transformer '%s' is not a yaml file", pathMapping.SrcPath, pathMapping.TransformerName)
+		}
+		content, err := vfs.ReadFile(pathMapping.SrcPath)
+		if err != nil {
+			return pathMapping, fmt.Errorf("failed to read the file at path %s . Error: %w", pathMapping.SrcPath, err)
+		}
+		contents = append(contents, strings.TrimSuffix(strings.TrimPrefix(string(content), "---\n"), "\n"))
+	}
+	// the conflicts are resolved again in every iteration, so the merged file for a destination is overwritten instead of creating a new one
+	mergedDir := filepath.Join(common.TempPath, "merged-yaml", common.GetSHA256Hash(filepath.Clean(pathMappings[idxs[0]].DestPath)))
+	if err := vfs.MkdirAll(mergedDir, common.DefaultDirectoryPermission); err != nil {
+		return pathMappings[idxs[0]], fmt.Errorf("failed to create a temporary directory for the merged yaml. Error: %w", err)
+	}
+	mergedPath := filepath.Join(mergedDir, filepath.Base(pathMappings[idxs[0]].DestPath))
+	if err := vfs.WriteFile(mergedPath, []byte(strings.Join(contents, "\n---\n")+"\n"), common.DefaultFilePermission); err != nil {
+		return pathMappings[idxs[0]], fmt.Errorf("failed to write the merged yaml to a file at path %s . Error: %w", mergedPath, err)
+	}
+	mergedPathMapping := pathMappings[idxs[0]]
+	mergedPathMapping.SrcPath = mergedPath
+	return mergedPathMapping, nil
+}
+
+// logPathMappingConflicts prints a summary of all the collisions found during the transformation
+func logPathMappingConflicts(conflicts []pathMappingConflict) {
+	if len(conflicts) == 0 {
+		return
+	}
+	logrus.Warnf("Found %d output paths that are written by multiple transformers:", len(conflicts))
+	for _, conflict := range conflicts {
+		logrus.Warnf("  %s : transformers %+v , policy '%s' , %s", conflict.DestPath, conflict.Transformers, conflict.Policy, conflict.Resolution)
+	}
+}
@@ -17,6 +17,7 @@
 package containerimage
 
 import (
+	"context"
 	"path/filepath"
 
 	"github.com/konveyor/move2kube-wasm/common"
@@ -32,7 +33,7 @@ const (
 	defaultDockerPushScriptsOutputPath = common.ScriptsDir
 )
 
-// ContainerImagesPushScript implements Transformer interface
+// ContainerImagesPushScript implements the ContextTransformer interface
 type ContainerImagesPushScript struct {
 	Config                          transformertypes.Transformer
 	Env                             *environment.Environment
@@ -52,7 +53,7 @@ type ImagePushTemplateConfig struct {
 }
 
 // Init Initializes the transformer
-func (t *ContainerImagesPushScript) Init(tc transformertypes.Transformer, env *environment.Environment) (err error) {
+func (t *ContainerImagesPushScript) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) (err error) {
 	t.Config = tc
 	t.Env = env
 	t.DockerfileImagePushScriptConfig = &DockerfileImagePushScriptConfig{}
@@ -72,15 +73,18 @@ func (t *ContainerImagesPushScript) GetConfig() (transformertypes.Transformer, *
 }
 
 // DirectoryDetect runs detect in each sub directory
-func (t *ContainerImagesPushScript) DirectoryDetect(dir string) (services map[string][]transformertypes.Artifact, err error) {
+func (t *ContainerImagesPushScript) DirectoryDetect(ctx context.Context, dir string) (services map[string][]transformertypes.Artifact, err error) {
 	return nil, nil
 }
 
 // Transform transforms the artifacts
-func (t *ContainerImagesPushScript) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *ContainerImagesPushScript) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	ipt := ImagePushTemplateConfig{}
 	for _, a := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		if a.Type != artifacts.NewImagesArtifactType {
 			continue
 		}
@@ -94,8 +98,8 @@ func (t *ContainerImagesPushScript) Transform(newArtifacts []transformertypes.Ar
 	if len(ipt.Images) == 0 {
 		return nil, nil, nil
 	}
-	ipt.RegistryURL = commonqa.ImageRegistry()
-	ipt.RegistryNamespace = commonqa.ImageRegistryNamespace()
+	ipt.RegistryURL = commonqa.ImageRegistry(ctx)
+	ipt.RegistryNamespace = commonqa.ImageRegistryNamespace(ctx)
 	pathMappings = append(pathMappings, transformertypes.PathMapping{
 		Type:           transformertypes.TemplatePathMappingType,
 		SrcPath:        filepath.Join(t.Env.Context, t.Config.Spec.TemplatesDir),
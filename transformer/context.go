@@ -0,0 +1,185 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/konveyor/move2kube-wasm/environment"
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+)
+
+// ContextTransformer is a transformer that can be cancelled using a context
+type ContextTransformer interface {
+	Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) (err error)
+	// GetConfig returns the transformer config
+	GetConfig() (transformertypes.Transformer, *environment.Environment)
+	DirectoryDetect(ctx context.Context, dir string) (services map[string][]transformertypes.Artifact, err error)
+	Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error)
+}
+
+// TransformerTimeoutError is returned when a transformer takes longer than the timeout in its transformer.yaml
+type TransformerTimeoutError struct {
+	Transformer string
+	Phase       string
+	Timeout     time.Duration
+}
+
+func (e *TransformerTimeoutError) Error() string {
+	return fmt.Sprintf("the transformer '%s' timed out after %s during %s", e.Transformer, e.Timeout, e.Phase)
+}
+
+// errTransformerAbandoned is returned for the calls to a transformer after a call to it was abandoned
+var errTransformerAbandoned = errors.New("an earlier call to the transformer timed out or was cancelled while it was still running, so it cannot be used for the rest of the run")
+
+// transformerAdapter makes a Transformer that does not support contexts usable as a ContextTransformer.
+// When the context is done the call returns immediately, but the underlying transformer keeps running in the background
+// since it cannot be stopped. The transformer is then abandoned, all the later calls fail without calling it,
+// so that it never runs concurrently with itself and its environment is not used while it is still writing to it.
+// Until it returns, the abandoned call can still write to the temporary directories of the transformer.
+// Transformers that ask questions have to be ContextTransformers, since the problems are recorded using the context.
+type transformerAdapter struct {
+	transformer Transformer
+	mutex       sync.Mutex
+	abandoned   bool
+}
+
+// NewContextTransformer returns a ContextTransformer that runs the calls to the transformer until the context is done
+func NewContextTransformer(t Transformer) ContextTransformer {
+	return &transformerAdapter{transformer: t}
+}
+
+// getContextTransformer returns the transformer object created using reflection as a ContextTransformer
+func getContextTransformer(obj interface{}) (ContextTransformer, error) {
+	switch t := obj.(type) {
+	case ContextTransformer:
+		return t, nil
+	case Transformer:
+		return NewContextTransformer(t), nil
+	}
+	return nil, fmt.Errorf("the type %T does not implement the Transformer or ContextTransformer interfaces", obj)
+}
+
+// isTransformerAbandoned returns true if a call to the transformer was abandoned while the transformer was still running
+func isTransformerAbandoned(t ContextTransformer) bool {
+	adapter, ok := t.(*transformerAdapter)
+	if !ok {
+		return false
+	}
+	adapter.mutex.Lock()
+	defer adapter.mutex.Unlock()
+	return adapter.abandoned
+}
+
+func (t *transformerAdapter) abandon() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.abandoned = true
+}
+
+// runAdapterCall runs a call to the transformer until the context is done, unless the transformer was abandoned by an earlier call
+func runAdapterCall[T any](ctx context.Context, t *transformerAdapter, f func() (T, error)) (T, error) {
+	if isTransformerAbandoned(t) {
+		var zero T
+		return zero, errTransformerAbandoned
+	}
+	return runWithContext(ctx, f, t.abandon)
+}
+
+// Init initializes the transformer
+func (t *transformerAdapter) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) error {
+	_, err := runAdapterCall(ctx, t, func() (struct{}, error) {
+		return struct{}{}, t.transformer.Init(tc, env)
+	})
+	return err
+}
+
+// GetConfig returns the transformer config
+func (t *transformerAdapter) GetConfig() (transformertypes.Transformer, *environment.Environment) {
+	return t.transformer.GetConfig()
+}
+
+// DirectoryDetect runs detect in the directory
+func (t *transformerAdapter) DirectoryDetect(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
+	return runAdapterCall(ctx, t, func() (map[string][]transformertypes.Artifact, error) {
+		return t.transformer.DirectoryDetect(dir)
+	})
+}
+
+// Transform transforms the artifacts
+func (t *transformerAdapter) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+	type transformResult struct {
+		pathMappings []transformertypes.PathMapping
+		artifacts    []transformertypes.Artifact
+	}
+	result, err := runAdapterCall(ctx, t, func() (transformResult, error) {
+		pathMappings, artifacts, err := t.transformer.Transform(newArtifacts, alreadySeenArtifacts)
+		return transformResult{pathMappings: pathMappings, artifacts: artifacts}, err
+	})
+	return result.pathMappings, result.artifacts, err
+}
+
+// runWithContext returns the result of f, or the error of the context if it is done before f returns.
+// In the latter case onAbandon is called, since f keeps running in the background.
+func runWithContext[T any](ctx context.Context, f func() (T, error), onAbandon func()) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var r result
+		r.value, r.err = f()
+		done <- r
+	}()
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		select {
+		case r := <-done:
+			return r.value, r.err
+		default:
+		}
+		onAbandon()
+		return zero, ctx.Err()
+	}
+}
+
+// withTransformerTimeout returns a context that times out after the timeout in the transformer config, if there is one
+func withTransformerTimeout(ctx context.Context, tc transformertypes.Transformer) (context.Context, context.CancelFunc) {
+	if tc.Spec.TimeoutDuration <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, tc.Spec.TimeoutDuration)
+}
+
+// getTransformerTimeoutError reports the transformer that timed out. Other errors, including the cancellation of the parent context, are returned as is.
+func getTransformerTimeoutError(parent context.Context, err error, tc transformertypes.Transformer, phase string) error {
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) || parent.Err() != nil {
+		return err
+	}
+	return &TransformerTimeoutError{Transformer: tc.Name, Phase: phase, Timeout: tc.Spec.TimeoutDuration}
+}
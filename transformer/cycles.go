@@ -0,0 +1,153 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+)
+
+const (
+	// maxRepeatedIterations is the number of consecutive iterations that only create equivalent artifacts before the transformation is aborted
+	maxRepeatedIterations = 2
+)
+
+// artifactCycleDetector remembers the fingerprints of the artifacts seen in earlier iterations
+type artifactCycleDetector struct {
+	graph *graphtypes.Graph
+	// seen contains the iteration in which each fingerprint was first seen
+	seen map[string]int
+}
+
+// repeatedArtifact is an artifact that is equivalent to one created in an earlier iteration
+type repeatedArtifact struct {
+	Name           string
+	Type           transformertypes.ArtifactType
+	Transformer    string
+	FirstIteration int
+}
+
+func newArtifactCycleDetector(graph *graphtypes.Graph) *artifactCycleDetector {
+	return &artifactCycleDetector{graph: graph, seen: map[string]int{}}
+}
+
+// add records the artifacts as seen in the iteration
+func (d *artifactCycleDetector) add(artifacts []transformertypes.Artifact, iteration int) {
+	for _, artifact := range artifacts {
+		fingerprint := getArtifactFingerprint(artifact)
+		if _, ok := d.seen[fingerprint]; !ok {
+			d.seen[fingerprint] = iteration
+		}
+	}
+}
+
+// check returns the repeated artifacts if every new artifact is equivalent to one seen in an earlier iteration.
+// In that case the next iterations would keep processing the same artifacts forever.
+func (d *artifactCycleDetector) check(newArtifacts []transformertypes.Artifact) []repeatedArtifact {
+	if len(newArtifacts) == 0 {
+		return nil
+	}
+	repeated := []repeatedArtifact{}
+	for _, artifact := range newArtifacts {
+		firstIteration, ok := d.seen[getArtifactFingerprint(artifact)]
+		if !ok {
+			return nil
+		}
+		repeated = append(repeated, repeatedArtifact{
+			Name:           artifact.Name,
+			Type:           artifact.Type,
+			Transformer:    d.getProducer(artifact),
+			FirstIteration: firstIteration,
+		})
+	}
+	return repeated
+}
+
+// getProducer returns the name of the transformer that created the artifact using the graph
+func (d *artifactCycleDetector) getProducer(artifact transformertypes.Artifact) string {
+	vertexId, ok := artifact.Configs[graphtypes.GraphSourceVertexKey].(int)
+	if !ok {
+		return "unknown"
+	}
+	if processVertexId, ok := artifact.Configs[graphtypes.GraphProcessVertexKey].(int); ok {
+		vertexId = processVertexId
+	}
+	vertex, ok := d.graph.Vertices[vertexId]
+	if !ok {
+		return "unknown"
+	}
+	if name, ok := vertex.Data[graphtypes.GraphTransformerNameKey].(string); ok {
+		return name
+	}
+	return vertex.Name
+}
+
+// getArtifactFingerprint returns a hash of the type, name, paths and configs of the artifact.
+// The configs used to track the artifact in the graph are ignored.
+func getArtifactFingerprint(artifact transformertypes.Artifact) string {
+	paths := []string{}
+	for pathType, pathList := range artifact.Paths {
+		for _, path := range pathList {
+			paths = append(paths, string(pathType)+"="+path)
+		}
+	}
+	sort.Strings(paths)
+	configs := map[transformertypes.ConfigType]interface{}{}
+	for configType, config := range artifact.Configs {
+		if configType == graphtypes.GraphSourceVertexKey || configType == graphtypes.GraphProcessVertexKey {
+			continue
+		}
+		configs[configType] = config
+	}
+	configsBytes, err := json.Marshal(configs)
+	if err != nil {
+		configsBytes = []byte(fmt.Sprintf("%+v", configs))
+	}
+	h := sha256.New()
+	h.Write([]byte(string(artifact.Type) + "\n" + artifact.Name + "\n" + strings.Join(paths, "\n") + "\n"))
+	h.Write(configsBytes)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// getArtifactCycleError describes the transformers and artifact types involved in the cycle
+func getArtifactCycleError(repeated []repeatedArtifact, iteration int) error {
+	transformerNames := []string{}
+	artifactTypes := []string{}
+	details := []string{}
+	for _, r := range repeated {
+		if !common.IsPresent(transformerNames, r.Transformer) {
+			transformerNames = append(transformerNames, r.Transformer)
+		}
+		if !common.IsPresent(artifactTypes, string(r.Type)) {
+			artifactTypes = append(artifactTypes, string(r.Type))
+		}
+		details = append(details, fmt.Sprintf("'%s' of type '%s' created by '%s' (first seen in iteration %d)", r.Name, r.Type, r.Transformer, r.FirstIteration))
+	}
+	sort.Strings(transformerNames)
+	sort.Strings(artifactTypes)
+	return fmt.Errorf(
+		"detected a cycle in iteration %d: the transformers %+v keep creating artifacts of the types %+v that are equivalent to artifacts from earlier iterations. Repeated artifacts: %s",
+		iteration, transformerNames, artifactTypes, strings.Join(details, ", "),
+	)
+}
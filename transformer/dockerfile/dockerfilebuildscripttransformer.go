@@ -17,6 +17,7 @@
 package dockerfile
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 
@@ -34,7 +35,7 @@ const (
 	defaultDockerBuildScriptsOutputPath = common.ScriptsDir
 )
 
-// DockerfileImageBuildScript implements Transformer interface
+// DockerfileImageBuildScript implements the ContextTransformer interface
 type DockerfileImageBuildScript struct {
 	Config                           transformertypes.Transformer
 	Env                              *environment.Environment
@@ -63,7 +64,7 @@ type DockerfileImageBuildConfig struct {
 }
 
 // Init Initializes the transformer
-func (t *DockerfileImageBuildScript) Init(tc transformertypes.Transformer, env *environment.Environment) (err error) {
+func (t *DockerfileImageBuildScript) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) (err error) {
 	t.Config = tc
 	t.Env = env
 	t.DockerfileImageBuildScriptConfig = &DockerfileImageBuildScriptConfig{}
@@ -83,17 +84,20 @@ func (t *DockerfileImageBuildScript) GetConfig() (transformertypes.Transformer,
 }
 
 // DirectoryDetect runs detect in each sub directory
-func (t *DockerfileImageBuildScript) DirectoryDetect(dir string) (namedServices map[string][]transformertypes.Artifact, err error) {
+func (t *DockerfileImageBuildScript) DirectoryDetect(ctx context.Context, dir string) (namedServices map[string][]transformertypes.Artifact, err error) {
 	return nil, nil
 }
 
 // Transform transforms the artifacts
-func (t *DockerfileImageBuildScript) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *DockerfileImageBuildScript) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	dockerfilesImageBuildConfig := []DockerfileImageBuildConfig{}
 	createdArtifacts := []transformertypes.Artifact{}
 	processedImages := map[string]bool{}
 	for _, artifact := range append(alreadySeenArtifacts, newArtifacts...) {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		if artifact.Type != artifacts.DockerfileArtifactType {
 			continue
 		}
@@ -174,8 +178,8 @@ func (t *DockerfileImageBuildScript) Transform(newArtifacts []transformertypes.A
 	containerImageBuildBatScriptPaths := []string{}
 	templateData := DockerfileImageBuildScriptTemplateConfig{
 		RelParentOfSourceDir: filepath.Join(relSourceDir, ".."),
-		RegistryURL:          commonqa.ImageRegistry(),
-		RegistryNamespace:    commonqa.ImageRegistryNamespace(),
+		RegistryURL:          commonqa.ImageRegistry(ctx),
+		RegistryNamespace:    commonqa.ImageRegistryNamespace(ctx),
 		DockerfilesConfig:    dockerfilesImageBuildConfig,
 	}
 	pathMappings = append(pathMappings, transformertypes.PathMapping{
@@ -106,7 +106,7 @@ package dockerfile
 //			logrus.Debugf("unable to load config for Transformer into %T : %s", sImageName, err)
 //		}
 //		if sImageName.ImageName == "" {
-//			sImageName.ImageName = common.MakeStringContainerImageNameCompliant(sConfig.ServiceName)
+//			sImageName.ImageName = common.MakeStringContainerImageNameCompliant(sConfig.GetImageName())
 //		}
 //		pathMappings = append(pathMappings, transformertypes.PathMapping{
 //			Type:     transformertypes.SourcePathMappingType,
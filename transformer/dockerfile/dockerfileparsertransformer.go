@@ -92,7 +92,7 @@ package dockerfile
 //			if contextPaths, ok := newArtifact.Paths[artifacts.DockerfileContextPathType]; ok && len(contextPaths) > 0 {
 //				contextPath = contextPaths[0]
 //			}
-//			createdArtifact, err := t.getIRFromDockerfile(paths[0], contextPath, imageName.ImageName, serviceConfig.ServiceName, serviceFsPath, ir)
+//			createdArtifact, err := t.getIRFromDockerfile(paths[0], contextPath, imageName.ImageName, serviceConfig, serviceFsPath, ir)
 //			if err != nil {
 //				logrus.Errorf("failed to convert the Dockerfile to IR. Error: %q", err)
 //				continue
@@ -103,7 +103,7 @@ package dockerfile
 //	return nil, createdArtifacts, nil
 //}
 //
-//func (t *DockerfileParser) getIRFromDockerfile(dockerfilepath, contextPath, imageName, serviceName, serviceFsPath string, ir irtypes.IR) (transformertypes.Artifact, error) {
+//func (t *DockerfileParser) getIRFromDockerfile(dockerfilepath, contextPath, imageName string, serviceConfig artifacts.ServiceConfig, serviceFsPath string, ir irtypes.IR) (transformertypes.Artifact, error) {
 //	df, err := t.getDockerFileAST(dockerfilepath)
 //	if err != nil {
 //		logrus.Errorf("Unable to parse dockerfile : %s", err)
@@ -152,9 +152,11 @@ package dockerfile
 //		container.AddExposedPort(common.DefaultServicePort)
 //	}
 //	ir.AddContainer(imageName, container)
-//	serviceContainer := core.Container{Name: serviceName}
+//	// Services merged into one pod share the service name and get a container each.
+//	// The IR services are merged into one multi-container service when the IR artifacts are merged.
+//	serviceContainer := core.Container{Name: serviceConfig.GetContainerName()}
 //	serviceContainer.Image = imageName
-//	irService := irtypes.NewServiceWithName(serviceName)
+//	irService := irtypes.NewServiceWithName(serviceConfig.ServiceName)
 //	serviceContainerPorts := []core.ContainerPort{}
 //	for _, port := range container.ExposedPorts {
 //		// Add the port to the k8s pod.
@@ -17,6 +17,7 @@
 package dotnet
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"github.com/konveyor/move2kube-wasm/common"
@@ -26,6 +27,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/types/source/dotnet"
 )
 
@@ -41,7 +43,7 @@ const (
 )
 
 // AskUserForDockerfileType asks the user what type of Dockerfiles to generate.
-func AskUserForDockerfileType(rootProjectName string) (buildOption, error) {
+func AskUserForDockerfileType(ctx context.Context, rootProjectName string) (buildOption, error) {
 	quesId := common.JoinQASubKeys(common.ConfigServicesKey, `"`+rootProjectName+`"`, "dockerfileType")
 	desc := fmt.Sprintf("What type of Dockerfiles should be generated for the service '%s'?", rootProjectName)
 	options := []string{
@@ -55,7 +57,7 @@ func AskUserForDockerfileType(rootProjectName string) (buildOption, error) {
 		fmt.Sprintf("[%s] Put the build stage in a separate Dockerfile and create a base image.", BUILD_IN_BASE_IMAGE),
 		fmt.Sprintf("[%s] Put the build stage in every Dockerfile to make it self contained. (Warning: This may cause one build per Dockerfile.)", BUILD_IN_EVERY_IMAGE),
 	}
-	selectedBuildOption := buildOption(qaengine.FetchSelectAnswer(quesId, desc, hints, string(def), options, nil))
+	selectedBuildOption := buildOption(qaengine.FetchSelectAnswer(ctx, quesId, desc, hints, string(def), options, nil))
 	switch selectedBuildOption {
 	case NO_BUILD_STAGE, BUILD_IN_BASE_IMAGE, BUILD_IN_EVERY_IMAGE:
 		return selectedBuildOption, nil
@@ -66,7 +68,7 @@ func AskUserForDockerfileType(rootProjectName string) (buildOption, error) {
 // GetCSProjPathsFromSlnFile parses the solution file for cs project file paths.
 // If "allPaths" is true then every path we find will be returned (not just c sharp project files).
 func GetCSProjPathsFromSlnFile(inputPath string, allPaths bool) ([]string, error) {
-	slnBytes, err := os.ReadFile(inputPath)
+	slnBytes, err := vfs.ReadFile(inputPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open the solution file at path %s . Error: %q", inputPath, err)
 	}
@@ -92,7 +94,7 @@ func GetCSProjPathsFromSlnFile(inputPath string, allPaths bool) ([]string, error
 // ParseCSProj parses a c sharp project file
 func ParseCSProj(path string) (dotnet.CSProj, error) {
 	configuration := dotnet.CSProj{}
-	csProjBytes, err := os.ReadFile(path)
+	csProjBytes, err := vfs.ReadFile(path)
 	if err != nil {
 		return configuration, fmt.Errorf("failed to read the c sharp project file at path %s . Error: %q", path, err)
 	}
@@ -17,6 +17,7 @@
 package dockerfilegenerator
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"github.com/konveyor/move2kube-wasm/qaengine"
@@ -106,7 +107,7 @@ type LaunchProfile struct {
 // Transformer
 // -----------------------------------------------------------------------------------
 
-// DotNetCoreDockerfileGenerator implements the Transformer interface
+// DotNetCoreDockerfileGenerator implements the ContextTransformer interface
 type DotNetCoreDockerfileGenerator struct {
 	Config           transformertypes.Transformer
 	Env              *environment.Environment
@@ -122,7 +123,7 @@ type DotNetCoreDockerfileYamlConfig struct {
 }
 
 // Init Initializes the transformer
-func (t *DotNetCoreDockerfileGenerator) Init(tc transformertypes.Transformer, env *environment.Environment) error {
+func (t *DotNetCoreDockerfileGenerator) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) error {
 	t.Config = tc
 	t.Env = env
 
@@ -157,7 +158,7 @@ func (t *DotNetCoreDockerfileGenerator) GetConfig() (transformertypes.Transforme
 }
 
 // DirectoryDetect runs detect in each sub directory
-func (t *DotNetCoreDockerfileGenerator) DirectoryDetect(dir string) (services map[string][]transformertypes.Artifact, err error) {
+func (t *DotNetCoreDockerfileGenerator) DirectoryDetect(ctx context.Context, dir string) (services map[string][]transformertypes.Artifact, err error) {
 	slnPaths, err := common.GetFilesByExtInCurrDir(dir, []string{dotnet.VISUAL_STUDIO_SOLUTION_FILE_EXT})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list the dot net visual studio solution files in the directory %s . Error: %q", dir, err)
@@ -243,10 +244,13 @@ func (t *DotNetCoreDockerfileGenerator) DirectoryDetect(dir string) (services ma
 }
 
 // Transform transforms the artifacts
-func (t *DotNetCoreDockerfileGenerator) Transform(newArtifacts []transformertypes.Artifact, oldArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *DotNetCoreDockerfileGenerator) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, oldArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	artifactsCreated := []transformertypes.Artifact{}
 	for _, newArtifact := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		dotNetConfig := artifacts.DotNetConfig{}
 		if err := newArtifact.GetConfig(artifacts.DotNetConfigType, &dotNetConfig); err != nil || !dotNetConfig.IsDotNetCore {
 			continue
@@ -255,7 +259,7 @@ func (t *DotNetCoreDockerfileGenerator) Transform(newArtifacts []transformertype
 			logrus.Errorf("the service directory is missing from the dot net core artifact: %+v", newArtifact)
 			continue
 		}
-		t1, t2, err := t.TransformArtifact(newArtifact, oldArtifacts, dotNetConfig)
+		t1, t2, err := t.TransformArtifact(ctx, newArtifact, oldArtifacts, dotNetConfig)
 		if err != nil {
 			logrus.Errorf("failed to trasnform the dot net core artifact: %+v . Error: %q", newArtifact, err)
 			continue
@@ -267,11 +271,11 @@ func (t *DotNetCoreDockerfileGenerator) Transform(newArtifacts []transformertype
 }
 
 // TransformArtifact transforms a single artifact
-func (t *DotNetCoreDockerfileGenerator) TransformArtifact(newArtifact transformertypes.Artifact, oldArtifacts []transformertypes.Artifact, dotNetConfig artifacts.DotNetConfig) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *DotNetCoreDockerfileGenerator) TransformArtifact(ctx context.Context, newArtifact transformertypes.Artifact, oldArtifacts []transformertypes.Artifact, dotNetConfig artifacts.DotNetConfig) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	artifactsCreated := []transformertypes.Artifact{}
 
-	selectedBuildOption, err := dotnetutils.AskUserForDockerfileType(newArtifact.Name)
+	selectedBuildOption, err := dotnetutils.AskUserForDockerfileType(ctx, newArtifact.Name)
 	if err != nil {
 		return pathMappings, artifactsCreated, fmt.Errorf("failed to ask the user what type of dockerfile they prefer. Error: %q", err)
 	}
@@ -287,7 +291,7 @@ func (t *DotNetCoreDockerfileGenerator) TransformArtifact(newArtifact transforme
 		quesKey := fmt.Sprintf(common.ConfigServicesDotNetChildProjectsNamesKey, `"`+newArtifact.Name+`"`)
 		desc := fmt.Sprintf("For the multi-project Dot Net Core app '%s', please select all the child projects that should be run as services in the cluster:", newArtifact.Name)
 		hints := []string{"deselect any child project that should not be run (example: libraries)"}
-		selectedChildProjectNames = qaengine.FetchMultiSelectAnswer(quesKey, desc, hints, selectedChildProjectNames, selectedChildProjectNames, nil)
+		selectedChildProjectNames = qaengine.FetchMultiSelectAnswer(ctx, quesKey, desc, hints, selectedChildProjectNames, selectedChildProjectNames, nil)
 		if len(selectedChildProjectNames) == 0 {
 			return pathMappings, artifactsCreated, fmt.Errorf("user deselected all the child projects of the dot net core multi-project app '%s'", newArtifact.Name)
 		}
@@ -317,6 +321,10 @@ func (t *DotNetCoreDockerfileGenerator) TransformArtifact(newArtifact transforme
 	// build is always done at the top level using the .sln file regardless of the build option selected
 
 	imageToCopyFrom := common.MakeStringContainerImageNameCompliant(newArtifact.Name + "-" + buildStageC)
+	parentServiceConfig := artifacts.ServiceConfig{}
+	if err := newArtifact.GetConfig(artifacts.ServiceConfigType, &parentServiceConfig); err != nil {
+		logrus.Debugf("unable to load config for Transformer into %T . Error: %q", parentServiceConfig, err)
+	}
 	if selectedBuildOption == dotnetutils.NO_BUILD_STAGE {
 		imageToCopyFrom = "" // files will be copied from the local file system instead of a builder image
 	}
@@ -458,7 +466,7 @@ func (t *DotNetCoreDockerfileGenerator) TransformArtifact(newArtifact transforme
 		// select a profile to use for publishing the child project
 
 		qaSubKey := common.JoinQASubKeys(`"`+newArtifact.Name+`"`, "childProjects", `"`+childProject.Name+`"`)
-		relSelectedProfilePath, _, err := getPublishProfile(publishProfilePaths, qaSubKey, serviceDir)
+		relSelectedProfilePath, _, err := getPublishProfile(ctx, publishProfilePaths, qaSubKey, serviceDir)
 		if err != nil {
 			logrus.Errorf("failed to select one of the publish profiles for the asp net app. Error: %q Profiles: %+v", err, publishProfilePaths)
 			continue
@@ -558,7 +566,7 @@ func (t *DotNetCoreDockerfileGenerator) TransformArtifact(newArtifact transforme
 
 		// have the user select the ports to use for the child project
 
-		templateConfig.Ports = commonqa.GetPortsForService(childProjectPorts, qaSubKey)
+		templateConfig.Ports = commonqa.GetPortsForService(ctx, childProjectPorts, qaSubKey)
 
 		dockerfilePath := filepath.Join(common.DefaultSourceDir, relServiceDir, relCSProjDir, common.DefaultDockerfileName)
 		pathMappings = append(pathMappings, transformertypes.PathMapping{
@@ -571,8 +579,11 @@ func (t *DotNetCoreDockerfileGenerator) TransformArtifact(newArtifact transforme
 		// artifacts to inform other transformers of the Dockerfile we generated
 
 		paths := map[transformertypes.PathType][]string{artifacts.DockerfilePathType: {dockerfilePath}}
-		serviceConfig := artifacts.ServiceConfig{ServiceName: childProject.Name}
+		serviceConfig := parentServiceConfig.GetChildServiceConfig(childProject.Name)
 		imageName := artifacts.ImageName{ImageName: common.MakeStringContainerImageNameCompliant(childProject.Name)}
+		if serviceConfig.ContainerName != "" {
+			imageName.ImageName = common.MakeStringContainerImageNameCompliant(serviceConfig.GetImageName())
+		}
 		dockerfileArtifact := transformertypes.Artifact{
 			Name:  imageName.ImageName,
 			Type:  artifacts.DockerfileArtifactType,
@@ -631,7 +642,7 @@ func isAspNet(configuration dotnet.CSProj) (bool, error) {
 }
 
 // getPublishProfile asks the user to select one of the publish profiles for the child project
-func getPublishProfile(profilePaths []string, subKey, baseDir string) (string, string, error) {
+func getPublishProfile(ctx context.Context, profilePaths []string, subKey, baseDir string) (string, string, error) {
 	if len(profilePaths) == 0 {
 		return "", "", nil
 	}
@@ -647,7 +658,7 @@ func getPublishProfile(profilePaths []string, subKey, baseDir string) (string, s
 	if len(relProfilePaths) > 1 {
 		quesKey := common.JoinQASubKeys(common.ConfigServicesKey, subKey, common.ConfigPublishProfileForServiceKeySegment)
 		desc := fmt.Sprintf("Select the profile to be use for publishing the ASP.NET child project %s :", subKey)
-		relSelectedProfilePath = qaengine.FetchSelectAnswer(quesKey, desc, nil, relSelectedProfilePath, relProfilePaths, nil)
+		relSelectedProfilePath = qaengine.FetchSelectAnswer(ctx, quesKey, desc, nil, relSelectedProfilePath, relProfilePaths, nil)
 	}
 	selectedProfilePath := filepath.Join(baseDir, relSelectedProfilePath)
 	publishUrl, err := parsePublishProfileFile(selectedProfilePath)
@@ -17,12 +17,13 @@
 package dockerfilegenerator
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 	"sort"
 
 	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/environment"
 	"github.com/konveyor/move2kube-wasm/types"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
@@ -56,7 +57,7 @@ type GolangVersionsMappingSpec struct {
 	GolangVersions []map[string]string `yaml:"golangVersions"`
 }
 
-// GolangDockerfileGenerator implements the Transformer interface
+// GolangDockerfileGenerator implements the ContextTransformer interface
 type GolangDockerfileGenerator struct {
 	Config       transformertypes.Transformer
 	Env          *environment.Environment
@@ -77,7 +78,7 @@ type GolangDockerfileYamlConfig struct {
 }
 
 // Init Initializes the transformer
-func (t *GolangDockerfileGenerator) Init(tc transformertypes.Transformer, env *environment.Environment) (err error) {
+func (t *GolangDockerfileGenerator) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) (err error) {
 	t.Config = tc
 	t.Env = env
 	t.GolangConfig = &GolangDockerfileYamlConfig{}
@@ -110,9 +111,9 @@ func (t *GolangDockerfileGenerator) GetConfig() (transformertypes.Transformer, *
 }
 
 // DirectoryDetect runs detect in each sub directory
-func (t *GolangDockerfileGenerator) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+func (t *GolangDockerfileGenerator) DirectoryDetect(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
 	modFilePath := filepath.Join(dir, "go.mod")
-	data, err := os.ReadFile(modFilePath)
+	data, err := vfs.ReadFile(modFilePath)
 	if err != nil {
 		return nil, nil
 	}
@@ -135,6 +136,10 @@ func (t *GolangDockerfileGenerator) DirectoryDetect(dir string) (map[string][]tr
 			},
 			Configs: map[transformertypes.ConfigType]interface{}{
 				artifacts.OriginalNameConfigType: artifacts.OriginalNameConfig{OriginalName: serviceName},
+				artifacts.DetectionConfigType: artifacts.DetectionConfig{
+					Confidence: artifacts.HighDetectionConfidence,
+					Reason:     "found a go.mod with a module path",
+				},
 			},
 		}},
 	}
@@ -142,10 +147,13 @@ func (t *GolangDockerfileGenerator) DirectoryDetect(dir string) (map[string][]tr
 }
 
 // Transform transforms the artifacts
-func (t *GolangDockerfileGenerator) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *GolangDockerfileGenerator) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	artifactsCreated := []transformertypes.Artifact{}
 	for _, a := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		if len(a.Paths[artifacts.ServiceDirPathType]) == 0 {
 			continue
 		}
@@ -164,7 +172,7 @@ func (t *GolangDockerfileGenerator) Transform(newArtifacts []transformertypes.Ar
 			logrus.Debugf("unable to load config for Transformer into %T : %s", imageName, err)
 		}
 		if imageName.ImageName == "" {
-			imageName.ImageName = common.MakeStringContainerImageNameCompliant(serviceConfig.ServiceName)
+			imageName.ImageName = common.MakeStringContainerImageNameCompliant(serviceConfig.GetImageName())
 		}
 		ir := irtypes.IR{}
 		irPresent := true
@@ -172,7 +180,7 @@ func (t *GolangDockerfileGenerator) Transform(newArtifacts []transformertypes.Ar
 			irPresent = false
 			logrus.Debugf("unable to load config for Transformer into %T : %s", ir, err)
 		}
-		data, err := os.ReadFile(a.Paths[GolangModFilePathType][0])
+		data, err := vfs.ReadFile(a.Paths[GolangModFilePathType][0])
 		if err != nil {
 			logrus.Errorf("Error while reading the go.mod file : %s", err)
 			return nil, nil, nil
@@ -206,7 +214,7 @@ func (t *GolangDockerfileGenerator) Transform(newArtifacts []transformertypes.Ar
 		if len(detectedPorts) == 0 {
 			detectedPorts = append(detectedPorts, common.DefaultServicePort)
 		}
-		detectedPorts = commonqa.GetPortsForService(detectedPorts, `"`+a.Name+`"`)
+		detectedPorts = commonqa.GetPortsForService(ctx, detectedPorts, `"`+a.Name+`"`)
 		golangConfig := GolangTemplateConfig{
 			AppName:        a.Name,
 			Ports:          detectedPorts,
@@ -99,6 +99,10 @@ func (t *EarAnalyser) DirectoryDetect(dir string) (map[string][]transformertypes
 				},
 				artifacts.OriginalNameConfigType: artifacts.OriginalNameConfig{OriginalName: serviceName},
 				artifacts.ImageNameConfigType:    artifacts.ImageName{ImageName: common.MakeStringContainerImageNameCompliant(serviceName)},
+				artifacts.DetectionConfigType: artifacts.DetectionConfig{
+					Confidence: artifacts.LowDetectionConfidence,
+					Reason:     "found a pre-built .ear archive",
+				},
 			},
 		}
 		services[normalizedServiceName] = append(services[normalizedServiceName], newArtifact)
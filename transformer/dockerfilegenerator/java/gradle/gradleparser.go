@@ -17,9 +17,9 @@
 package gradle
 
 import (
-	"os"
 	"strings"
 
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/sirupsen/logrus"
 )
 
@@ -28,7 +28,7 @@ import (
 // ParseGardleBuildFile parses a gradle build file
 func ParseGardleBuildFile(buildFilePath string) (gradleBuild Gradle, err error) {
 	state := gradleParseState{}
-	buildFile, err := os.ReadFile(buildFilePath)
+	buildFile, err := vfs.ReadFile(buildFilePath)
 	if err != nil {
 		logrus.Errorf("Unable to read gradle build file : %s", err)
 		return Gradle{}, err
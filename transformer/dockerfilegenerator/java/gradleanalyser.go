@@ -18,6 +18,7 @@ package java
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"github.com/konveyor/move2kube-wasm/qaengine"
 	"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
@@ -31,6 +32,7 @@ import (
 	//"github.com/konveyor/move2kube-wasm/qaengine"
 	"github.com/konveyor/move2kube-wasm/transformer/dockerfilegenerator/java/gradle"
 	//"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
 	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
 	"github.com/magiconair/properties"
@@ -142,7 +144,7 @@ import (
 
 // ---------------------------------------------------------------
 
-// GradleAnalyser implements Transformer interface
+// GradleAnalyser implements ContextTransformer interface
 type GradleAnalyser struct {
 	Config       transformertypes.Transformer
 	Env          *environment.Environment
@@ -222,7 +224,7 @@ var (
 )
 
 // Init Initializes the transformer
-func (t *GradleAnalyser) Init(tc transformertypes.Transformer, env *environment.Environment) (err error) {
+func (t *GradleAnalyser) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) (err error) {
 	t.Config = tc
 	t.Env = env
 	t.GradleConfig = &GradleYamlConfig{}
@@ -249,7 +251,7 @@ func (t *GradleAnalyser) GetConfig() (transformertypes.Transformer, *environment
 }
 
 // DirectoryDetect runs detect in each sub directory
-func (t *GradleAnalyser) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+func (t *GradleAnalyser) DirectoryDetect(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
 
 	// look for settings.gradle
 
@@ -369,11 +371,14 @@ func (t *GradleAnalyser) DirectoryDetect(dir string) (map[string][]transformerty
 }
 
 // Transform transforms the input artifacts, mostly handles artifacts created during the plan phase.
-func (t *GradleAnalyser) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *GradleAnalyser) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	createdArtifacts := []transformertypes.Artifact{}
 
 	for _, newArtifact := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return pathMappings, createdArtifacts, err
+		}
 
 		// only process service artifacts
 
@@ -411,7 +416,7 @@ func (t *GradleAnalyser) Transform(newArtifacts []transformertypes.Artifact, alr
 
 		// transform a single service artifact (probably created during the plan phase by GradleAnalyser.DirectoryDetect)
 
-		currPathMappings, currCreatedArtifacts, err := t.TransformArtifact(newArtifact, alreadySeenArtifacts, serviceConfig, gradleConfig)
+		currPathMappings, currCreatedArtifacts, err := t.TransformArtifact(ctx, newArtifact, alreadySeenArtifacts, serviceConfig, gradleConfig)
 		if err != nil {
 			logrus.Errorf("failed to transform the artifact: %+v . Error: %q", newArtifact, err)
 			continue
@@ -424,11 +429,11 @@ func (t *GradleAnalyser) Transform(newArtifacts []transformertypes.Artifact, alr
 }
 
 // TransformArtifact transforms a single artifact.
-func (t *GradleAnalyser) TransformArtifact(newArtifact transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact, serviceConfig artifacts.ServiceConfig, gradleConfig artifacts.GradleConfig) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *GradleAnalyser) TransformArtifact(ctx context.Context, newArtifact transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact, serviceConfig artifacts.ServiceConfig, gradleConfig artifacts.GradleConfig) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	createdArtifacts := []transformertypes.Artifact{}
 
-	selectedBuildOption, err := askUserForDockerfileType(gradleConfig.RootProjectName)
+	selectedBuildOption, err := askUserForDockerfileType(ctx, gradleConfig.RootProjectName)
 	if err != nil {
 		return pathMappings, createdArtifacts, err
 	}
@@ -444,7 +449,7 @@ func (t *GradleAnalyser) TransformArtifact(newArtifact transformertypes.Artifact
 		quesKey := fmt.Sprintf(common.ConfigServicesChildModulesNamesKey, `"`+serviceConfig.ServiceName+`"`)
 		desc := fmt.Sprintf("For the multi-module Gradle project '%s', please select all the child modules that should be run as services in the cluster:", serviceConfig.ServiceName)
 		hints := []string{"deselect child modules that should not be run (like libraries)"}
-		selectedChildModuleNames = qaengine.FetchMultiSelectAnswer(quesKey, desc, hints, selectedChildModuleNames, selectedChildModuleNames, nil)
+		selectedChildModuleNames = qaengine.FetchMultiSelectAnswer(ctx, quesKey, desc, hints, selectedChildModuleNames, selectedChildModuleNames, nil)
 		if len(selectedChildModuleNames) == 0 {
 			return pathMappings, createdArtifacts, fmt.Errorf("user deselected all the child modules of the gradle multi-module project '%s'", serviceConfig.ServiceName)
 		}
@@ -453,10 +458,13 @@ func (t *GradleAnalyser) TransformArtifact(newArtifact transformertypes.Artifact
 	// have jar/war/ear analyzer transformers generate a Dockerfile with only the run stage for each of the child modules
 
 	lowestJavaVersion := ""
-	imageToCopyFrom := serviceConfig.ServiceName + "-" + buildStageC
+	imageToCopyFrom := serviceConfig.GetImageName() + "-" + buildStageC
 	serviceRootDir := newArtifact.Paths[artifacts.ServiceRootDirPathType][0]
 
 	for _, childModule := range gradleConfig.ChildModules {
+		if err := ctx.Err(); err != nil {
+			return pathMappings, createdArtifacts, err
+		}
 
 		// only look at the child modules the user selected
 
@@ -497,7 +505,7 @@ func (t *GradleAnalyser) TransformArtifact(newArtifact transformertypes.Artifact
 		if childModuleInfo.SpringBoot != nil {
 			if childModuleInfo.SpringBoot.SpringBootProfiles != nil && len(*childModuleInfo.SpringBoot.SpringBootProfiles) != 0 {
 				quesKey := fmt.Sprintf(common.ConfigServicesChildModulesSpringProfilesKey, `"`+serviceConfig.ServiceName+`"`, `"`+childModule.Name+`"`)
-				selectedSpringProfiles := qaengine.FetchMultiSelectAnswer(quesKey, desc, hints, *childModuleInfo.SpringBoot.SpringBootProfiles, *childModuleInfo.SpringBoot.SpringBootProfiles, nil)
+				selectedSpringProfiles := qaengine.FetchMultiSelectAnswer(ctx, quesKey, desc, hints, *childModuleInfo.SpringBoot.SpringBootProfiles, *childModuleInfo.SpringBoot.SpringBootProfiles, nil)
 				for _, selectedSpringProfile := range selectedSpringProfiles {
 					detectedPorts = append(detectedPorts, childModuleInfo.SpringBoot.SpringBootProfilePorts[selectedSpringProfile]...)
 				}
@@ -509,7 +517,7 @@ func (t *GradleAnalyser) TransformArtifact(newArtifact transformertypes.Artifact
 
 		// have the user select the port to use
 
-		selectedPort := commonqa.GetPortForService(detectedPorts, common.JoinQASubKeys(`"`+serviceConfig.ServiceName+`"`, "childModules", `"`+childModule.Name+`"`))
+		selectedPort := commonqa.GetPortForService(ctx, detectedPorts, common.JoinQASubKeys(`"`+serviceConfig.ServiceName+`"`, "childModules", `"`+childModule.Name+`"`))
 		if childModuleInfo.SpringBoot != nil {
 			envVarsMap["SERVER_PORT"] = cast.ToString(selectedPort)
 		} else {
@@ -537,6 +545,11 @@ func (t *GradleAnalyser) TransformArtifact(newArtifact transformertypes.Artifact
 
 		// create an artifact that will get picked up by the jar/war/ear analyzer transformers
 
+		childServiceConfig := serviceConfig.GetChildServiceConfig(common.MakeStringK8sServiceNameCompliant(childModule.Name))
+		childImageName := common.MakeStringContainerImageNameCompliant(childModule.Name)
+		if childServiceConfig.ContainerName != "" {
+			childImageName = common.MakeStringContainerImageNameCompliant(childServiceConfig.GetImageName())
+		}
 		runStageArtifact := transformertypes.Artifact{
 			Name:  childModule.Name,
 			Type:  childModuleInfo.Type,
@@ -549,8 +562,8 @@ func (t *GradleAnalyser) TransformArtifact(newArtifact transformertypes.Artifact
 					DeploymentFilePath: insideContainerDepFilePath,
 					EnvVariables:       envVarsMap,
 				},
-				artifacts.ImageNameConfigType: artifacts.ImageName{ImageName: common.MakeStringContainerImageNameCompliant(childModule.Name)},
-				artifacts.ServiceConfigType:   artifacts.ServiceConfig{ServiceName: common.MakeStringK8sServiceNameCompliant(childModule.Name)},
+				artifacts.ImageNameConfigType: artifacts.ImageName{ImageName: childImageName},
+				artifacts.ServiceConfigType:   childServiceConfig,
 			},
 		}
 		createdArtifacts = append(createdArtifacts, runStageArtifact)
@@ -577,12 +590,12 @@ func (t *GradleAnalyser) TransformArtifact(newArtifact transformertypes.Artifact
 	if err != nil {
 		return pathMappings, createdArtifacts, fmt.Errorf("failed to get the Dockerfile template. Error: %q", err)
 	}
-	tempDir, err := os.MkdirTemp(t.Env.TempPath, "gradle-transformer-build-*")
+	tempDir, err := vfs.MkdirTemp(t.Env.TempPath, "gradle-transformer-build-*")
 	if err != nil {
 		return pathMappings, createdArtifacts, fmt.Errorf("failed to create a temporary directory inside the directory %s . Error: %q", t.Env.TempPath, err)
 	}
 	dockerfileTemplatePath := filepath.Join(tempDir, common.DefaultDockerfileName+".build.template")
-	if err := os.WriteFile(dockerfileTemplatePath, []byte(dockerfileTemplate), common.DefaultFilePermission); err != nil {
+	if err := vfs.WriteFile(dockerfileTemplatePath, []byte(dockerfileTemplate), common.DefaultFilePermission); err != nil {
 		return pathMappings, createdArtifacts, fmt.Errorf("failed to write the Dockerfile template to a temporary file at path %s . Error: %q", dockerfileTemplatePath, err)
 	}
 
@@ -733,12 +746,12 @@ func getSpringBootConfigFromGradle(buildFilePath string, gradleBuild, parentGrad
 func (t *GradleAnalyser) getDockerfileTemplate() (string, error) {
 	// TODO: see if we can cache gradle dependencies similar to https://stackoverflow.com/a/37442191
 	licenseFilePath := filepath.Join(t.Env.GetEnvironmentContext(), t.Env.RelTemplatesDir, "Dockerfile.license")
-	license, err := os.ReadFile(licenseFilePath)
+	license, err := vfs.ReadFile(licenseFilePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read the Dockerfile license file at path %s . Error: %q", licenseFilePath, err)
 	}
 	gradleBuildTemplatePath := filepath.Join(t.Env.GetEnvironmentContext(), t.Env.RelTemplatesDir, "Dockerfile.gradle-build")
-	gradleBuildTemplate, err := os.ReadFile(gradleBuildTemplatePath)
+	gradleBuildTemplate, err := vfs.ReadFile(gradleBuildTemplatePath)
 	if err != nil {
 		return string(license), fmt.Errorf("failed to read the Dockerfile Gradle build template file at path %s . Error: %q", gradleBuildTemplatePath, err)
 	}
@@ -975,7 +988,7 @@ func getDeploymentFilePathFromGradle(gradleBuild *gradle.Gradle, buildScriptPath
 }
 
 func getChildModules(gradleSettingsFilePath string) ([]artifacts.GradleChildModule, error) {
-	gradleSettingsFile, err := os.Open(gradleSettingsFilePath)
+	gradleSettingsFile, err := vfs.Open(gradleSettingsFilePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open the settings.gradle file at path %s . Error: %q", gradleSettingsFilePath, err)
 	}
@@ -19,13 +19,13 @@ package java
 import (
 	"fmt"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
-	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/konveyor/move2kube-wasm/common"
 	"github.com/konveyor/move2kube-wasm/environment"
 	//irtypes "github.com/konveyor/move2kube-wasm/types/ir"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
 	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
 	"github.com/sirupsen/logrus"
@@ -109,6 +109,10 @@ func (t *JarAnalyser) DirectoryDetect(dir string) (map[string][]transformertypes
 					Port:               t.JarConfig.DefaultPort,
 					JavaVersion:        t.JarConfig.JavaVersion,
 				},
+				artifacts.DetectionConfigType: artifacts.DetectionConfig{
+					Confidence: artifacts.LowDetectionConfidence,
+					Reason:     "found a pre-built .jar archive",
+				},
 			},
 		}
 		services[normalizedServiceName] = append(services[normalizedServiceName], newArtifact)
@@ -139,7 +143,7 @@ func (t *JarAnalyser) Transform(newArtifacts []transformertypes.Artifact, alread
 			logrus.Debugf("failed to load the image name config from the artifact %+v . Error: %q", newArtifact, err)
 		}
 		if imageName.ImageName == "" {
-			imageName.ImageName = common.MakeStringContainerImageNameCompliant(serviceConfig.ServiceName)
+			imageName.ImageName = common.MakeStringContainerImageNameCompliant(serviceConfig.GetImageName())
 		}
 		// get the Dockerfile template
 		dockerfileTemplate, _, err := t.getDockerfileTemplate(newArtifact)
@@ -149,12 +153,12 @@ func (t *JarAnalyser) Transform(newArtifacts []transformertypes.Artifact, alread
 		}
 		// write the Dockerfile template to a temporary file for a pathmapping to pick it up
 		tempDir := filepath.Join(t.Env.TempPath, newArtifact.Name)
-		if err := os.MkdirAll(tempDir, common.DefaultDirectoryPermission); err != nil {
+		if err := vfs.MkdirAll(tempDir, common.DefaultDirectoryPermission); err != nil {
 			logrus.Errorf("failed to create the temporary directory %s . Error: %q", tempDir, err)
 			continue
 		}
 		dockerfileTemplatePath := filepath.Join(tempDir, common.DefaultDockerfileName)
-		if err := os.WriteFile(dockerfileTemplatePath, []byte(dockerfileTemplate), common.DefaultFilePermission); err != nil {
+		if err := vfs.WriteFile(dockerfileTemplatePath, []byte(dockerfileTemplate), common.DefaultFilePermission); err != nil {
 			logrus.Errorf("failed to write the Dockerfile template at path %s . Error: %q", dockerfileTemplatePath, err)
 			continue
 		}
@@ -235,7 +239,7 @@ func (t *JarAnalyser) Transform(newArtifacts []transformertypes.Artifact, alread
 
 func (t *JarAnalyser) getDockerfileTemplate(newArtifact transformertypes.Artifact) (string, bool, error) {
 	jarRunDockerfileTemplatePath := filepath.Join(t.Env.GetEnvironmentContext(), t.Env.RelTemplatesDir, "Dockerfile.jar-run")
-	jarRunDockerfileTemplate, err := os.ReadFile(jarRunDockerfileTemplatePath)
+	jarRunDockerfileTemplate, err := vfs.ReadFile(jarRunDockerfileTemplatePath)
 	if err != nil {
 		return "", false, fmt.Errorf("failed to read the JAR run Dockerfile template at path %s . Error: %q", jarRunDockerfileTemplatePath, err)
 	}
@@ -244,14 +248,14 @@ func (t *JarAnalyser) getDockerfileTemplate(newArtifact transformertypes.Artifac
 	if buildContainerPaths, ok := newArtifact.Paths[artifacts.BuildContainerFileType]; ok && len(buildContainerPaths) > 0 {
 		isBuildContainerPresent = true
 		buildStageDockerfilePath := buildContainerPaths[0]
-		dockerFileHeadBytes, err := os.ReadFile(buildStageDockerfilePath)
+		dockerFileHeadBytes, err := vfs.ReadFile(buildStageDockerfilePath)
 		if err != nil {
 			return "", isBuildContainerPresent, fmt.Errorf("failed to read the build stage Dockerfile at path %s . Error: %q", buildStageDockerfilePath, err)
 		}
 		dockerFileHead = string(dockerFileHeadBytes)
 	} else {
 		licensePath := filepath.Join(t.Env.GetEnvironmentContext(), t.Env.RelTemplatesDir, "Dockerfile.license")
-		dockerFileHeadBytes, err := os.ReadFile(licensePath)
+		dockerFileHeadBytes, err := vfs.ReadFile(licensePath)
 		if err != nil {
 			return "", isBuildContainerPresent, fmt.Errorf("failed to read the Dockerfile license at path %s . Error: %q", licensePath, err)
 		}
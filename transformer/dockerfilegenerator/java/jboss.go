@@ -19,12 +19,12 @@ package java
 import (
 	"fmt"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
-	"os"
 	"path/filepath"
 
 	"github.com/konveyor/move2kube-wasm/common"
 	"github.com/konveyor/move2kube-wasm/environment"
 	//irtypes "github.com/konveyor/move2kube-wasm/types/ir"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
 	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
 	"github.com/sirupsen/logrus"
@@ -117,12 +117,12 @@ func (t *Jboss) Transform(newArtifacts []transformertypes.Artifact, alreadySeenA
 			continue
 		}
 		tempDir := filepath.Join(t.Env.TempPath, newArtifact.Name)
-		if err := os.MkdirAll(tempDir, common.DefaultDirectoryPermission); err != nil {
+		if err := vfs.MkdirAll(tempDir, common.DefaultDirectoryPermission); err != nil {
 			logrus.Errorf("failed to create the temporary directory %s . Error: %q", tempDir, err)
 			continue
 		}
 		dockerfileTemplatePath := filepath.Join(tempDir, common.DefaultDockerfileName)
-		if err := os.WriteFile(dockerfileTemplatePath, []byte(template), common.DefaultFilePermission); err != nil {
+		if err := vfs.WriteFile(dockerfileTemplatePath, []byte(template), common.DefaultFilePermission); err != nil {
 			logrus.Errorf("Could not write the generated Build Dockerfile template: %s", err)
 		}
 		templateData := JbossDockerfileTemplate{}
@@ -197,21 +197,21 @@ func (t *Jboss) Transform(newArtifacts []transformertypes.Artifact, alreadySeenA
 
 func (t *Jboss) getDockerfileTemplate(newArtifact transformertypes.Artifact) (string, error) {
 	jbossRunTemplatePath := filepath.Join(t.Env.GetEnvironmentContext(), t.Env.RelTemplatesDir, "Dockerfile.jboss")
-	jbossRunTemplate, err := os.ReadFile(jbossRunTemplatePath)
+	jbossRunTemplate, err := vfs.ReadFile(jbossRunTemplatePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read the jboss run stage Dockerfile template at path %s . Error: %q", jbossRunTemplatePath, err)
 	}
 	dockerFileHead := ""
 	if buildContainerPaths := newArtifact.Paths[artifacts.BuildContainerFileType]; len(buildContainerPaths) > 0 {
 		dockerfileBuildPath := buildContainerPaths[0]
-		dockerFileHeadBytes, err := os.ReadFile(dockerfileBuildPath)
+		dockerFileHeadBytes, err := vfs.ReadFile(dockerfileBuildPath)
 		if err != nil {
 			return "", fmt.Errorf("failed to read the build stage Dockerfile template at path %s . Error: %q", dockerfileBuildPath, err)
 		}
 		dockerFileHead = string(dockerFileHeadBytes)
 	} else {
 		licenseFilePath := filepath.Join(t.Env.GetEnvironmentContext(), t.Env.RelTemplatesDir, "Dockerfile.license")
-		dockerFileHeadBytes, err := os.ReadFile(licenseFilePath)
+		dockerFileHeadBytes, err := vfs.ReadFile(licenseFilePath)
 		if err != nil {
 			return "", fmt.Errorf("failed to read the Dockerfile license at path %s . Error: %q", licenseFilePath, err)
 		}
@@ -19,13 +19,13 @@ package java
 import (
 	"fmt"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
-	"os"
 	"path/filepath"
 
 	"github.com/konveyor/move2kube-wasm/common"
 	"github.com/konveyor/move2kube-wasm/environment"
 	"github.com/konveyor/move2kube-wasm/types"
 	//irtypes "github.com/konveyor/move2kube-wasm/types/ir"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
 	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
 	"github.com/sirupsen/logrus"
@@ -135,12 +135,12 @@ func (t *Liberty) Transform(newArtifacts []transformertypes.Artifact, alreadySee
 			continue
 		}
 		tempDir := filepath.Join(t.Env.TempPath, newArtifact.Name)
-		if err := os.MkdirAll(tempDir, common.DefaultDirectoryPermission); err != nil {
+		if err := vfs.MkdirAll(tempDir, common.DefaultDirectoryPermission); err != nil {
 			logrus.Errorf("failed to make the temporary directory %s . Error: %q", tempDir, err)
 			continue
 		}
 		dockerfileTemplatePath := filepath.Join(tempDir, common.DefaultDockerfileName)
-		if err := os.WriteFile(dockerfileTemplatePath, []byte(template), common.DefaultFilePermission); err != nil {
+		if err := vfs.WriteFile(dockerfileTemplatePath, []byte(template), common.DefaultFilePermission); err != nil {
 			logrus.Errorf("failed to write the liberty Dockerfile template to the temporary file at path %s . Error: %q", dockerfileTemplatePath, err)
 			continue
 		}
@@ -224,21 +224,21 @@ func (t *Liberty) Transform(newArtifacts []transformertypes.Artifact, alreadySee
 
 func (t *Liberty) getDockerfileTemplate(newArtifact transformertypes.Artifact) (string, error) {
 	libertyRunTemplatePath := filepath.Join(t.Env.GetEnvironmentContext(), t.Env.RelTemplatesDir, "Dockerfile.liberty")
-	libertyRunTemplate, err := os.ReadFile(libertyRunTemplatePath)
+	libertyRunTemplate, err := vfs.ReadFile(libertyRunTemplatePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read the liberty run stage Dockerfile template at path %s . Error: %q", libertyRunTemplatePath, err)
 	}
 	dockerFileHead := ""
 	if buildContainerPaths := newArtifact.Paths[artifacts.BuildContainerFileType]; len(buildContainerPaths) > 0 {
 		dockerfileBuildPath := buildContainerPaths[0]
-		dockerFileHeadBytes, err := os.ReadFile(dockerfileBuildPath)
+		dockerFileHeadBytes, err := vfs.ReadFile(dockerfileBuildPath)
 		if err != nil {
 			return "", fmt.Errorf("failed to read the build stage Dockerfile template at path %s . Error: %q", dockerfileBuildPath, err)
 		}
 		dockerFileHead = string(dockerFileHeadBytes)
 	} else {
 		licenseFilePath := filepath.Join(t.Env.GetEnvironmentContext(), t.Env.RelTemplatesDir, "Dockerfile.license")
-		dockerFileHeadBytes, err := os.ReadFile(licenseFilePath)
+		dockerFileHeadBytes, err := vfs.ReadFile(licenseFilePath)
 		if err != nil {
 			return "", fmt.Errorf("failed to read the Dockerfile license at path %s . Error: %q", licenseFilePath, err)
 		}
@@ -17,12 +17,12 @@
 package java
 
 import (
+	"context"
 	"fmt"
 	"github.com/konveyor/move2kube-wasm/qaengine"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
 	"github.com/spf13/cast"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -34,6 +34,7 @@ import (
 	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
 	"github.com/sirupsen/logrus"
 	//"github.com/spf13/cast"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 )
 
 const (
@@ -48,7 +49,7 @@ const (
 	MAVEN_DEFAULT_BUILD_DIR = "target"
 )
 
-// MavenAnalyser implements Transformer interface
+// MavenAnalyser implements ContextTransformer interface
 type MavenAnalyser struct {
 	Config      transformertypes.Transformer
 	Env         *environment.Environment
@@ -74,7 +75,7 @@ type MavenBuildDockerfileTemplate struct {
 }
 
 // Init initializes the transformer
-func (t *MavenAnalyser) Init(tc transformertypes.Transformer, env *environment.Environment) (err error) {
+func (t *MavenAnalyser) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) (err error) {
 	t.Config = tc
 	t.Env = env
 	t.MavenConfig = &MavenYamlConfig{}
@@ -101,7 +102,7 @@ func (t *MavenAnalyser) GetConfig() (transformertypes.Transformer, *environment.
 }
 
 // DirectoryDetect runs detect in each sub directory
-func (t *MavenAnalyser) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+func (t *MavenAnalyser) DirectoryDetect(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
 
 	// look for pom.xml
 
@@ -164,6 +165,9 @@ func (t *MavenAnalyser) DirectoryDetect(dir string) (map[string][]transformertyp
 		mavenConfig.ChildModules = []artifacts.ChildModule{}
 		paths[artifacts.ServiceDirPathType] = []string{}
 		for _, relChildModulePomPath := range *pom.Modules {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
 			relChildModulePomPath = filepath.Clean(relChildModulePomPath)
 			if filepath.Ext(relChildModulePomPath) != ".xml" {
 				relChildModulePomPath = filepath.Join(relChildModulePomPath, maven.PomXMLFileName)
@@ -200,6 +204,10 @@ func (t *MavenAnalyser) DirectoryDetect(dir string) (map[string][]transformertyp
 		Paths: paths,
 		Configs: map[transformertypes.ConfigType]interface{}{
 			artifacts.MavenConfigType: mavenConfig,
+			artifacts.DetectionConfigType: artifacts.DetectionConfig{
+				Confidence: artifacts.HighDetectionConfidence,
+				Reason:     "found a pom.xml",
+			},
 		},
 	}
 	normalizedServiceName := common.MakeStringK8sServiceNameCompliant(mavenConfig.MavenAppName)
@@ -209,10 +217,13 @@ func (t *MavenAnalyser) DirectoryDetect(dir string) (map[string][]transformertyp
 }
 
 // Transform transforms the input artifacts mostly handling artifacts created during the plan phase.
-func (t *MavenAnalyser) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *MavenAnalyser) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	createdArtifacts := []transformertypes.Artifact{}
 	for _, newArtifact := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return pathMappings, createdArtifacts, err
+		}
 		if newArtifact.Type != artifacts.ServiceArtifactType {
 			continue
 		}
@@ -236,7 +247,7 @@ func (t *MavenAnalyser) Transform(newArtifacts []transformertypes.Artifact, alre
 			logrus.Errorf("failed to load the pom.xml file at path %s . Error: %q", rootPomFilePath, err)
 			continue
 		}
-		currPathMappings, currArtifacts, err := t.TransformArtifact(newArtifact, alreadySeenArtifacts, pom, rootPomFilePath, serviceConfig, mavenConfig)
+		currPathMappings, currArtifacts, err := t.TransformArtifact(ctx, newArtifact, alreadySeenArtifacts, pom, rootPomFilePath, serviceConfig, mavenConfig)
 		if err != nil {
 			logrus.Errorf("failed to transform the artifact: %+v . Error: %q", newArtifact, err)
 			continue
@@ -260,11 +271,11 @@ type infoT struct {
 }
 
 // TransformArtifact is the same as Transform but operating on a single artifact and its pom.xml at a time.
-func (t *MavenAnalyser) TransformArtifact(newArtifact transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact, pom *maven.Pom, rootPomFilePath string, serviceConfig artifacts.ServiceConfig, mavenConfig artifacts.MavenConfig) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *MavenAnalyser) TransformArtifact(ctx context.Context, newArtifact transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact, pom *maven.Pom, rootPomFilePath string, serviceConfig artifacts.ServiceConfig, mavenConfig artifacts.MavenConfig) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	createdArtifacts := []transformertypes.Artifact{}
 
-	selectedBuildOption, err := askUserForDockerfileType(serviceConfig.ServiceName)
+	selectedBuildOption, err := askUserForDockerfileType(ctx, serviceConfig.ServiceName)
 	if err != nil {
 		return pathMappings, createdArtifacts, err
 	}
@@ -280,7 +291,7 @@ func (t *MavenAnalyser) TransformArtifact(newArtifact transformertypes.Artifact,
 		quesKey := fmt.Sprintf(common.ConfigServicesChildModulesNamesKey, `"`+serviceConfig.ServiceName+`"`)
 		desc := fmt.Sprintf("For the multi-module Maven project '%s', please select all the child modules that should be run as services in the cluster:", serviceConfig.ServiceName)
 		hints := []string{"deselect child modules that should not be run (like libraries)"}
-		selectedChildModuleNames = qaengine.FetchMultiSelectAnswer(quesKey, desc, hints, selectedChildModuleNames, selectedChildModuleNames, nil)
+		selectedChildModuleNames = qaengine.FetchMultiSelectAnswer(ctx, quesKey, desc, hints, selectedChildModuleNames, selectedChildModuleNames, nil)
 		if len(selectedChildModuleNames) == 0 {
 			return pathMappings, createdArtifacts, fmt.Errorf("user deselected all the child modules of the maven multi-module project '%s'", serviceConfig.ServiceName)
 		}
@@ -289,10 +300,13 @@ func (t *MavenAnalyser) TransformArtifact(newArtifact transformertypes.Artifact,
 	// have jar/war/ear analyzer transformers generate a Dockerfile with only the run stage for each of the child modules
 
 	lowestJavaVersion := ""
-	imageToCopyFrom := serviceConfig.ServiceName + "-" + buildStageC
+	imageToCopyFrom := serviceConfig.GetImageName() + "-" + buildStageC
 	serviceRootDir := newArtifact.Paths[artifacts.ServiceRootDirPathType][0]
 
 	for _, childModule := range mavenConfig.ChildModules {
+		if err := ctx.Err(); err != nil {
+			return pathMappings, createdArtifacts, err
+		}
 
 		// only look at the child modules the user selected
 
@@ -332,7 +346,7 @@ func (t *MavenAnalyser) TransformArtifact(newArtifact transformertypes.Artifact,
 		if childModuleInfo.SpringBoot != nil {
 			if childModuleInfo.SpringBoot.SpringBootProfiles != nil && len(*childModuleInfo.SpringBoot.SpringBootProfiles) != 0 {
 				quesKey := fmt.Sprintf(common.ConfigServicesChildModulesSpringProfilesKey, `"`+serviceConfig.ServiceName+`"`, `"`+childModule.Name+`"`)
-				selectedSpringProfiles := qaengine.FetchMultiSelectAnswer(quesKey, desc, hints, *childModuleInfo.SpringBoot.SpringBootProfiles, *childModuleInfo.SpringBoot.SpringBootProfiles, nil)
+				selectedSpringProfiles := qaengine.FetchMultiSelectAnswer(ctx, quesKey, desc, hints, *childModuleInfo.SpringBoot.SpringBootProfiles, *childModuleInfo.SpringBoot.SpringBootProfiles, nil)
 				for _, selectedSpringProfile := range selectedSpringProfiles {
 					detectedPorts = append(detectedPorts, childModuleInfo.SpringBoot.SpringBootProfilePorts[selectedSpringProfile]...)
 				}
@@ -344,7 +358,7 @@ func (t *MavenAnalyser) TransformArtifact(newArtifact transformertypes.Artifact,
 
 		// have the user select the port to use
 
-		selectedPort := commonqa.GetPortForService(detectedPorts, common.JoinQASubKeys(`"`+serviceConfig.ServiceName+`"`, "childModules", `"`+childModule.Name+`"`))
+		selectedPort := commonqa.GetPortForService(ctx, detectedPorts, common.JoinQASubKeys(`"`+serviceConfig.ServiceName+`"`, "childModules", `"`+childModule.Name+`"`))
 		if childModuleInfo.SpringBoot != nil {
 			envVarsMap["SERVER_PORT"] = cast.ToString(selectedPort)
 		} else {
@@ -372,6 +386,11 @@ func (t *MavenAnalyser) TransformArtifact(newArtifact transformertypes.Artifact,
 
 		// create an artifact that will get picked up by the jar/war/ear analyzer transformers
 
+		childServiceConfig := serviceConfig.GetChildServiceConfig(common.MakeStringK8sServiceNameCompliant(childModule.Name))
+		childImageName := common.MakeStringContainerImageNameCompliant(childModule.Name)
+		if childServiceConfig.ContainerName != "" {
+			childImageName = common.MakeStringContainerImageNameCompliant(childServiceConfig.GetImageName())
+		}
 		runStageArtifact := transformertypes.Artifact{
 			Name:  childModule.Name,
 			Type:  childModuleInfo.Type,
@@ -384,8 +403,8 @@ func (t *MavenAnalyser) TransformArtifact(newArtifact transformertypes.Artifact,
 					DeploymentFilePath: insideContainerDepFilePath,
 					EnvVariables:       envVarsMap,
 				},
-				artifacts.ImageNameConfigType: artifacts.ImageName{ImageName: common.MakeStringContainerImageNameCompliant(childModule.Name)},
-				artifacts.ServiceConfigType:   artifacts.ServiceConfig{ServiceName: common.MakeStringK8sServiceNameCompliant(childModule.Name)},
+				artifacts.ImageNameConfigType: artifacts.ImageName{ImageName: childImageName},
+				artifacts.ServiceConfigType:   childServiceConfig,
 			},
 		}
 
@@ -421,12 +440,12 @@ func (t *MavenAnalyser) TransformArtifact(newArtifact transformertypes.Artifact,
 	if err != nil {
 		return pathMappings, createdArtifacts, fmt.Errorf("failed to get the Dockerfile template. Error: %q", err)
 	}
-	tempDir, err := os.MkdirTemp(t.Env.TempPath, "maven-transformer-build-*")
+	tempDir, err := vfs.MkdirTemp(t.Env.TempPath, "maven-transformer-build-*")
 	if err != nil {
 		return pathMappings, createdArtifacts, fmt.Errorf("failed to create a temporary directory inside the directory %s . Error: %q", t.Env.TempPath, err)
 	}
 	dockerfileTemplatePath := filepath.Join(tempDir, common.DefaultDockerfileName+".build.template")
-	if err := os.WriteFile(dockerfileTemplatePath, []byte(dockerfileTemplate), common.DefaultFilePermission); err != nil {
+	if err := vfs.WriteFile(dockerfileTemplatePath, []byte(dockerfileTemplate), common.DefaultFilePermission); err != nil {
 		return pathMappings, createdArtifacts, fmt.Errorf("failed to write the Dockerfile template to a temporary file at path %s . Error: %q", dockerfileTemplatePath, err)
 	}
 
@@ -448,6 +467,7 @@ func (t *MavenAnalyser) TransformArtifact(newArtifact transformertypes.Artifact,
 	// ask the user which maven profiles should be used while building the app
 
 	selectedMavenProfiles := qaengine.FetchMultiSelectAnswer(
+		ctx,
 		common.JoinQASubKeys(common.ConfigServicesKey, `"`+serviceConfig.ServiceName+`"`, "mavenProfiles"),
 		fmt.Sprintf("Select the maven profiles to use for the '%s' service", serviceConfig.ServiceName),
 		[]string{"The selected maven profiles will be used during the build."},
@@ -582,12 +602,12 @@ func getInfoFromPom(pom, parentPom *maven.Pom, pomFilePath string, mavenConfig *
 func (t *MavenAnalyser) getDockerfileTemplate() (string, error) {
 	// multi stage build similar to https://nieldw.medium.com/caching-maven-dependencies-in-a-docker-build-dca6ca7ad612
 	licenseFilePath := filepath.Join(t.Env.GetEnvironmentContext(), t.Env.RelTemplatesDir, "Dockerfile.license")
-	license, err := os.ReadFile(licenseFilePath)
+	license, err := vfs.ReadFile(licenseFilePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read the Dockerfile license file at path %s . Error: %q", licenseFilePath, err)
 	}
 	mavenBuildTemplatePath := filepath.Join(t.Env.GetEnvironmentContext(), t.Env.RelTemplatesDir, "Dockerfile.maven-build")
-	mavenBuildTemplate, err := os.ReadFile(mavenBuildTemplatePath)
+	mavenBuildTemplate, err := vfs.ReadFile(mavenBuildTemplatePath)
 	if err != nil {
 		return string(license), fmt.Errorf("failed to read the Dockerfile Maven build template file at path %s . Error: %q", mavenBuildTemplatePath, err)
 	}
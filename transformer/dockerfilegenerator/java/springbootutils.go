@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	"github.com/magiconair/properties"
 	"github.com/mikefarah/yq/v4/pkg/yqlib"
@@ -30,7 +31,6 @@ import (
 	"gopkg.in/yaml.v3"
 	"io"
 	"k8s.io/kubernetes/pkg/apis/core"
-	"os"
 	"path/filepath"
 	"strings"
 )
@@ -354,7 +354,7 @@ func getSpringBootAppNameProfilesAndPorts(springbootMetadataFiles SpringBootMeta
 }
 
 func getYamlDocumentsFromFile(filePath string) ([][]byte, error) {
-	fileBytes, err := os.ReadFile(filePath)
+	fileBytes, err := vfs.ReadFile(filePath)
 	if err != nil {
 		logrus.Errorf("failed to read file at path %s . Error: %q", filePath, err)
 		return nil, nil
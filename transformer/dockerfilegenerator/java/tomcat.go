@@ -19,12 +19,12 @@ package java
 import (
 	"fmt"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
-	"os"
 	"path/filepath"
 
 	"github.com/konveyor/move2kube-wasm/common"
 	"github.com/konveyor/move2kube-wasm/environment"
 	//irtypes "github.com/konveyor/move2kube-wasm/types/ir"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
 	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
 	"github.com/sirupsen/logrus"
@@ -121,12 +121,12 @@ func (t *Tomcat) Transform(newArtifacts []transformertypes.Artifact, alreadySeen
 			continue
 		}
 		tempDir := filepath.Join(t.Env.TempPath, newArtifact.Name)
-		if err := os.MkdirAll(tempDir, common.DefaultDirectoryPermission); err != nil {
+		if err := vfs.MkdirAll(tempDir, common.DefaultDirectoryPermission); err != nil {
 			logrus.Errorf("failed to create the temporary directory %s . Error: %q", tempDir, err)
 			continue
 		}
 		dockerfileTemplatePath := filepath.Join(tempDir, common.DefaultDockerfileName)
-		if err := os.WriteFile(dockerfileTemplatePath, []byte(dockerfileTemplate), common.DefaultFilePermission); err != nil {
+		if err := vfs.WriteFile(dockerfileTemplatePath, []byte(dockerfileTemplate), common.DefaultFilePermission); err != nil {
 			logrus.Errorf("failed to write the tomcat Dockerfile template to a temporary file at path %s . Error: %q", dockerfileTemplatePath, err)
 			continue
 		}
@@ -191,21 +191,21 @@ func (t *Tomcat) Transform(newArtifacts []transformertypes.Artifact, alreadySeen
 
 func (t *Tomcat) getDockerfileTemplate(newArtifact transformertypes.Artifact) (string, error) {
 	tomcatRunTemplatePath := filepath.Join(t.Env.GetEnvironmentContext(), t.Env.RelTemplatesDir, "Dockerfile.tomcat")
-	tomcatRunTemplate, err := os.ReadFile(tomcatRunTemplatePath)
+	tomcatRunTemplate, err := vfs.ReadFile(tomcatRunTemplatePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read the tomcat run stage Dockerfile template at path %s . Error: %q", tomcatRunTemplatePath, err)
 	}
 	dockerFileHead := ""
 	if buildContainerPaths := newArtifact.Paths[artifacts.BuildContainerFileType]; len(buildContainerPaths) > 0 {
 		dockerfileBuildPath := buildContainerPaths[0]
-		dockerFileHeadBytes, err := os.ReadFile(dockerfileBuildPath)
+		dockerFileHeadBytes, err := vfs.ReadFile(dockerfileBuildPath)
 		if err != nil {
 			return "", fmt.Errorf("failed to read the build stage Dockerfile template at path %s . Error: %q", dockerfileBuildPath, err)
 		}
 		dockerFileHead = string(dockerFileHeadBytes)
 	} else {
 		licenseFilePath := filepath.Join(t.Env.GetEnvironmentContext(), t.Env.RelTemplatesDir, "Dockerfile.license")
-		dockerFileHeadBytes, err := os.ReadFile(licenseFilePath)
+		dockerFileHeadBytes, err := vfs.ReadFile(licenseFilePath)
 		if err != nil {
 			return "", fmt.Errorf("failed to read the Dockerfile license at path %s . Error: %q", licenseFilePath, err)
 		}
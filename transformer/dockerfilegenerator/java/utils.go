@@ -17,6 +17,7 @@
 package java
 
 import (
+	"context"
 	"fmt"
 	"github.com/konveyor/move2kube-wasm/qaengine"
 
@@ -57,7 +58,7 @@ func getJavaPackage(mappingFile string, version string) (pkg string, err error)
 }
 
 // askUserForDockerfileType asks the user what type of Dockerfiles to generate.
-func askUserForDockerfileType(rootProjectName string) (buildOption, error) {
+func askUserForDockerfileType(ctx context.Context, rootProjectName string) (buildOption, error) {
 	quesId := common.JoinQASubKeys(common.ConfigServicesKey, `"`+rootProjectName+`"`, "dockerfileType")
 	desc := fmt.Sprintf("What type of Dockerfiles should be generated for the service '%s'?", rootProjectName)
 	options := []string{
@@ -71,7 +72,7 @@ func askUserForDockerfileType(rootProjectName string) (buildOption, error) {
 		fmt.Sprintf("[%s] Put the build stage in a separate Dockerfile and create a base image.", BUILD_IN_BASE_IMAGE),
 		fmt.Sprintf("[%s] Put the build stage in every Dockerfile to make it self contained. (Warning: This may cause one build per Dockerfile.)", BUILD_IN_EVERY_IMAGE),
 	}
-	selectedBuildOption := buildOption(qaengine.FetchSelectAnswer(quesId, desc, hints, string(def), options, nil))
+	selectedBuildOption := buildOption(qaengine.FetchSelectAnswer(ctx, quesId, desc, hints, string(def), options, nil))
 	switch selectedBuildOption {
 	case NO_BUILD_STAGE, BUILD_IN_BASE_IMAGE, BUILD_IN_EVERY_IMAGE:
 		return selectedBuildOption, nil
@@ -94,6 +94,10 @@ func (t *WarAnalyser) DirectoryDetect(dir string) (map[string][]transformertypes
 					JavaVersion:        t.WarConfig.JavaVersion,
 				},
 				artifacts.ImageNameConfigType: artifacts.ImageName{ImageName: imageName},
+				artifacts.DetectionConfigType: artifacts.DetectionConfig{
+					Confidence: artifacts.LowDetectionConfidence,
+					Reason:     "found a pre-built .war archive",
+				},
 			},
 		}
 		services[normalizedServiceName] = append(services[normalizedServiceName], newArtifact)
@@ -17,6 +17,7 @@
 package dockerfilegenerator
 
 import (
+	"context"
 	"fmt"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
@@ -96,7 +97,7 @@ type NodeVersionsMappingSpec struct {
 // Transformer
 // -----------------------------------------------------------------------------------
 
-// NodejsDockerfileGenerator implements the Transformer interface
+// NodejsDockerfileGenerator implements the ContextTransformer interface
 type NodejsDockerfileGenerator struct {
 	Config       transformertypes.Transformer
 	Env          *environment.Environment
@@ -121,7 +122,7 @@ const (
 )
 
 // Init Initializes the transformer
-func (t *NodejsDockerfileGenerator) Init(tc transformertypes.Transformer, env *environment.Environment) error {
+func (t *NodejsDockerfileGenerator) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) error {
 	t.Config = tc
 	t.Env = env
 
@@ -158,7 +159,7 @@ func (t *NodejsDockerfileGenerator) GetConfig() (transformertypes.Transformer, *
 }
 
 // DirectoryDetect runs detect in each sub directory
-func (t *NodejsDockerfileGenerator) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+func (t *NodejsDockerfileGenerator) DirectoryDetect(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
 	packageJsonPath := filepath.Join(dir, packageJSONFile)
 	packageJson := PackageJSON{}
 	if err := common.ReadJSON(packageJsonPath, &packageJson); err != nil {
@@ -175,6 +176,10 @@ func (t *NodejsDockerfileGenerator) DirectoryDetect(dir string) (map[string][]tr
 			Paths: map[transformertypes.PathType][]string{artifacts.ServiceDirPathType: {dir}},
 			Configs: map[transformertypes.ConfigType]interface{}{
 				artifacts.OriginalNameConfigType: artifacts.OriginalNameConfig{OriginalName: serviceName},
+				artifacts.DetectionConfigType: artifacts.DetectionConfig{
+					Confidence: artifacts.HighDetectionConfidence,
+					Reason:     "found a package.json with a name",
+				},
 			},
 		}},
 	}
@@ -182,10 +187,13 @@ func (t *NodejsDockerfileGenerator) DirectoryDetect(dir string) (map[string][]tr
 }
 
 // Transform transforms the artifacts
-func (t *NodejsDockerfileGenerator) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *NodejsDockerfileGenerator) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	artifactsCreated := []transformertypes.Artifact{}
 	for _, newArtifact := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		if len(newArtifact.Paths[artifacts.ServiceDirPathType]) == 0 {
 			continue
 		}
@@ -204,7 +212,7 @@ func (t *NodejsDockerfileGenerator) Transform(newArtifacts []transformertypes.Ar
 			logrus.Debugf("unable to load config for Transformer into %T . Error: %q", imageName, err)
 		}
 		if imageName.ImageName == "" {
-			imageName.ImageName = common.MakeStringContainerImageNameCompliant(serviceConfig.ServiceName)
+			imageName.ImageName = common.MakeStringContainerImageNameCompliant(serviceConfig.GetImageName())
 		}
 		ir := irtypes.IR{}
 		irPresent := true
@@ -255,7 +263,7 @@ func (t *NodejsDockerfileGenerator) Transform(newArtifacts []transformertypes.Ar
 				}
 			}
 		}
-		port := commonqa.GetPortForService(ports, `"`+newArtifact.Name+`"`)
+		port := commonqa.GetPortForService(ctx, ports, `"`+newArtifact.Name+`"`)
 		var props map[string]string
 		if idx := common.FindIndex(t.Spec.NodeVersions, func(x map[string]string) bool { return x[versionKey] == nodeVersion }); idx != -1 {
 			props = t.Spec.NodeVersions[idx]
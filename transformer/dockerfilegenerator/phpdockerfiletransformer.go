@@ -17,11 +17,11 @@
 package dockerfilegenerator
 
 import (
+	"context"
 	"fmt"
 	"github.com/konveyor/move2kube-wasm/qaengine"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -31,6 +31,7 @@ import (
 	"github.com/konveyor/move2kube-wasm/environment"
 	//irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	//"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
 	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
 	"github.com/sirupsen/logrus"
@@ -42,7 +43,7 @@ const (
 	confExt     = ".conf"
 )
 
-// PHPDockerfileGenerator implements the Transformer interface
+// PHPDockerfileGenerator implements the ContextTransformer interface
 type PHPDockerfileGenerator struct {
 	Config transformertypes.Transformer
 	Env    *environment.Environment
@@ -55,7 +56,7 @@ type PhpTemplateConfig struct {
 }
 
 // Init Initializes the transformer
-func (t *PHPDockerfileGenerator) Init(tc transformertypes.Transformer, env *environment.Environment) (err error) {
+func (t *PHPDockerfileGenerator) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) (err error) {
 	t.Config = tc
 	t.Env = env
 	return nil
@@ -69,7 +70,7 @@ func (t *PHPDockerfileGenerator) GetConfig() (transformertypes.Transformer, *env
 // parseConfFile parses the conf file to detect the port
 func parseConfFile(confFilePath string) (int32, error) {
 	var port int32
-	confFile, err := os.Open(confFilePath)
+	confFile, err := vfs.Open(confFilePath)
 	if err != nil {
 		logrus.Errorf("Could not open the apache config file: %s", err)
 		return port, err
@@ -106,7 +107,7 @@ func detectConfFiles(dir string) ([]string, error) {
 		return confFilesPaths, err
 	}
 	for _, confFilePath := range confFiles {
-		confFile, err := os.Open(confFilePath)
+		confFile, err := vfs.Open(confFilePath)
 		if err != nil {
 			logrus.Debugf("Could not open the conf file: %s", err)
 			confFile.Close()
@@ -129,13 +130,13 @@ func detectConfFiles(dir string) ([]string, error) {
 }
 
 // GetConfFileForService returns ports used by a service
-func GetConfFileForService(confFiles []string, serviceName string) string {
+func GetConfFileForService(ctx context.Context, confFiles []string, serviceName string) string {
 	noAnswer := "none of the above"
 	confFiles = append(confFiles, noAnswer)
 	quesKey := common.JoinQASubKeys(common.ConfigServicesKey, `"`+serviceName+`"`, common.ConfigApacheConfFileForServiceKeySegment)
 	desc := fmt.Sprintf("Choose the apache config file to be used for the service %s", serviceName)
 	hints := []string{fmt.Sprintf("Selected apache config file will be used for identifying the port to be exposed for the service %s", serviceName)}
-	selectedConfFile := qaengine.FetchSelectAnswer(quesKey, desc, hints, confFiles[0], confFiles, nil)
+	selectedConfFile := qaengine.FetchSelectAnswer(ctx, quesKey, desc, hints, confFiles[0], confFiles, nil)
 	if selectedConfFile == noAnswer {
 		logrus.Debugf("No apache config file selected for the service %s", serviceName)
 		return ""
@@ -144,7 +145,7 @@ func GetConfFileForService(confFiles []string, serviceName string) string {
 }
 
 // DirectoryDetect runs detect in each sub directory
-func (t *PHPDockerfileGenerator) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+func (t *PHPDockerfileGenerator) DirectoryDetect(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
 	phpFiles, err := common.GetFilesByExtInCurrDir(dir, []string{phpExt})
 	if err != nil {
 		return nil, fmt.Errorf("failed to look for .php files in the directory %s . Error: %q", dir, err)
@@ -161,6 +162,10 @@ func (t *PHPDockerfileGenerator) DirectoryDetect(dir string) (map[string][]trans
 			},
 			Configs: map[transformertypes.ConfigType]interface{}{
 				artifacts.OriginalNameConfigType: artifacts.OriginalNameConfig{OriginalName: serviceName},
+				artifacts.DetectionConfigType: artifacts.DetectionConfig{
+					Confidence: artifacts.LowDetectionConfidence,
+					Reason:     "found .php files",
+				},
 			},
 		}},
 	}
@@ -168,10 +173,13 @@ func (t *PHPDockerfileGenerator) DirectoryDetect(dir string) (map[string][]trans
 }
 
 // Transform transforms the artifacts
-func (t *PHPDockerfileGenerator) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *PHPDockerfileGenerator) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	artifactsCreated := []transformertypes.Artifact{}
 	for _, a := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		if len(a.Paths[artifacts.ServiceDirPathType]) == 0 {
 			continue
 		}
@@ -207,7 +215,7 @@ func (t *PHPDockerfileGenerator) Transform(newArtifacts []transformertypes.Artif
 			if len(confFiles) == 1 {
 				phpConfig.ConfFile = confFiles[0]
 			} else if len(confFiles) > 1 {
-				phpConfig.ConfFile = GetConfFileForService(confFiles, a.Name)
+				phpConfig.ConfFile = GetConfFileForService(ctx, confFiles, a.Name)
 			}
 			if phpConfig.ConfFile != "" {
 				phpConfig.ConfFilePort, err = parseConfFile(filepath.Join(a.Paths[artifacts.ServiceDirPathType][0], phpConfig.ConfFile))
@@ -216,11 +224,11 @@ func (t *PHPDockerfileGenerator) Transform(newArtifacts []transformertypes.Artif
 				}
 			}
 			if phpConfig.ConfFilePort == 0 {
-				phpConfig.ConfFilePort = commonqa.GetPortForService(detectedPorts, `"`+a.Name+`"`)
+				phpConfig.ConfFilePort = commonqa.GetPortForService(ctx, detectedPorts, `"`+a.Name+`"`)
 			}
 		}
 		if sImageName.ImageName == "" {
-			sImageName.ImageName = common.MakeStringContainerImageNameCompliant(sConfig.ServiceName)
+			sImageName.ImageName = common.MakeStringContainerImageNameCompliant(sConfig.GetImageName())
 		}
 		pathMappings = append(pathMappings, transformertypes.PathMapping{
 			Type:     transformertypes.SourcePathMappingType,
@@ -18,11 +18,11 @@ package dockerfilegenerator
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"github.com/konveyor/move2kube-wasm/qaengine"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
-	"os"
 	"path/filepath"
 	"regexp"
 
@@ -30,12 +30,13 @@ import (
 	"github.com/konveyor/move2kube-wasm/environment"
 	//irtypes "github.com/konveyor/move2kube/types/ir"
 	//"github.com/konveyor/move2kube/types/qaengine/commonqa"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
 	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
 	"github.com/sirupsen/logrus"
 )
 
-// PythonDockerfileGenerator implements the Transformer interface
+// PythonDockerfileGenerator implements the ContextTransformer interface
 type PythonDockerfileGenerator struct {
 	Config transformertypes.Transformer
 	Env    *environment.Environment
@@ -74,7 +75,7 @@ var (
 )
 
 // Init Initializes the transformer
-func (t *PythonDockerfileGenerator) Init(tc transformertypes.Transformer, env *environment.Environment) (err error) {
+func (t *PythonDockerfileGenerator) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) (err error) {
 	t.Config = tc
 	t.Env = env
 	return nil
@@ -92,7 +93,7 @@ func findMainScripts(pythonFilesPath []string) ([]string, error) {
 	}
 	pythonMainFiles := []string{}
 	for _, pythonFilePath := range pythonFilesPath {
-		pythonFile, err := os.Open(pythonFilePath)
+		pythonFile, err := vfs.Open(pythonFilePath)
 		if err != nil {
 			logrus.Debugf("failed to open the file at path %s . Error: %q", pythonFilePath, err)
 			continue
@@ -113,7 +114,7 @@ func findMainScripts(pythonFilesPath []string) ([]string, error) {
 
 // findDjangoDependency checks for django dependency in the requirements.txt file
 func findDjangoDependency(reqTxtFilePath string) bool {
-	reqTxtFile, err := os.ReadFile(reqTxtFilePath)
+	reqTxtFile, err := vfs.ReadFile(reqTxtFilePath)
 	if err != nil {
 		logrus.Warnf("failed to read the file at path %s . Error: %q", reqTxtFilePath, err)
 		return false
@@ -122,7 +123,7 @@ func findDjangoDependency(reqTxtFilePath string) bool {
 }
 
 // getMainPythonFileForService returns the main file used by a service
-func getMainPythonFileForService(mainPythonFilesPath []string, baseDir string, serviceName string) string {
+func getMainPythonFileForService(ctx context.Context, mainPythonFilesPath []string, baseDir string, serviceName string) string {
 	var mainPythonFilesRelPath []string
 	for _, mainPythonFilePath := range mainPythonFilesPath {
 		if mainPythonFileRelPath, err := filepath.Rel(baseDir, mainPythonFilePath); err == nil {
@@ -133,11 +134,11 @@ func getMainPythonFileForService(mainPythonFilesPath []string, baseDir string, s
 	quesKey := common.JoinQASubKeys(common.ConfigServicesKey, `"`+serviceName+`"`, common.ConfigMainPythonFileForServiceKeySegment)
 	desc := fmt.Sprintf("Select the main file to be used for the service %s :", serviceName)
 	hints := []string{fmt.Sprintf("Selected main file will be used for the service %s", serviceName)}
-	return qaengine.FetchSelectAnswer(quesKey, desc, hints, mainPythonFilesRelPath[0], mainPythonFilesRelPath, nil)
+	return qaengine.FetchSelectAnswer(ctx, quesKey, desc, hints, mainPythonFilesRelPath[0], mainPythonFilesRelPath, nil)
 }
 
 // getStartingPythonFileForService returns the starting python file used by a service
-func getStartingPythonFileForService(pythonFilesPath []string, baseDir string, serviceName string) string {
+func getStartingPythonFileForService(ctx context.Context, pythonFilesPath []string, baseDir string, serviceName string) string {
 	var pythonFilesRelPath []string
 	for _, pythonFilePath := range pythonFilesPath {
 		if pythonFileRelPath, err := filepath.Rel(baseDir, pythonFilePath); err == nil {
@@ -148,11 +149,11 @@ func getStartingPythonFileForService(pythonFilesPath []string, baseDir string, s
 	quesKey := common.JoinQASubKeys(common.ConfigServicesKey, `"`+serviceName+`"`, common.ConfigStartingPythonFileForServiceKeySegment)
 	desc := fmt.Sprintf("Select the python file to be used for the service %s :", serviceName)
 	hints := []string{fmt.Sprintf("Selected python file will be used for starting the service %s", serviceName)}
-	return qaengine.FetchSelectAnswer(quesKey, desc, hints, pythonFilesRelPath[0], pythonFilesRelPath, nil)
+	return qaengine.FetchSelectAnswer(ctx, quesKey, desc, hints, pythonFilesRelPath[0], pythonFilesRelPath, nil)
 }
 
 // DirectoryDetect runs detect in each sub directory
-func (t *PythonDockerfileGenerator) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+func (t *PythonDockerfileGenerator) DirectoryDetect(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
 	pythonFilesPath, err := common.GetFilesByExtInCurrDir(dir, []string{pythonExt})
 	if err != nil {
 		return nil, fmt.Errorf("failed to look for python files in the directory %s . Error: %q", dir, err)
@@ -173,6 +174,10 @@ func (t *PythonDockerfileGenerator) DirectoryDetect(dir string) (map[string][]tr
 			PythonFilesPathType:          pythonFilesPath,
 		}, Configs: map[transformertypes.ConfigType]interface{}{
 			artifacts.OriginalNameConfigType: artifacts.OriginalNameConfig{OriginalName: serviceName},
+			artifacts.DetectionConfigType: artifacts.DetectionConfig{
+				Confidence: artifacts.LowDetectionConfidence,
+				Reason:     "found .py files",
+			},
 		},
 	}
 
@@ -191,10 +196,13 @@ func (t *PythonDockerfileGenerator) DirectoryDetect(dir string) (map[string][]tr
 }
 
 // Transform transforms the artifacts
-func (t *PythonDockerfileGenerator) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *PythonDockerfileGenerator) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	artifactsCreated := []transformertypes.Artifact{}
 	for _, newArtifact := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		if len(newArtifact.Paths[artifacts.ServiceDirPathType]) == 0 {
 			logrus.Errorf("the service directory is missing from the artifact: %+v", newArtifact)
 			continue
@@ -215,7 +223,7 @@ func (t *PythonDockerfileGenerator) Transform(newArtifacts []transformertypes.Ar
 			logrus.Debugf("unable to load config for Transformer into %T : %s", imageName, err)
 		}
 		if imageName.ImageName == "" {
-			imageName.ImageName = common.MakeStringContainerImageNameCompliant(serviceConfig.ServiceName)
+			imageName.ImageName = common.MakeStringContainerImageNameCompliant(serviceConfig.GetImageName())
 		}
 
 		ir := irtypes.IR{}
@@ -226,9 +234,9 @@ func (t *PythonDockerfileGenerator) Transform(newArtifacts []transformertypes.Ar
 		}
 		var pythonTemplateConfig PythonTemplateConfig
 		if len(newArtifact.Paths[MainPythonFilesPathType]) > 0 {
-			pythonTemplateConfig.StartingScriptRelPath = getMainPythonFileForService(newArtifact.Paths[MainPythonFilesPathType], serviceDir, newArtifact.Name)
+			pythonTemplateConfig.StartingScriptRelPath = getMainPythonFileForService(ctx, newArtifact.Paths[MainPythonFilesPathType], serviceDir, newArtifact.Name)
 		} else {
-			pythonTemplateConfig.StartingScriptRelPath = getStartingPythonFileForService(newArtifact.Paths[PythonFilesPathType], serviceDir, newArtifact.Name)
+			pythonTemplateConfig.StartingScriptRelPath = getStartingPythonFileForService(ctx, newArtifact.Paths[PythonFilesPathType], serviceDir, newArtifact.Name)
 		}
 		pythonTemplateConfig.AppName = newArtifact.Name
 		var pythonConfig PythonConfig
@@ -242,7 +250,7 @@ func (t *PythonDockerfileGenerator) Transform(newArtifacts []transformertypes.Ar
 		if len(ports) == 0 {
 			ports = []int32{common.DefaultServicePort}
 		}
-		pythonTemplateConfig.Port = commonqa.GetPortForService(ports, `"`+newArtifact.Name+`"`)
+		pythonTemplateConfig.Port = commonqa.GetPortForService(ctx, ports, `"`+newArtifact.Name+`"`)
 		if len(newArtifact.Paths[artifacts.ServiceDirPathType]) == 0 {
 			logrus.Errorf("The service directory path is missing for the artifact: %+v", newArtifact)
 			continue
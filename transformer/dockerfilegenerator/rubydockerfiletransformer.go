@@ -17,6 +17,7 @@
 package dockerfilegenerator
 
 import (
+	"context"
 	"fmt"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
@@ -35,7 +36,7 @@ const (
 	rubyFileExt = ".rb"
 )
 
-// RubyDockerfileGenerator implements the Transformer interface
+// RubyDockerfileGenerator implements the ContextTransformer interface
 type RubyDockerfileGenerator struct {
 	Config transformertypes.Transformer
 	Env    *environment.Environment
@@ -48,7 +49,7 @@ type RubyTemplateConfig struct {
 }
 
 // Init Initializes the transformer
-func (t *RubyDockerfileGenerator) Init(tc transformertypes.Transformer, env *environment.Environment) (err error) {
+func (t *RubyDockerfileGenerator) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) (err error) {
 	t.Config = tc
 	t.Env = env
 	return nil
@@ -60,7 +61,7 @@ func (t *RubyDockerfileGenerator) GetConfig() (transformertypes.Transformer, *en
 }
 
 // DirectoryDetect runs detect in each sub directory
-func (t *RubyDockerfileGenerator) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+func (t *RubyDockerfileGenerator) DirectoryDetect(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
 	gemfilePaths, err := common.GetFilesByName(dir, []string{"Gemfile"}, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to look for Gemfiles in the dir %s . Error: %q", dir, err)
@@ -91,10 +92,13 @@ func (t *RubyDockerfileGenerator) DirectoryDetect(dir string) (map[string][]tran
 }
 
 // Transform transforms the artifacts
-func (t *RubyDockerfileGenerator) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *RubyDockerfileGenerator) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	artifactsCreated := []transformertypes.Artifact{}
 	for _, a := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		if len(a.Paths[artifacts.ServiceDirPathType]) == 0 {
 			continue
 		}
@@ -126,10 +130,10 @@ func (t *RubyDockerfileGenerator) Transform(newArtifacts []transformertypes.Arti
 		if len(detectedPorts) == 0 {
 			detectedPorts = append(detectedPorts, common.DefaultServicePort)
 		}
-		rubyConfig.Port = commonqa.GetPortForService(detectedPorts, `"`+a.Name+`"`)
+		rubyConfig.Port = commonqa.GetPortForService(ctx, detectedPorts, `"`+a.Name+`"`)
 		rubyConfig.AppName = a.Name
 		if sImageName.ImageName == "" {
-			sImageName.ImageName = common.MakeStringContainerImageNameCompliant(sConfig.ServiceName)
+			sImageName.ImageName = common.MakeStringContainerImageNameCompliant(sConfig.GetImageName())
 		}
 		pathMappings = append(pathMappings, transformertypes.PathMapping{
 			Type:     transformertypes.SourcePathMappingType,
@@ -17,10 +17,10 @@
 package dockerfilegenerator
 
 import (
+	"context"
 	"fmt"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
-	"os"
 	"path/filepath"
 
 	"github.com/BurntSushi/toml"
@@ -28,12 +28,13 @@ import (
 	"github.com/konveyor/move2kube-wasm/environment"
 	//irtypes "github.com/konveyor/move2kube/types/ir"
 	//"github.com/konveyor/move2kube/types/qaengine/commonqa"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
 	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
 	"github.com/sirupsen/logrus"
 )
 
-// RustDockerfileGenerator implements the Transformer interface
+// RustDockerfileGenerator implements the ContextTransformer interface
 type RustDockerfileGenerator struct {
 	Config transformertypes.Transformer
 	Env    *environment.Environment
@@ -75,7 +76,7 @@ type GlobalParameters struct {
 }
 
 // Init Initializes the transformer
-func (t *RustDockerfileGenerator) Init(tc transformertypes.Transformer, env *environment.Environment) (err error) {
+func (t *RustDockerfileGenerator) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) (err error) {
 	t.Config = tc
 	t.Env = env
 	return nil
@@ -87,9 +88,9 @@ func (t *RustDockerfileGenerator) GetConfig() (transformertypes.Transformer, *en
 }
 
 // DirectoryDetect runs detect in each sub directory
-func (t *RustDockerfileGenerator) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+func (t *RustDockerfileGenerator) DirectoryDetect(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
 	cargoPath := filepath.Join(dir, cargoTomlFile)
-	if _, err := os.Stat(cargoPath); err != nil {
+	if _, err := vfs.Stat(cargoPath); err != nil {
 		return nil, nil
 	}
 	cargoTomlConfig := CargoTomlConfig{}
@@ -105,6 +106,10 @@ func (t *RustDockerfileGenerator) DirectoryDetect(dir string) (map[string][]tran
 			},
 			Configs: map[transformertypes.ConfigType]interface{}{
 				artifacts.OriginalNameConfigType: artifacts.OriginalNameConfig{OriginalName: serviceName},
+				artifacts.DetectionConfigType: artifacts.DetectionConfig{
+					Confidence: artifacts.HighDetectionConfidence,
+					Reason:     "found a Cargo.toml",
+				},
 			},
 		}},
 	}
@@ -112,10 +117,13 @@ func (t *RustDockerfileGenerator) DirectoryDetect(dir string) (map[string][]tran
 }
 
 // Transform transforms the artifacts
-func (t *RustDockerfileGenerator) Transform(newArtifacts []transformertypes.Artifact, oldArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *RustDockerfileGenerator) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, oldArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	artifactsCreated := []transformertypes.Artifact{}
 	for _, a := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		if len(a.Paths[artifacts.ServiceDirPathType]) == 0 {
 			continue
 		}
@@ -146,7 +154,7 @@ func (t *RustDockerfileGenerator) Transform(newArtifacts []transformertypes.Arti
 		var rustConfig RustTemplateConfig
 		rustConfig.AppName = a.Name
 		rocketTomlFilePath := filepath.Join(a.Paths[artifacts.ServiceDirPathType][0], rocketTomlFile)
-		if _, err := os.Stat(rocketTomlFilePath); err == nil {
+		if _, err := vfs.Stat(rocketTomlFilePath); err == nil {
 			rustConfig.RocketToml = rocketTomlFile
 			var rocketTomlConfig RocketTomlConfig
 			if _, err := toml.DecodeFile(rocketTomlFilePath, &rocketTomlConfig); err == nil {
@@ -157,9 +165,9 @@ func (t *RustDockerfileGenerator) Transform(newArtifacts []transformertypes.Arti
 		if len(ports) == 0 {
 			ports = append(ports, common.DefaultServicePort)
 		}
-		rustConfig.Port = commonqa.GetPortForService(ports, `"`+a.Name+`"`)
+		rustConfig.Port = commonqa.GetPortForService(ctx, ports, `"`+a.Name+`"`)
 		if sImageName.ImageName == "" {
-			sImageName.ImageName = common.MakeStringContainerImageNameCompliant(sConfig.ServiceName)
+			sImageName.ImageName = common.MakeStringContainerImageNameCompliant(sConfig.GetImageName())
 		}
 		pathMappings = append(pathMappings, transformertypes.PathMapping{
 			Type:     transformertypes.SourcePathMappingType,
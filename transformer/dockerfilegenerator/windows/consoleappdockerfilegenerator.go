@@ -17,6 +17,7 @@
 package windows
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
@@ -24,7 +25,6 @@ import (
 	"io"
 	"net"
 	"net/url"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -34,6 +34,7 @@ import (
 	dotnetutils "github.com/konveyor/move2kube-wasm/transformer/dockerfilegenerator/dotnet"
 	//irtypes "github.com/konveyor/move2kube/types/ir"
 	//"github.com/konveyor/move2kube/types/qaengine/commonqa"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/types/source/dotnet"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
 	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
@@ -55,14 +56,14 @@ type ConsoleTemplateConfig struct {
 	BaseImageVersion string
 }
 
-// WinConsoleAppDockerfileGenerator implements the Transformer interface
+// WinConsoleAppDockerfileGenerator implements the ContextTransformer interface
 type WinConsoleAppDockerfileGenerator struct {
 	Config transformertypes.Transformer
 	Env    *environment.Environment
 }
 
 // Init Initializes the transformer
-func (t *WinConsoleAppDockerfileGenerator) Init(tc transformertypes.Transformer, env *environment.Environment) (err error) {
+func (t *WinConsoleAppDockerfileGenerator) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) (err error) {
 	t.Config = tc
 	t.Env = env
 	return nil
@@ -75,7 +76,7 @@ func (t *WinConsoleAppDockerfileGenerator) GetConfig() (transformertypes.Transfo
 
 // parseAppConfig parses the application config
 func (t *WinConsoleAppDockerfileGenerator) parseAppConfigForPort(AppCfgFilePath string) ([]int32, error) {
-	appConfigFile, err := os.Open(AppCfgFilePath)
+	appConfigFile, err := vfs.Open(AppCfgFilePath)
 	if err != nil {
 		return nil, fmt.Errorf("could not open the App.config file: %s", err)
 	}
@@ -154,7 +155,7 @@ func (t *WinConsoleAppDockerfileGenerator) parseAppConfigForPort(AppCfgFilePath
 }
 
 // DirectoryDetect runs detect in each sub directory
-func (t *WinConsoleAppDockerfileGenerator) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+func (t *WinConsoleAppDockerfileGenerator) DirectoryDetect(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
 	slnPaths, err := common.GetFilesByExtInCurrDir(dir, []string{dotnet.VISUAL_STUDIO_SOLUTION_FILE_EXT})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list the dot net visual studio solution files in the directory %s . Error: %q", dir, err)
@@ -183,7 +184,7 @@ func (t *WinConsoleAppDockerfileGenerator) DirectoryDetect(dir string) (map[stri
 	found := false
 	for _, relCSProjPath := range relCSProjPaths {
 		csProjPath := filepath.Join(dir, strings.TrimSpace(relCSProjPath))
-		csProjBytes, err := os.ReadFile(csProjPath)
+		csProjBytes, err := vfs.ReadFile(csProjPath)
 		if err != nil {
 			logrus.Errorf("failed to read the c sharp project file at path %s . Error: %q", csProjPath, err)
 			continue
@@ -216,7 +217,7 @@ func (t *WinConsoleAppDockerfileGenerator) DirectoryDetect(dir string) (map[stri
 		}
 		found = true
 		appCfgFilePath := filepath.Join(dir, filepath.Dir(relCSProjPath), AppCfgFile)
-		if _, err := os.Stat(appCfgFilePath); err != nil {
+		if _, err := vfs.Stat(appCfgFilePath); err != nil {
 			continue
 		}
 		appCfgFilePaths = append(appCfgFilePaths, appCfgFilePath)
@@ -241,10 +242,13 @@ func (t *WinConsoleAppDockerfileGenerator) DirectoryDetect(dir string) (map[stri
 }
 
 // Transform transforms the artifacts
-func (t *WinConsoleAppDockerfileGenerator) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *WinConsoleAppDockerfileGenerator) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	artifactsCreated := []transformertypes.Artifact{}
 	for _, newArtifact := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		if len(newArtifact.Paths[artifacts.ServiceDirPathType]) == 0 {
 			continue
 		}
@@ -283,14 +287,14 @@ func (t *WinConsoleAppDockerfileGenerator) Transform(newArtifacts []transformert
 		if len(detectedPorts) == 0 {
 			detectedPorts = ir.GetAllServicePorts()
 		}
-		detectedPorts = commonqa.GetPortsForService(detectedPorts, `"`+newArtifact.Name+`"`)
+		detectedPorts = commonqa.GetPortsForService(ctx, detectedPorts, `"`+newArtifact.Name+`"`)
 		var consoleConfig ConsoleTemplateConfig
 		consoleConfig.AppName = newArtifact.Name
 		consoleConfig.Ports = detectedPorts
 		consoleConfig.BaseImageVersion = dotnet.DefaultBaseImageVersion
 
 		if imageName.ImageName == "" {
-			imageName.ImageName = common.MakeStringContainerImageNameCompliant(serviceConfig.ServiceName)
+			imageName.ImageName = common.MakeStringContainerImageNameCompliant(serviceConfig.GetImageName())
 		}
 		pathMappings = append(pathMappings, transformertypes.PathMapping{
 			Type:     transformertypes.SourcePathMappingType,
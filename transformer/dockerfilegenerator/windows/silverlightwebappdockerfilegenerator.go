@@ -17,11 +17,11 @@
 package windows
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -30,6 +30,7 @@ import (
 	dotnetutils "github.com/konveyor/move2kube-wasm/transformer/dockerfilegenerator/dotnet"
 	//irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	//"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/types/source/dotnet"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
 	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
@@ -42,14 +43,14 @@ type SilverLightTemplateConfig struct {
 	AppName string
 }
 
-// WinSilverLightWebAppDockerfileGenerator implements the Transformer interface
+// WinSilverLightWebAppDockerfileGenerator implements the ContextTransformer interface
 type WinSilverLightWebAppDockerfileGenerator struct {
 	Config transformertypes.Transformer
 	Env    *environment.Environment
 }
 
 // Init Initializes the transformer
-func (t *WinSilverLightWebAppDockerfileGenerator) Init(tc transformertypes.Transformer, env *environment.Environment) (err error) {
+func (t *WinSilverLightWebAppDockerfileGenerator) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) (err error) {
 	t.Config = tc
 	t.Env = env
 	return nil
@@ -61,7 +62,7 @@ func (t *WinSilverLightWebAppDockerfileGenerator) GetConfig() (transformertypes.
 }
 
 // DirectoryDetect runs detect in each sub directory
-func (t *WinSilverLightWebAppDockerfileGenerator) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+func (t *WinSilverLightWebAppDockerfileGenerator) DirectoryDetect(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
 	slnPaths, err := common.GetFilesByExtInCurrDir(dir, []string{dotnet.VISUAL_STUDIO_SOLUTION_FILE_EXT})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list the dot net visual studio solution files in the directory %s . Error: %q", dir, err)
@@ -88,7 +89,7 @@ func (t *WinSilverLightWebAppDockerfileGenerator) DirectoryDetect(dir string) (m
 	found := false
 	for _, relCSProjPath := range relCSProjPaths {
 		csProjPath := filepath.Join(dir, strings.TrimSpace(relCSProjPath))
-		csProjBytes, err := os.ReadFile(csProjPath)
+		csProjBytes, err := vfs.ReadFile(csProjPath)
 		if err != nil {
 			logrus.Errorf("failed to read the c sharp project file at path %s . Error: %q", csProjPath, err)
 			continue
@@ -150,10 +151,13 @@ func (t *WinSilverLightWebAppDockerfileGenerator) DirectoryDetect(dir string) (m
 }
 
 // Transform transforms the artifacts
-func (t *WinSilverLightWebAppDockerfileGenerator) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *WinSilverLightWebAppDockerfileGenerator) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	artifactsCreated := []transformertypes.Artifact{}
 	for _, a := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		relSrcPath, err := filepath.Rel(t.Env.GetEnvironmentSource(), a.Paths[artifacts.ServiceDirPathType][0])
 		if err != nil {
 			logrus.Errorf("Unable to convert source path %s to be relative : %s", a.Paths[artifacts.ServiceDirPathType][0], err)
@@ -180,12 +184,12 @@ func (t *WinSilverLightWebAppDockerfileGenerator) Transform(newArtifacts []trans
 		if len(detectedPorts) == 0 {
 			detectedPorts = append(detectedPorts, common.DefaultServicePort)
 		}
-		detectedPorts = commonqa.GetPortsForService(detectedPorts, `"`+a.Name+`"`)
+		detectedPorts = commonqa.GetPortsForService(ctx, detectedPorts, `"`+a.Name+`"`)
 		var silverLightConfig SilverLightTemplateConfig
 		silverLightConfig.AppName = a.Name
 		silverLightConfig.Ports = detectedPorts
 		if sImageName.ImageName == "" {
-			sImageName.ImageName = common.MakeStringContainerImageNameCompliant(sConfig.ServiceName)
+			sImageName.ImageName = common.MakeStringContainerImageNameCompliant(sConfig.GetImageName())
 		}
 		pathMappings = append(pathMappings, transformertypes.PathMapping{
 			Type:     transformertypes.SourcePathMappingType,
@@ -17,6 +17,7 @@
 package windows
 
 import (
+	"context"
 	"fmt"
 	"github.com/konveyor/move2kube-wasm/qaengine"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
@@ -61,7 +62,7 @@ type WebTemplateConfig struct {
 	CopyFrom           string
 }
 
-// WinWebAppDockerfileGenerator implements the Transformer interface
+// WinWebAppDockerfileGenerator implements the ContextTransformer interface
 type WinWebAppDockerfileGenerator struct {
 	Config                      transformertypes.Transformer
 	Env                         *environment.Environment
@@ -69,7 +70,7 @@ type WinWebAppDockerfileGenerator struct {
 }
 
 // Init Initializes the transformer
-func (t *WinWebAppDockerfileGenerator) Init(tc transformertypes.Transformer, env *environment.Environment) error {
+func (t *WinWebAppDockerfileGenerator) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) error {
 	t.Config = tc
 	t.Env = env
 	mappingFile := DotNetWindowsVersionMapping{}
@@ -90,7 +91,7 @@ func (t *WinWebAppDockerfileGenerator) GetConfig() (transformertypes.Transformer
 }
 
 // DirectoryDetect runs detect in each sub directory
-func (t *WinWebAppDockerfileGenerator) DirectoryDetect(dir string) (map[string][]transformertypes.Artifact, error) {
+func (t *WinWebAppDockerfileGenerator) DirectoryDetect(ctx context.Context, dir string) (map[string][]transformertypes.Artifact, error) {
 	slnPaths, err := common.GetFilesByExtInCurrDir(dir, []string{dotnet.VISUAL_STUDIO_SOLUTION_FILE_EXT})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list the dot net visual studio solution files in the directory %s . Error: %q", dir, err)
@@ -237,10 +238,13 @@ func (t *WinWebAppDockerfileGenerator) DirectoryDetect(dir string) (map[string][
 }
 
 // Transform transforms the artifacts
-func (t *WinWebAppDockerfileGenerator) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+func (t *WinWebAppDockerfileGenerator) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
 	pathMappings := []transformertypes.PathMapping{}
 	artifactsCreated := []transformertypes.Artifact{}
 	for _, newArtifact := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		dotNetConfig := artifacts.DotNetConfig{}
 		if err := newArtifact.GetConfig(artifacts.DotNetConfigType, &dotNetConfig); err != nil || dotNetConfig.IsDotNetCore {
 			continue
@@ -249,7 +253,7 @@ func (t *WinWebAppDockerfileGenerator) Transform(newArtifacts []transformertypes
 			logrus.Errorf("the service directory is missing from the dot net artifact %+v", newArtifact)
 			continue
 		}
-		selectedBuildOption, err := dotnetutils.AskUserForDockerfileType(newArtifact.Name)
+		selectedBuildOption, err := dotnetutils.AskUserForDockerfileType(ctx, newArtifact.Name)
 		if err != nil {
 			logrus.Errorf("failed to ask the user what type of dockerfile they prefer. Error: %q", err)
 			continue
@@ -266,7 +270,7 @@ func (t *WinWebAppDockerfileGenerator) Transform(newArtifacts []transformertypes
 			quesKey := fmt.Sprintf(common.ConfigServicesDotNetChildProjectsNamesKey, `"`+newArtifact.Name+`"`)
 			desc := fmt.Sprintf("For the multi-project Dot Net app '%s', please select all the child projects that should be run as services in the cluster:", newArtifact.Name)
 			hints := []string{"deselect any child project that should not be run (example: libraries)"}
-			selectedChildProjectNames = qaengine.FetchMultiSelectAnswer(quesKey, desc, hints, selectedChildProjectNames, selectedChildProjectNames, nil)
+			selectedChildProjectNames = qaengine.FetchMultiSelectAnswer(ctx, quesKey, desc, hints, selectedChildProjectNames, selectedChildProjectNames, nil)
 			if len(selectedChildProjectNames) == 0 {
 				return pathMappings, artifactsCreated, fmt.Errorf("user deselected all the child projects of the dot net multi-project app '%s'", newArtifact.Name)
 			}
@@ -300,6 +304,10 @@ func (t *WinWebAppDockerfileGenerator) Transform(newArtifacts []transformertypes
 		// build is always done at the top level using the .sln file regardless of the build option selected
 
 		imageToCopyFrom := common.MakeStringContainerImageNameCompliant(newArtifact.Name + "-" + buildStageC)
+		parentServiceConfig := artifacts.ServiceConfig{}
+		if err := newArtifact.GetConfig(artifacts.ServiceConfigType, &parentServiceConfig); err != nil {
+			logrus.Debugf("unable to load config for Transformer into %T . Error: %q", parentServiceConfig, err)
+		}
 		if selectedBuildOption == dotnetutils.NO_BUILD_STAGE {
 			imageToCopyFrom = "" // files will be copied from the local file system instead of a builder image
 		}
@@ -325,7 +333,7 @@ func (t *WinWebAppDockerfileGenerator) Transform(newArtifacts []transformertypes
 			}
 
 			// have the user select the ports to use for the child project
-			selectedPorts := commonqa.GetPortsForService(detectedPorts, common.JoinQASubKeys(`"`+newArtifact.Name+`"`, "childProjects", `"`+childProject.Name+`"`))
+			selectedPorts := commonqa.GetPortsForService(ctx, detectedPorts, common.JoinQASubKeys(`"`+newArtifact.Name+`"`, "childProjects", `"`+childProject.Name+`"`))
 			// data to fill the Dockerfile template
 
 			relCSProjDir := filepath.Dir(childProject.RelCSProjPath)
@@ -374,8 +382,11 @@ func (t *WinWebAppDockerfileGenerator) Transform(newArtifacts []transformertypes
 			// artifacts to inform other transformers of the Dockerfile we generated
 
 			paths := map[transformertypes.PathType][]string{artifacts.DockerfilePathType: {dockerfilePath}}
-			serviceName := artifacts.ServiceConfig{ServiceName: childProject.Name}
+			serviceName := parentServiceConfig.GetChildServiceConfig(childProject.Name)
 			imageName := artifacts.ImageName{ImageName: common.MakeStringContainerImageNameCompliant(childProject.Name)}
+			if serviceName.ContainerName != "" {
+				imageName.ImageName = common.MakeStringContainerImageNameCompliant(serviceName.GetImageName())
+			}
 			dockerfileArtifact := transformertypes.Artifact{
 				Name:  imageName.ImageName,
 				Type:  artifacts.DockerfileArtifactType,
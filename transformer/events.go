@@ -0,0 +1,58 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"sort"
+
+	"github.com/konveyor/move2kube-wasm/types/event"
+	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
+	plantypes "github.com/konveyor/move2kube-wasm/types/plan"
+	"github.com/spf13/cast"
+)
+
+// publishDetectedServices publishes an event for every service detected by the transformer in the directory
+func publishDetectedServices(services map[string][]plantypes.PlanArtifact, transformerName string, dir string) {
+	serviceNames := []string{}
+	for serviceName := range services {
+		serviceNames = append(serviceNames, serviceName)
+	}
+	sort.Strings(serviceNames)
+	for _, serviceName := range serviceNames {
+		event.Publish(event.Event{Type: event.ServiceDetected, Transformer: transformerName, Directory: dir, Service: serviceName})
+	}
+}
+
+// publishTransformerFinished publishes the vertex and the edges added to the graph for a transformer run
+func publishTransformerFinished(graph *graphtypes.Graph, vertexId int, edgeIds []int, transformerName string, numArtifacts int, err error) {
+	vertex := graph.Vertices[vertexId]
+	e := event.Event{
+		Type:         event.TransformerFinished,
+		Transformer:  transformerName,
+		Iteration:    vertex.Iteration,
+		NumArtifacts: numArtifacts,
+		Duration:     cast.ToString(vertex.Data[graphtypes.GraphDurationKey]),
+		Vertex:       &vertex,
+	}
+	for _, edgeId := range edgeIds {
+		e.Edges = append(e.Edges, graph.Edges[edgeId])
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	event.Publish(e)
+}
@@ -16,9 +16,156 @@
 
 package external
 
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
+	"github.com/konveyor/move2kube-wasm/environment"
+	containertypes "github.com/konveyor/move2kube-wasm/environment/container"
+	environmenttypes "github.com/konveyor/move2kube-wasm/types/environment"
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DetectInputPathEnvName is the environment variable containing the path of the json file with the input of the detect command
+	DetectInputPathEnvName = "M2K_DETECT_INPUT_PATH"
+	// DetectOutputPathEnvName is the environment variable containing the path where the detect command writes its output as json
+	DetectOutputPathEnvName = "M2K_DETECT_OUTPUT_PATH"
+	// TransformInputPathEnvName is the environment variable containing the path of the json file with the input of the transform command
+	TransformInputPathEnvName = "M2K_TRANSFORM_INPUT_PATH"
+	// TransformOutputPathEnvName is the environment variable containing the path where the transform command writes its output as json
+	TransformOutputPathEnvName = "M2K_TRANSFORM_OUTPUT_PATH"
+)
+
 var (
 	// DetectContainerOutputDir is the directory where external transformer detect output is stored
 	DetectContainerOutputDir = "/var/tmp/m2k_detect_output"
 	// TransformContainerOutputDir is the directory where external transformer transform output is stored
 	TransformContainerOutputDir = "/var/tmp/m2k_transform_output"
 )
+
+// Executable implements ContextTransformer interface using commands that are run in the directory of the transformer.
+// The commands are killed when the context of the call is done.
+type Executable struct {
+	Config     transformertypes.Transformer
+	Env        *environment.Environment
+	ExecConfig *ExecutableYamlConfig
+}
+
+// ExecutableYamlConfig is the format of executable yaml config
+type ExecutableYamlConfig struct {
+	Platforms []string `yaml:"platforms"`
+	// DirectoryDetectCMD is run for every directory. It reads {"InputDirectory": dir} from the file in M2K_DETECT_INPUT_PATH
+	// and writes the services found in the directory to the file in M2K_DETECT_OUTPUT_PATH
+	DirectoryDetectCMD environmenttypes.Command `yaml:"directoryDetectCMD"`
+	// TransformCMD reads {"newArtifacts": [...], "oldArtifacts": [...]} from the file in M2K_TRANSFORM_INPUT_PATH
+	// and writes {"pathMappings": [...], "artifacts": [...]} to the file in M2K_TRANSFORM_OUTPUT_PATH
+	TransformCMD environmenttypes.Command   `yaml:"transformCMD"`
+	Container    environmenttypes.Container `yaml:"container,omitempty"`
+}
+
+type detectInput struct {
+	InputDirectory string `json:"InputDirectory"`
+}
+
+type transformInput struct {
+	NewArtifacts []transformertypes.Artifact `json:"newArtifacts"`
+	OldArtifacts []transformertypes.Artifact `json:"oldArtifacts"`
+}
+
+// Init initializes the transformer
+func (t *Executable) Init(ctx context.Context, tc transformertypes.Transformer, env *environment.Environment) (err error) {
+	t.Config = tc
+	t.Env = env
+	t.ExecConfig = &ExecutableYamlConfig{}
+	if err := common.GetObjFromInterface(t.Config.Spec.Config, t.ExecConfig); err != nil {
+		return fmt.Errorf("unable to load config for Transformer %+v into %T . Error: %w", t.Config.Spec.Config, t.ExecConfig, err)
+	}
+	if t.ExecConfig.Container.Image != "" {
+		return fmt.Errorf("the transformer runs its commands in the container image '%s' . Error: %w", t.ExecConfig.Container.Image, containertypes.ErrNoContainerRuntime)
+	}
+	if len(t.ExecConfig.Platforms) > 0 && !common.IsPresent(t.ExecConfig.Platforms, runtime.GOOS) {
+		return fmt.Errorf("the platform '%s' is not one of the platforms %+v supported by the transformer", runtime.GOOS, t.ExecConfig.Platforms)
+	}
+	if err := common.CheckSupported("executing commands"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetConfig returns the transformer config
+func (t *Executable) GetConfig() (transformertypes.Transformer, *environment.Environment) {
+	return t.Config, t.Env
+}
+
+// DirectoryDetect runs the detect command in the directory
+func (t *Executable) DirectoryDetect(ctx context.Context, dir string) (services map[string][]transformertypes.Artifact, err error) {
+	if len(t.ExecConfig.DirectoryDetectCMD) == 0 {
+		return nil, nil
+	}
+	services = map[string][]transformertypes.Artifact{}
+	if err := t.execute(ctx, t.ExecConfig.DirectoryDetectCMD, DetectInputPathEnvName, DetectOutputPathEnvName, detectInput{InputDirectory: dir}, &services); err != nil {
+		return nil, fmt.Errorf("failed to detect the services in the directory %s . Error: %w", dir, err)
+	}
+	return services, nil
+}
+
+// Transform runs the transform command on the artifacts
+func (t *Executable) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) ([]transformertypes.PathMapping, []transformertypes.Artifact, error) {
+	if len(t.ExecConfig.TransformCMD) == 0 {
+		return nil, nil, nil
+	}
+	output := transformertypes.TransformOutput{}
+	input := transformInput{NewArtifacts: newArtifacts, OldArtifacts: alreadySeenArtifacts}
+	if err := t.execute(ctx, t.ExecConfig.TransformCMD, TransformInputPathEnvName, TransformOutputPathEnvName, input, &output); err != nil {
+		return nil, nil, fmt.Errorf("failed to transform the artifacts. Error: %w", err)
+	}
+	return output.PathMappings, output.CreatedArtifacts, nil
+}
+
+// execute writes the input to a json file, runs the command and reads the output from the json file written by the command.
+// The paths of the files are passed to the command using environment variables.
+// A command that does not write the output file has no output.
+func (t *Executable) execute(ctx context.Context, cmd environmenttypes.Command, inputPathEnvName, outputPathEnvName string, input interface{}, output interface{}) error {
+	tempPath, err := vfs.MkdirTemp(t.Env.TempPath, "exec-")
+	if err != nil {
+		return fmt.Errorf("failed to create a temporary directory for the input and output of the command. Error: %w", err)
+	}
+	defer vfs.RemoveAll(tempPath)
+	inputPath := filepath.Join(tempPath, "input.json")
+	outputPath := filepath.Join(tempPath, "output.json")
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the input of the command to json. Error: %w", err)
+	}
+	if err := vfs.WriteFile(inputPath, inputBytes, common.DefaultFilePermission); err != nil {
+		return fmt.Errorf("failed to write the input of the command to the file %s . Error: %w", inputPath, err)
+	}
+	stdout, stderr, exitcode, err := t.Env.Exec(ctx, cmd, []string{inputPathEnvName + "=" + inputPath, outputPathEnvName + "=" + outputPath})
+	if err != nil {
+		return fmt.Errorf("failed to execute the command %+v . Error: %w", cmd, err)
+	}
+	logrus.Debugf("the command %+v of the transformer '%s' exited with the code %d . stdout: %s , stderr: %s", cmd, t.Config.Name, exitcode, stdout, stderr)
+	if exitcode != 0 {
+		return fmt.Errorf("the command %+v exited with the code %d . stderr: %s", cmd, exitcode, stderr)
+	}
+	outputBytes, err := vfs.ReadFile(outputPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			logrus.Debugf("the command %+v of the transformer '%s' did not write any output", cmd, t.Config.Name)
+			return nil
+		}
+		return fmt.Errorf("failed to read the output of the command from the file %s . Error: %w", outputPath, err)
+	}
+	if err := json.Unmarshal(outputBytes, output); err != nil {
+		return fmt.Errorf("failed to parse the output of the command as json. Output: %s . Error: %w", string(outputBytes), err)
+	}
+	return nil
+}
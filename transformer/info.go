@@ -0,0 +1,167 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+	"github.com/spf13/cast"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// BuiltInTransformerSource is the source of the transformers that ship with move2kube
+	BuiltInTransformerSource = "built-in"
+	// CustomTransformerSource is the source of the transformers in the customizations directory
+	CustomTransformerSource = "custom"
+)
+
+// TransformerInfo describes a transformer and whether it would be used
+type TransformerInfo struct {
+	Name              string            `json:"name"`
+	Class             string            `json:"class"`
+	Source            string            `json:"source"`
+	YamlPath          string            `json:"yamlPath"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	ConsumedArtifacts []string          `json:"consumes"`
+	ProducedArtifacts []string          `json:"produces"`
+	SelectedByDefault bool              `json:"selectedByDefault"`
+	ContainerBased    bool              `json:"containerBased"`
+	InvokedByDefault  bool              `json:"invokedByDefault"`
+	DetectsServices   bool              `json:"detectsServices"`
+	// Filtered is true if the transformer would not be used, FilteredReason explains why
+	Filtered       bool   `json:"filtered"`
+	FilteredReason string `json:"filteredReason,omitempty"`
+}
+
+// GetTransformerInfos describes all the transformers in the assets directory and which of them are filtered by the selector
+func GetTransformerInfos(assetsPath string, transformerYamlPaths map[string]string, selector labels.Selector) []TransformerInfo {
+	allConfigs := loadTransformerConfigs(transformerYamlPaths, nil)
+	activeConfigs := getFilteredTransformers(transformerYamlPaths, selector, false)
+	infos := []TransformerInfo{}
+	for _, tc := range allConfigs {
+		info := TransformerInfo{
+			Name:              tc.Name,
+			Class:             tc.Spec.Class,
+			Source:            BuiltInTransformerSource,
+			YamlPath:          tc.Spec.TransformerYamlPath,
+			Labels:            tc.Labels,
+			ConsumedArtifacts: []string{},
+			ProducedArtifacts: []string{},
+			SelectedByDefault: true,
+			InvokedByDefault:  tc.Spec.InvokedByDefault.Enabled,
+			DetectsServices:   tc.Spec.DirectoryDetect.Levels != 0,
+		}
+		if relPath, err := filepath.Rel(assetsPath, tc.Spec.TransformerYamlPath); err == nil {
+			info.YamlPath = relPath
+		}
+		info.Source = getTransformerSource(assetsPath, tc.Spec.TransformerYamlPath)
+		if v, ok := tc.Labels[DEFAULT_SELECTED_LABEL]; ok {
+			info.SelectedByDefault = cast.ToBool(v)
+		}
+		if v, ok := tc.Labels[CONTAINER_BASED_LABEL]; ok {
+			info.ContainerBased = cast.ToBool(v)
+		}
+		for _, artifactType := range getSortedTypes(tc.Spec.ConsumedArtifacts) {
+			if !tc.Spec.ConsumedArtifacts[artifactType].Disabled {
+				info.ConsumedArtifacts = append(info.ConsumedArtifacts, string(artifactType))
+			}
+		}
+		for _, artifactType := range getSortedTypes(tc.Spec.ProducedArtifacts) {
+			produced := tc.Spec.ProducedArtifacts[artifactType]
+			if produced.Disabled {
+				continue
+			}
+			if produced.ChangeTypeTo != "" {
+				info.ProducedArtifacts = append(info.ProducedArtifacts, fmt.Sprintf("%s->%s", artifactType, produced.ChangeTypeTo))
+				continue
+			}
+			info.ProducedArtifacts = append(info.ProducedArtifacts, string(artifactType))
+		}
+		if _, ok := activeConfigs[tc.Name]; !ok {
+			info.Filtered = true
+			info.FilteredReason = getFilteredReason(tc, allConfigs, selector)
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// getFilteredReason explains why getFilteredTransformers did not select the transformer
+func getFilteredReason(tc transformertypes.Transformer, allConfigs map[string]transformertypes.Transformer, selector labels.Selector) string {
+	if selector != nil && !selector.Matches(labels.Set(tc.Labels)) {
+		return "does not match the transformer selector"
+	}
+	if _, ok := transformerTypes[tc.Spec.Class]; !ok {
+		return fmt.Sprintf("the transformer class '%s' is not available", tc.Spec.Class)
+	}
+	overriddenBy := []string{}
+	for name, otc := range allConfigs {
+		if otc.Spec.OverrideSelector == nil || (selector != nil && !selector.Matches(labels.Set(otc.Labels))) {
+			continue
+		}
+		if otc.Spec.OverrideSelector.Matches(labels.Set(tc.Labels)) {
+			overriddenBy = append(overriddenBy, name)
+		}
+	}
+	if len(overriddenBy) != 0 {
+		sort.Strings(overriddenBy)
+		return fmt.Sprintf("overridden by %s", strings.Join(overriddenBy, ", "))
+	}
+	return "ignored"
+}
+
+// GetKnownArtifactTypes returns the artifact types consumed or produced by any of the transformers
+func GetKnownArtifactTypes(transformerYamlPaths map[string]string) []string {
+	knownTypes := map[transformertypes.ArtifactType]bool{}
+	for _, tc := range loadTransformerConfigs(transformerYamlPaths, nil) {
+		for artifactType := range tc.Spec.ConsumedArtifacts {
+			knownTypes[artifactType] = true
+		}
+		for artifactType, produced := range tc.Spec.ProducedArtifacts {
+			knownTypes[artifactType] = true
+			if produced.ChangeTypeTo != "" {
+				knownTypes[produced.ChangeTypeTo] = true
+			}
+		}
+	}
+	delete(knownTypes, ALLOW_ALL_ARTIFACT_TYPES)
+	sortedTypes := []string{}
+	for _, artifactType := range getSortedTypes(knownTypes) {
+		sortedTypes = append(sortedTypes, string(artifactType))
+	}
+	return sortedTypes
+}
+
+// getTransformerSource returns whether the transformer yaml is one of the built-in transformers or in the customizations
+func getTransformerSource(assetsPath, transformerYamlPath string) string {
+	if relPath, err := filepath.Rel(assetsPath, transformerYamlPath); err == nil && strings.HasPrefix(relPath, CustomTransformerSource+string(filepath.Separator)) {
+		return CustomTransformerSource
+	}
+	return BuiltInTransformerSource
+}
+
+// IsTransformerClassAvailable returns true if transformers of the class can be run in this build
+func IsTransformerClassAvailable(class string) bool {
+	_, ok := transformerTypes[class]
+	return ok
+}
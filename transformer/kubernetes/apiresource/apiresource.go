@@ -17,6 +17,7 @@
 package apiresource
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -40,7 +41,7 @@ const (
 // IAPIResource defines the interface to be defined for a new api resource
 type IAPIResource interface {
 	getSupportedKinds() []string
-	createNewResources(ir irtypes.EnhancedIR, supportedKinds []string, targetCluster collecttypes.ClusterMetadata) []runtime.Object
+	createNewResources(ctx context.Context, ir irtypes.EnhancedIR, supportedKinds []string, targetCluster collecttypes.ClusterMetadata) []runtime.Object
 	// Return nil if not supported
 	convertToClusterSupportedKinds(obj runtime.Object, supportedKinds []string, otherobjs []runtime.Object, enhancedIR irtypes.EnhancedIR, targetCluster collecttypes.ClusterMetadata) ([]runtime.Object, bool)
 }
@@ -52,10 +53,10 @@ type APIResource struct {
 }
 
 // convertIRToObjects converts IR to a runtime objects
-func (o *APIResource) convertIRToObjects(ir irtypes.EnhancedIR, targetCluster collecttypes.ClusterMetadata) []runtime.Object {
+func (o *APIResource) convertIRToObjects(ctx context.Context, ir irtypes.EnhancedIR, targetCluster collecttypes.ClusterMetadata) []runtime.Object {
 	logrus.Trace("APIResource.convertIRToObjects start")
 	defer logrus.Trace("APIResource.convertIRToObjects end")
-	objs := o.createNewResources(ir, o.getClusterSupportedKinds(targetCluster), targetCluster)
+	objs := o.createNewResources(ctx, ir, o.getClusterSupportedKinds(targetCluster), targetCluster)
 	for _, obj := range objs {
 		if !o.loadResource(obj, objs, ir, targetCluster) {
 			logrus.Errorf("Object created seems to be of an incompatible type : %+v [Supported Types: %+v]", obj.GetObjectKind(), o.getSupportedKinds())
@@ -17,15 +17,14 @@
 package apiresource
 
 import (
+	"context"
 	"strings"
 
 	"github.com/konveyor/move2kube-wasm/common"
 	"github.com/konveyor/move2kube-wasm/transformer/kubernetes/k8sschema"
 	collecttypes "github.com/konveyor/move2kube-wasm/types/collection"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
-	okdappsv1 "github.com/openshift/api/apps/v1"
 	"github.com/sirupsen/logrus"
-	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	apps "k8s.io/kubernetes/pkg/apis/apps"
@@ -56,11 +55,13 @@ type Deployment struct {
 
 // getSupportedKinds returns kinds supported by the deployment
 func (d *Deployment) getSupportedKinds() []string {
-	return []string{podKind, jobKind, common.DeploymentKind, deploymentConfigKind, replicationControllerKind, daemonSetKind, statefulSetKind}
+	kinds := []string{podKind, jobKind, common.DeploymentKind}
+	kinds = append(kinds, deploymentConfigKinds...)
+	return append(kinds, replicationControllerKind, daemonSetKind, statefulSetKind)
 }
 
 // createNewResources converts ir to runtime object
-func (d *Deployment) createNewResources(ir irtypes.EnhancedIR, supportedKinds []string, targetCluster collecttypes.ClusterMetadata) []runtime.Object {
+func (d *Deployment) createNewResources(ctx context.Context, ir irtypes.EnhancedIR, supportedKinds []string, targetCluster collecttypes.ClusterMetadata) []runtime.Object {
 	objs := []runtime.Object{}
 	for _, service := range ir.Services {
 		var obj runtime.Object
@@ -121,8 +122,8 @@ func (d *Deployment) convertToClusterSupportedKinds(obj runtime.Object, supporte
 		return []runtime.Object{obj}, true
 	}
 	if common.IsPresent(supportedKinds, common.DeploymentKind) {
-		if d1, ok := obj.(*okdappsv1.DeploymentConfig); ok {
-			return []runtime.Object{d.toDeployment(d1.ObjectMeta, k8sschema.ConvertToPodSpec(&d1.Spec.Template.Spec), d1.Spec.Replicas, targetCluster.Spec)}, true
+		if meta, podSpec, replicas, ok := getDeploymentConfigSpec(obj); ok {
+			return []runtime.Object{d.toDeployment(meta, podSpec, replicas, targetCluster.Spec)}, true
 		} else if d1, ok := lobj.(*core.ReplicationController); ok {
 			return []runtime.Object{d.toDeployment(d1.ObjectMeta, d1.Spec.Template.Spec, d1.Spec.Replicas, targetCluster.Spec)}, true
 		} else if d1, ok := lobj.(*core.Pod); ok {
@@ -143,8 +144,8 @@ func (d *Deployment) convertToClusterSupportedKinds(obj runtime.Object, supporte
 		return []runtime.Object{obj}, true
 	}
 	if common.IsPresent(supportedKinds, replicationControllerKind) {
-		if d1, ok := obj.(*okdappsv1.DeploymentConfig); ok {
-			return []runtime.Object{d.toReplicationController(d1.ObjectMeta, k8sschema.ConvertToPodSpec(&d1.Spec.Template.Spec), d1.Spec.Replicas, targetCluster.Spec)}, true
+		if meta, podSpec, replicas, ok := getDeploymentConfigSpec(obj); ok {
+			return []runtime.Object{d.toReplicationController(meta, podSpec, replicas, targetCluster.Spec)}, true
 		} else if d1, ok := lobj.(*apps.Deployment); ok {
 			return []runtime.Object{d.toReplicationController(d1.ObjectMeta, d1.Spec.Template.Spec, d1.Spec.Replicas, targetCluster.Spec)}, true
 		} else if d1, ok := lobj.(*core.Pod); ok {
@@ -154,8 +155,8 @@ func (d *Deployment) convertToClusterSupportedKinds(obj runtime.Object, supporte
 		return []runtime.Object{obj}, true
 	}
 	if common.IsPresent(supportedKinds, podKind) {
-		if d1, ok := obj.(*okdappsv1.DeploymentConfig); ok {
-			return []runtime.Object{d.toPod(d1.ObjectMeta, k8sschema.ConvertToPodSpec(&d1.Spec.Template.Spec), core.RestartPolicyAlways, targetCluster.Spec)}, true
+		if meta, podSpec, _, ok := getDeploymentConfigSpec(obj); ok {
+			return []runtime.Object{d.toPod(meta, podSpec, core.RestartPolicyAlways, targetCluster.Spec)}, true
 		} else if d1, ok := lobj.(*apps.Deployment); ok {
 			return []runtime.Object{d.toPod(d1.ObjectMeta, d1.Spec.Template.Spec, core.RestartPolicyAlways, targetCluster.Spec)}, true
 		} else if d1, ok := lobj.(*core.ReplicationController); ok {
@@ -184,19 +185,6 @@ func (d *Deployment) createDeployment(service irtypes.Service, cluster collectty
 	return d.toDeployment(meta, core.PodSpec(podSpec), int32(service.Replicas), cluster)
 }
 
-func (d *Deployment) createDeploymentConfig(service irtypes.Service, cluster collecttypes.ClusterMetadataSpec) *okdappsv1.DeploymentConfig {
-	meta := metav1.ObjectMeta{
-		Name:        service.Name,
-		Labels:      getPodLabels(service.Name, service.Networks),
-		Annotations: getAnnotations(service),
-	}
-	podSpec := service.PodSpec
-	podSpec = irtypes.PodSpec(d.convertVolumesKindsByPolicy(core.PodSpec(podSpec), cluster))
-	podSpec.RestartPolicy = core.RestartPolicyAlways
-	logrus.Debugf("Created DeploymentConfig for %s", service.Name)
-	return d.toDeploymentConfig(meta, core.PodSpec(podSpec), int32(service.Replicas), cluster)
-}
-
 // createReplicationController initializes Kubernetes ReplicationController object
 func (d *Deployment) createReplicationController(service irtypes.Service, cluster collecttypes.ClusterMetadataSpec) *core.ReplicationController {
 	meta := metav1.ObjectMeta{
@@ -305,44 +293,6 @@ func (d *Deployment) createStatefulSet(service irtypes.Service, cluster collectt
 
 // Conversions section
 
-func (d *Deployment) toDeploymentConfig(meta metav1.ObjectMeta, podspec core.PodSpec, replicas int32, cluster collecttypes.ClusterMetadataSpec) *okdappsv1.DeploymentConfig {
-	podspec = d.convertVolumesKindsByPolicy(podspec, cluster)
-	triggerPolicies := []okdappsv1.DeploymentTriggerPolicy{{
-		Type: okdappsv1.DeploymentTriggerOnConfigChange,
-	}}
-	for _, container := range podspec.Containers {
-		imageStreamName, imageStreamTag := new(ImageStream).GetImageStreamNameAndTag(container.Image)
-		triggerPolicies = append(triggerPolicies, okdappsv1.DeploymentTriggerPolicy{
-			Type: okdappsv1.DeploymentTriggerOnImageChange,
-			ImageChangeParams: &okdappsv1.DeploymentTriggerImageChangeParams{
-				Automatic:      true,
-				ContainerNames: []string{container.Name},
-				From: corev1.ObjectReference{
-					Kind: "ImageStreamTag",
-					Name: imageStreamName + ":" + imageStreamTag,
-				},
-			},
-		})
-	}
-	dc := okdappsv1.DeploymentConfig{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       deploymentConfigKind,
-			APIVersion: okdappsv1.SchemeGroupVersion.String(),
-		},
-		ObjectMeta: meta,
-		Spec: okdappsv1.DeploymentConfigSpec{
-			Replicas: int32(replicas),
-			Selector: getServiceLabels(meta.Name),
-			Template: &corev1.PodTemplateSpec{
-				ObjectMeta: meta,
-				Spec:       k8sschema.ConvertToV1PodSpec(&podspec), // obj.Spec.Template.Spec,
-			},
-			Triggers: triggerPolicies,
-		},
-	}
-	return &dc
-}
-
 func (d *Deployment) toDeployment(meta metav1.ObjectMeta, podspec core.PodSpec, replicas int32, cluster collecttypes.ClusterMetadataSpec) *apps.Deployment {
 	podspec = d.convertVolumesKindsByPolicy(podspec, cluster)
 	dc := &apps.Deployment{
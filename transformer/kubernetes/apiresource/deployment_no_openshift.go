@@ -0,0 +1,46 @@
+//go:build m2k_no_openshift
+// +build m2k_no_openshift
+
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package apiresource
+
+import (
+	collecttypes "github.com/konveyor/move2kube-wasm/types/collection"
+	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	core "k8s.io/kubernetes/pkg/apis/core"
+)
+
+// deploymentConfigKinds is empty since DeploymentConfig is left out when building with the m2k_no_openshift tag
+var deploymentConfigKinds = []string{}
+
+// createDeploymentConfig is never called since DeploymentConfig is not a supported kind
+func (d *Deployment) createDeploymentConfig(service irtypes.Service, cluster collecttypes.ClusterMetadataSpec) runtime.Object {
+	return nil
+}
+
+// getDeploymentConfigSpec always returns false since the DeploymentConfig type is not compiled in
+func getDeploymentConfigSpec(obj runtime.Object) (metav1.ObjectMeta, core.PodSpec, int32, bool) {
+	return metav1.ObjectMeta{}, core.PodSpec{}, 0, false
+}
+
+// toDeploymentConfig is never called since DeploymentConfig is not a supported kind
+func (d *Deployment) toDeploymentConfig(meta metav1.ObjectMeta, podspec core.PodSpec, replicas int32, cluster collecttypes.ClusterMetadataSpec) runtime.Object {
+	return nil
+}
@@ -0,0 +1,95 @@
+//go:build !m2k_no_openshift
+// +build !m2k_no_openshift
+
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package apiresource
+
+import (
+	"github.com/konveyor/move2kube-wasm/transformer/kubernetes/k8sschema"
+	collecttypes "github.com/konveyor/move2kube-wasm/types/collection"
+	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
+	okdappsv1 "github.com/openshift/api/apps/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	core "k8s.io/kubernetes/pkg/apis/core"
+)
+
+// deploymentConfigKinds are the OpenShift only kinds handled by Deployment
+var deploymentConfigKinds = []string{deploymentConfigKind}
+
+func (d *Deployment) createDeploymentConfig(service irtypes.Service, cluster collecttypes.ClusterMetadataSpec) *okdappsv1.DeploymentConfig {
+	meta := metav1.ObjectMeta{
+		Name:        service.Name,
+		Labels:      getPodLabels(service.Name, service.Networks),
+		Annotations: getAnnotations(service),
+	}
+	podSpec := service.PodSpec
+	podSpec = irtypes.PodSpec(d.convertVolumesKindsByPolicy(core.PodSpec(podSpec), cluster))
+	podSpec.RestartPolicy = core.RestartPolicyAlways
+	logrus.Debugf("Created DeploymentConfig for %s", service.Name)
+	return d.toDeploymentConfig(meta, core.PodSpec(podSpec), int32(service.Replicas), cluster)
+}
+
+// getDeploymentConfigSpec returns the metadata, pod spec and replicas of the object if it is a DeploymentConfig
+func getDeploymentConfigSpec(obj runtime.Object) (metav1.ObjectMeta, core.PodSpec, int32, bool) {
+	d1, ok := obj.(*okdappsv1.DeploymentConfig)
+	if !ok {
+		return metav1.ObjectMeta{}, core.PodSpec{}, 0, false
+	}
+	return d1.ObjectMeta, k8sschema.ConvertToPodSpec(&d1.Spec.Template.Spec), d1.Spec.Replicas, true
+}
+
+func (d *Deployment) toDeploymentConfig(meta metav1.ObjectMeta, podspec core.PodSpec, replicas int32, cluster collecttypes.ClusterMetadataSpec) *okdappsv1.DeploymentConfig {
+	podspec = d.convertVolumesKindsByPolicy(podspec, cluster)
+	triggerPolicies := []okdappsv1.DeploymentTriggerPolicy{{
+		Type: okdappsv1.DeploymentTriggerOnConfigChange,
+	}}
+	for _, container := range podspec.Containers {
+		imageStreamName, imageStreamTag := new(ImageStream).GetImageStreamNameAndTag(container.Image)
+		triggerPolicies = append(triggerPolicies, okdappsv1.DeploymentTriggerPolicy{
+			Type: okdappsv1.DeploymentTriggerOnImageChange,
+			ImageChangeParams: &okdappsv1.DeploymentTriggerImageChangeParams{
+				Automatic:      true,
+				ContainerNames: []string{container.Name},
+				From: corev1.ObjectReference{
+					Kind: "ImageStreamTag",
+					Name: imageStreamName + ":" + imageStreamTag,
+				},
+			},
+		})
+	}
+	dc := okdappsv1.DeploymentConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       deploymentConfigKind,
+			APIVersion: okdappsv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: meta,
+		Spec: okdappsv1.DeploymentConfigSpec{
+			Replicas: int32(replicas),
+			Selector: getServiceLabels(meta.Name),
+			Template: &corev1.PodTemplateSpec{
+				ObjectMeta: meta,
+				Spec:       k8sschema.ConvertToV1PodSpec(&podspec), // obj.Spec.Template.Spec,
+			},
+			Triggers: triggerPolicies,
+		},
+	}
+	return &dc
+}
@@ -1,3 +1,6 @@
+//go:build !m2k_no_openshift
+// +build !m2k_no_openshift
+
 /*
  *  Copyright IBM Corporation 2021
  *
@@ -17,6 +20,7 @@
 package apiresource
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/konveyor/move2kube-wasm/common"
@@ -46,7 +50,7 @@ func (*ImageStream) getSupportedKinds() []string {
 }
 
 // createNewResources converts IR to runtime objects
-func (imageStream *ImageStream) createNewResources(ir irtypes.EnhancedIR, supportedKinds []string, targetCluster collecttypes.ClusterMetadata) []runtime.Object {
+func (imageStream *ImageStream) createNewResources(ctx context.Context, ir irtypes.EnhancedIR, supportedKinds []string, targetCluster collecttypes.ClusterMetadata) []runtime.Object {
 	objs := []runtime.Object{}
 	if !common.IsPresent(supportedKinds, imageStreamKind) {
 		logrus.Debugf("Could not find a valid resource type in cluster to create an ImageStream")
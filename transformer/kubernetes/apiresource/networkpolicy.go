@@ -17,6 +17,7 @@
 package apiresource
 
 import (
+	"context"
 	"github.com/konveyor/move2kube-wasm/common"
 	"github.com/konveyor/move2kube-wasm/types"
 	collecttypes "github.com/konveyor/move2kube-wasm/types/collection"
@@ -42,7 +43,7 @@ func (d *NetworkPolicy) getSupportedKinds() []string {
 }
 
 // createNewResources converts ir to runtime objects
-func (d *NetworkPolicy) createNewResources(ir irtypes.EnhancedIR, supportedKinds []string, targetCluster collecttypes.ClusterMetadata) []runtime.Object {
+func (d *NetworkPolicy) createNewResources(ctx context.Context, ir irtypes.EnhancedIR, supportedKinds []string, targetCluster collecttypes.ClusterMetadata) []runtime.Object {
 	objs := []runtime.Object{}
 	if !common.IsPresent(supportedKinds, networkPolicyKind) {
 		logrus.Errorf("Could not find a valid resource type in cluster to create a NetworkPolicy")
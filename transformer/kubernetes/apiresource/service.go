@@ -17,6 +17,7 @@
 package apiresource
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -26,7 +27,6 @@ import (
 	collecttypes "github.com/konveyor/move2kube-wasm/types/collection"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
-	okdroutev1 "github.com/openshift/api/route/v1"
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -45,11 +45,11 @@ type Service struct {
 
 // getSupportedKinds returns supported kinds
 func (d *Service) getSupportedKinds() []string {
-	return []string{common.ServiceKind, common.IngressKind, routeKind}
+	return append([]string{common.ServiceKind, common.IngressKind}, routeKinds...)
 }
 
 // createNewResources converts IR to runtime objects
-func (d *Service) createNewResources(ir irtypes.EnhancedIR, supportedKinds []string, targetCluster collecttypes.ClusterMetadata) []runtime.Object {
+func (d *Service) createNewResources(ctx context.Context, ir irtypes.EnhancedIR, supportedKinds []string, targetCluster collecttypes.ClusterMetadata) []runtime.Object {
 	objs := []runtime.Object{}
 	ingressEnabled := false
 	for _, service := range ir.Services {
@@ -58,14 +58,14 @@ func (d *Service) createNewResources(ir irtypes.EnhancedIR, supportedKinds []str
 			// Create services depending on whether the service needs to be externally exposed
 			if common.IsPresent(supportedKinds, routeKind) {
 				//Create Route
-				routeObjs := d.createRoutes(service, ir, targetCluster)
+				routeObjs := d.createRoutes(ctx, service, ir, targetCluster)
 				for _, routeObj := range routeObjs {
 					objs = append(objs, routeObj)
 				}
 				exposeobjectcreated = true
 			} else if common.IsPresent(supportedKinds, common.IngressKind) {
 				//Create Ingress
-				// obj := d.createIngress(service)
+				// obj := d.createIngress(ctx, service)
 				// objs = append(objs, obj)
 				exposeobjectcreated = true
 				ingressEnabled = true
@@ -83,7 +83,7 @@ func (d *Service) createNewResources(ir irtypes.EnhancedIR, supportedKinds []str
 
 	// Create one ingress for all services
 	if ingressEnabled {
-		obj := d.createIngress(ir, targetCluster)
+		obj := d.createIngress(ctx, ir, targetCluster)
 		if obj != nil {
 			objs = append(objs, obj)
 		}
@@ -94,11 +94,11 @@ func (d *Service) createNewResources(ir irtypes.EnhancedIR, supportedKinds []str
 
 // convertToClusterSupportedKinds converts kinds to cluster supported kinds
 func (d *Service) convertToClusterSupportedKinds(obj runtime.Object, supportedKinds []string, otherobjs []runtime.Object, ir irtypes.EnhancedIR, targetCluster collecttypes.ClusterMetadata) ([]runtime.Object, bool) {
+	if objs, ok := d.convertRouteToClusterSupportedKinds(obj, supportedKinds, ir, targetCluster); ok {
+		return objs, true
+	}
 	lobj, _ := k8sschema.ConvertToLiasonScheme(obj)
 	if common.IsPresent(supportedKinds, routeKind) {
-		if _, ok := obj.(*okdroutev1.Route); ok {
-			return []runtime.Object{obj}, true
-		}
 		if ingress, ok := lobj.(*networking.Ingress); ok {
 			return d.ingressToRoute(*ingress), true
 		}
@@ -106,9 +106,6 @@ func (d *Service) convertToClusterSupportedKinds(obj runtime.Object, supportedKi
 			return []runtime.Object{obj}, true
 		}
 	} else if common.IsPresent(supportedKinds, common.IngressKind) {
-		if route, ok := obj.(*okdroutev1.Route); ok {
-			return d.routeToIngress(*route, ir, targetCluster.Spec), true
-		}
 		if _, ok := lobj.(*networking.Ingress); ok {
 			return []runtime.Object{obj}, true
 		}
@@ -116,9 +113,6 @@ func (d *Service) convertToClusterSupportedKinds(obj runtime.Object, supportedKi
 			return []runtime.Object{obj}, true
 		}
 	} else {
-		if route, ok := obj.(*okdroutev1.Route); ok {
-			return d.routeToService(*route), true
-		}
 		if ingress, ok := lobj.(*networking.Ingress); ok {
 			return d.ingressToService(*ingress), true
 		}
@@ -133,136 +127,6 @@ func (d *Service) convertToClusterSupportedKinds(obj runtime.Object, supportedKi
 	return nil, false
 }
 
-func (d *Service) ingressToRoute(ingress networking.Ingress) []runtime.Object {
-	weight := int32(1)                                    //Hard-coded to 1 to avoid Helm v3 errors
-	ingressArray := []okdroutev1.RouteIngress{{Host: ""}} //Hard-coded to empty string to avoid Helm v3 errors
-
-	objs := []runtime.Object{}
-
-	for _, ingressspec := range ingress.Spec.Rules {
-		for _, path := range ingressspec.IngressRuleValue.HTTP.Paths {
-			targetPort := intstr.IntOrString{Type: intstr.String, StrVal: path.Backend.Service.Port.Name}
-			if path.Backend.Service.Port.Name == "" {
-				targetPort.Type = intstr.Int
-				targetPort.IntVal = path.Backend.Service.Port.Number
-			}
-			route := &okdroutev1.Route{
-				TypeMeta: metav1.TypeMeta{
-					Kind:       routeKind,
-					APIVersion: okdroutev1.SchemeGroupVersion.String(),
-				},
-				ObjectMeta: ingress.ObjectMeta,
-				Spec: okdroutev1.RouteSpec{
-					Host: ingressspec.Host,
-					Path: path.Path,
-					To: okdroutev1.RouteTargetReference{
-						Kind:   common.ServiceKind,
-						Name:   path.Backend.Service.Name,
-						Weight: &weight,
-					},
-					Port: &okdroutev1.RoutePort{TargetPort: targetPort},
-				},
-				Status: okdroutev1.RouteStatus{Ingress: ingressArray},
-			}
-			objs = append(objs, route)
-		}
-	}
-
-	return objs
-}
-
-func (d *Service) routeToIngress(route okdroutev1.Route, ir irtypes.EnhancedIR, targetClusterSpec collecttypes.ClusterMetadataSpec) []runtime.Object {
-	targetPort := networking.ServiceBackendPort{}
-	if route.Spec.Port != nil {
-		if route.Spec.Port.TargetPort.Type == intstr.String {
-			targetPort.Name = route.Spec.Port.TargetPort.StrVal
-		} else {
-			targetPort.Number = route.Spec.Port.TargetPort.IntVal
-		}
-	} else {
-		targetName := route.Spec.To.Name
-		s, ok := ir.Services[targetName]
-		if !ok {
-			for _, s1 := range ir.Services {
-				if s1.BackendServiceName == targetName {
-					s = s1
-					ok = true
-					break
-				}
-			}
-		}
-		if !ok || len(s.ServiceToPodPortForwardings) == 0 {
-			logrus.Errorf("failed to find the service the route is pointing to. Exposing a default port (8080) in the Ingress")
-			targetPort.Number = 8080
-		} else {
-			portForwarding := s.ServiceToPodPortForwardings[0]
-			if portForwarding.ServicePort.Name != "" {
-				targetPort.Name = portForwarding.ServicePort.Name
-			} else {
-				targetPort.Number = portForwarding.ServicePort.Number
-			}
-		}
-	}
-
-	ingress := networking.Ingress{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       common.IngressKind,
-			APIVersion: networking.SchemeGroupVersion.String(),
-		},
-		ObjectMeta: route.ObjectMeta,
-		Spec: networking.IngressSpec{
-			Rules: []networking.IngressRule{
-				{
-					IngressRuleValue: networking.IngressRuleValue{
-						HTTP: &networking.HTTPIngressRuleValue{
-							Paths: []networking.HTTPIngressPath{
-								{
-									Path: route.Spec.Path,
-									Backend: networking.IngressBackend{
-										Service: &networking.IngressServiceBackend{
-											Name: route.Spec.To.Name,
-											Port: targetPort,
-										},
-									},
-								},
-							},
-						},
-					},
-					Host: route.Spec.Host,
-				},
-			},
-		},
-	}
-
-	return []runtime.Object{&ingress}
-}
-
-func (d *Service) routeToService(route okdroutev1.Route) []runtime.Object {
-	// TODO: Think through how will the clusterip service that was originally there will behave when merged with this service?
-	svc := &core.Service{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       common.ServiceKind,
-			APIVersion: core.SchemeGroupVersion.String(),
-		},
-		ObjectMeta: route.ObjectMeta,
-		Spec: core.ServiceSpec{
-			// TODO: we are expecting the pod label selector to be merged in from other existing services
-			// TODO: How to choose between nodeport and loadbalancer?
-			Type: core.ServiceTypeNodePort,
-			Ports: []core.ServicePort{
-				{
-					Name: route.Spec.Port.TargetPort.StrVal,
-					Port: route.Spec.Port.TargetPort.IntVal,
-					// TODO: what about targetPort?
-				},
-			},
-		},
-	}
-	svc.Name = route.Spec.To.Name
-
-	return []runtime.Object{svc}
-}
-
 func (d *Service) ingressToService(ingress networking.Ingress) []runtime.Object {
 	objs := []runtime.Object{}
 	for _, ingressspec := range ingress.Spec.Rules {
@@ -293,64 +157,8 @@ func (d *Service) ingressToService(ingress networking.Ingress) []runtime.Object
 	return objs
 }
 
-func (d *Service) createRoutes(service irtypes.Service, ir irtypes.EnhancedIR, targetCluster collecttypes.ClusterMetadata) [](*okdroutev1.Route) {
-	routes := [](*okdroutev1.Route){}
-	servicePorts, hostPrefixes, relPaths, _ := d.getExposeInfo(service)
-	for i, servicePort := range servicePorts {
-		if relPaths[i] == "" {
-			continue
-		}
-		route := d.createRoute(ir.Name, service, servicePort, hostPrefixes[i], relPaths[i], ir, targetCluster)
-		routes = append(routes, route)
-	}
-	return routes
-}
-
-// TODO: Remove these two sections after helm v3 issue is fixed
-// [https://github.com/openshift/origin/issues/24060]
-// [https://bugzilla.redhat.com/show_bug.cgi?id=1773682]
-// Can't use https because of this https://github.com/openshift/origin/issues/2162
-// When service has multiple ports,the route needs a port name. Port number doesn't seem to work.
-func (d *Service) createRoute(irName string, service irtypes.Service, port core.ServicePort, hostprefix, path string, ir irtypes.EnhancedIR, targetCluster collecttypes.ClusterMetadata) *okdroutev1.Route {
-	weight := int32(1)                                    //Hard-coded to 1 to avoid Helm v3 errors
-	ingressArray := []okdroutev1.RouteIngress{{Host: ""}} //Hard-coded to empty string to avoid Helm v3 errors
-
-	host := targetCluster.Spec.Host
-	if host == "" {
-		host = commonqa.IngressHost(d.getHostName(irName), targetCluster.Labels[collecttypes.ClusterQaLabelKey])
-	}
-	ph := host
-	if hostprefix != "" {
-		ph = hostprefix + "." + ph
-	}
-	route := &okdroutev1.Route{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       routeKind,
-			APIVersion: okdroutev1.SchemeGroupVersion.String(),
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:   service.Name,
-			Labels: getServiceLabels(service.Name),
-		},
-		Spec: okdroutev1.RouteSpec{
-			Host: ph,
-			Path: path,
-			To: okdroutev1.RouteTargetReference{
-				Kind:   common.ServiceKind,
-				Name:   service.Name,
-				Weight: &weight,
-			},
-			Port: &okdroutev1.RoutePort{TargetPort: intstr.IntOrString{Type: intstr.String, StrVal: port.Name}},
-		},
-		Status: okdroutev1.RouteStatus{
-			Ingress: ingressArray,
-		},
-	}
-	return route
-}
-
 // createIngress creates a single ingress for all services
-func (d *Service) createIngress(ir irtypes.EnhancedIR, targetCluster collecttypes.ClusterMetadata) *networking.Ingress {
+func (d *Service) createIngress(ctx context.Context, ir irtypes.EnhancedIR, targetCluster collecttypes.ClusterMetadata) *networking.Ingress {
 	pathType := networking.PathTypePrefix
 
 	hostHTTPIngressPaths := map[string][]networking.HTTPIngressPath{} //[hostprefix]
@@ -394,7 +202,7 @@ func (d *Service) createIngress(ir irtypes.EnhancedIR, targetCluster collecttype
 	// Set the default ingressClass value
 	quesKeyClass := common.JoinQASubKeys(qaId, common.ConfigIngressClassNameKeySuffix)
 	descClass := "Provide the Ingress class name for ingress"
-	ingressClassName := qaengine.FetchStringAnswer(quesKeyClass, descClass, []string{"Leave empty to use the cluster default"}, "", nil)
+	ingressClassName := qaengine.FetchStringAnswer(ctx, quesKeyClass, descClass, []string{"Leave empty to use the cluster default"}, "", nil)
 
 	// Configure the rule with the above fan-out paths
 	rules := []networking.IngressRule{}
@@ -402,11 +210,11 @@ func (d *Service) createIngress(ir irtypes.EnhancedIR, targetCluster collecttype
 	secretName := ""
 	defaultSecretName := ""
 	if host == "" {
-		host = commonqa.IngressHost(d.getHostName(ir.Name), qaLabel)
+		host = commonqa.IngressHost(ctx, d.getHostName(ir.Name), qaLabel)
 	}
 	quesKeyTLS := common.JoinQASubKeys(qaId, common.ConfigIngressTLSKeySuffix)
 	descTLS := "Provide the TLS secret for ingress"
-	secretName = qaengine.FetchStringAnswer(quesKeyTLS, descTLS, []string{"Leave empty to use http"}, defaultSecretName, nil)
+	secretName = qaengine.FetchStringAnswer(ctx, quesKeyTLS, descTLS, []string{"Leave empty to use http"}, defaultSecretName, nil)
 	for hostprefix, httpIngressPaths := range hostHTTPIngressPaths {
 		ph := host
 		if hostprefix != "" {
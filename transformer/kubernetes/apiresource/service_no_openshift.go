@@ -0,0 +1,46 @@
+//go:build m2k_no_openshift
+// +build m2k_no_openshift
+
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package apiresource
+
+import (
+	"context"
+	collecttypes "github.com/konveyor/move2kube-wasm/types/collection"
+	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
+	"k8s.io/apimachinery/pkg/runtime"
+	networking "k8s.io/kubernetes/pkg/apis/networking"
+)
+
+// routeKinds is empty since Route is left out when building with the m2k_no_openshift tag
+var routeKinds = []string{}
+
+// convertRouteToClusterSupportedKinds always returns false since the Route type is not compiled in
+func (d *Service) convertRouteToClusterSupportedKinds(obj runtime.Object, supportedKinds []string, ir irtypes.EnhancedIR, targetCluster collecttypes.ClusterMetadata) ([]runtime.Object, bool) {
+	return nil, false
+}
+
+// ingressToRoute is never called since Route is not a supported kind
+func (d *Service) ingressToRoute(ingress networking.Ingress) []runtime.Object {
+	return nil
+}
+
+// createRoutes is never called since Route is not a supported kind
+func (d *Service) createRoutes(ctx context.Context, service irtypes.Service, ir irtypes.EnhancedIR, targetCluster collecttypes.ClusterMetadata) []runtime.Object {
+	return nil
+}
@@ -0,0 +1,239 @@
+//go:build !m2k_no_openshift
+// +build !m2k_no_openshift
+
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package apiresource
+
+import (
+	"context"
+	"github.com/konveyor/move2kube-wasm/common"
+	collecttypes "github.com/konveyor/move2kube-wasm/types/collection"
+	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
+	"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
+	okdroutev1 "github.com/openshift/api/route/v1"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	core "k8s.io/kubernetes/pkg/apis/core"
+	networking "k8s.io/kubernetes/pkg/apis/networking"
+)
+
+// routeKinds are the OpenShift only kinds handled by Service
+var routeKinds = []string{routeKind}
+
+// convertRouteToClusterSupportedKinds converts the object to the kinds supported by the cluster if it is a Route
+func (d *Service) convertRouteToClusterSupportedKinds(obj runtime.Object, supportedKinds []string, ir irtypes.EnhancedIR, targetCluster collecttypes.ClusterMetadata) ([]runtime.Object, bool) {
+	route, ok := obj.(*okdroutev1.Route)
+	if !ok {
+		return nil, false
+	}
+	if common.IsPresent(supportedKinds, routeKind) {
+		return []runtime.Object{obj}, true
+	}
+	if common.IsPresent(supportedKinds, common.IngressKind) {
+		return d.routeToIngress(*route, ir, targetCluster.Spec), true
+	}
+	return d.routeToService(*route), true
+}
+
+func (d *Service) ingressToRoute(ingress networking.Ingress) []runtime.Object {
+	weight := int32(1)                                    //Hard-coded to 1 to avoid Helm v3 errors
+	ingressArray := []okdroutev1.RouteIngress{{Host: ""}} //Hard-coded to empty string to avoid Helm v3 errors
+
+	objs := []runtime.Object{}
+
+	for _, ingressspec := range ingress.Spec.Rules {
+		for _, path := range ingressspec.IngressRuleValue.HTTP.Paths {
+			targetPort := intstr.IntOrString{Type: intstr.String, StrVal: path.Backend.Service.Port.Name}
+			if path.Backend.Service.Port.Name == "" {
+				targetPort.Type = intstr.Int
+				targetPort.IntVal = path.Backend.Service.Port.Number
+			}
+			route := &okdroutev1.Route{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       routeKind,
+					APIVersion: okdroutev1.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: ingress.ObjectMeta,
+				Spec: okdroutev1.RouteSpec{
+					Host: ingressspec.Host,
+					Path: path.Path,
+					To: okdroutev1.RouteTargetReference{
+						Kind:   common.ServiceKind,
+						Name:   path.Backend.Service.Name,
+						Weight: &weight,
+					},
+					Port: &okdroutev1.RoutePort{TargetPort: targetPort},
+				},
+				Status: okdroutev1.RouteStatus{Ingress: ingressArray},
+			}
+			objs = append(objs, route)
+		}
+	}
+
+	return objs
+}
+
+func (d *Service) routeToIngress(route okdroutev1.Route, ir irtypes.EnhancedIR, targetClusterSpec collecttypes.ClusterMetadataSpec) []runtime.Object {
+	targetPort := networking.ServiceBackendPort{}
+	if route.Spec.Port != nil {
+		if route.Spec.Port.TargetPort.Type == intstr.String {
+			targetPort.Name = route.Spec.Port.TargetPort.StrVal
+		} else {
+			targetPort.Number = route.Spec.Port.TargetPort.IntVal
+		}
+	} else {
+		targetName := route.Spec.To.Name
+		s, ok := ir.Services[targetName]
+		if !ok {
+			for _, s1 := range ir.Services {
+				if s1.BackendServiceName == targetName {
+					s = s1
+					ok = true
+					break
+				}
+			}
+		}
+		if !ok || len(s.ServiceToPodPortForwardings) == 0 {
+			logrus.Errorf("failed to find the service the route is pointing to. Exposing a default port (8080) in the Ingress")
+			targetPort.Number = 8080
+		} else {
+			portForwarding := s.ServiceToPodPortForwardings[0]
+			if portForwarding.ServicePort.Name != "" {
+				targetPort.Name = portForwarding.ServicePort.Name
+			} else {
+				targetPort.Number = portForwarding.ServicePort.Number
+			}
+		}
+	}
+
+	ingress := networking.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       common.IngressKind,
+			APIVersion: networking.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: route.ObjectMeta,
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path: route.Spec.Path,
+									Backend: networking.IngressBackend{
+										Service: &networking.IngressServiceBackend{
+											Name: route.Spec.To.Name,
+											Port: targetPort,
+										},
+									},
+								},
+							},
+						},
+					},
+					Host: route.Spec.Host,
+				},
+			},
+		},
+	}
+
+	return []runtime.Object{&ingress}
+}
+
+func (d *Service) routeToService(route okdroutev1.Route) []runtime.Object {
+	// TODO: Think through how will the clusterip service that was originally there will behave when merged with this service?
+	svc := &core.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       common.ServiceKind,
+			APIVersion: core.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: route.ObjectMeta,
+		Spec: core.ServiceSpec{
+			// TODO: we are expecting the pod label selector to be merged in from other existing services
+			// TODO: How to choose between nodeport and loadbalancer?
+			Type: core.ServiceTypeNodePort,
+			Ports: []core.ServicePort{
+				{
+					Name: route.Spec.Port.TargetPort.StrVal,
+					Port: route.Spec.Port.TargetPort.IntVal,
+					// TODO: what about targetPort?
+				},
+			},
+		},
+	}
+	svc.Name = route.Spec.To.Name
+
+	return []runtime.Object{svc}
+}
+
+func (d *Service) createRoutes(ctx context.Context, service irtypes.Service, ir irtypes.EnhancedIR, targetCluster collecttypes.ClusterMetadata) [](*okdroutev1.Route) {
+	routes := [](*okdroutev1.Route){}
+	servicePorts, hostPrefixes, relPaths, _ := d.getExposeInfo(service)
+	for i, servicePort := range servicePorts {
+		if relPaths[i] == "" {
+			continue
+		}
+		route := d.createRoute(ctx, ir.Name, service, servicePort, hostPrefixes[i], relPaths[i], ir, targetCluster)
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// TODO: Remove these two sections after helm v3 issue is fixed
+// [https://github.com/openshift/origin/issues/24060]
+// [https://bugzilla.redhat.com/show_bug.cgi?id=1773682]
+// Can't use https because of this https://github.com/openshift/origin/issues/2162
+// When service has multiple ports,the route needs a port name. Port number doesn't seem to work.
+func (d *Service) createRoute(ctx context.Context, irName string, service irtypes.Service, port core.ServicePort, hostprefix, path string, ir irtypes.EnhancedIR, targetCluster collecttypes.ClusterMetadata) *okdroutev1.Route {
+	weight := int32(1)                                    //Hard-coded to 1 to avoid Helm v3 errors
+	ingressArray := []okdroutev1.RouteIngress{{Host: ""}} //Hard-coded to empty string to avoid Helm v3 errors
+
+	host := targetCluster.Spec.Host
+	if host == "" {
+		host = commonqa.IngressHost(ctx, d.getHostName(irName), targetCluster.Labels[collecttypes.ClusterQaLabelKey])
+	}
+	ph := host
+	if hostprefix != "" {
+		ph = hostprefix + "." + ph
+	}
+	route := &okdroutev1.Route{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       routeKind,
+			APIVersion: okdroutev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   service.Name,
+			Labels: getServiceLabels(service.Name),
+		},
+		Spec: okdroutev1.RouteSpec{
+			Host: ph,
+			Path: path,
+			To: okdroutev1.RouteTargetReference{
+				Kind:   common.ServiceKind,
+				Name:   service.Name,
+				Weight: &weight,
+			},
+			Port: &okdroutev1.RoutePort{TargetPort: intstr.IntOrString{Type: intstr.String, StrVal: port.Name}},
+		},
+		Status: okdroutev1.RouteStatus{
+			Ingress: ingressArray,
+		},
+	}
+	return route
+}
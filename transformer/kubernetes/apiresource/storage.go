@@ -17,6 +17,7 @@
 package apiresource
 
 import (
+	"context"
 	"github.com/konveyor/move2kube-wasm/common"
 	collecttypes "github.com/konveyor/move2kube-wasm/types/collection"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
@@ -36,7 +37,7 @@ func (s *Storage) getSupportedKinds() []string {
 }
 
 // createNewResources converts IR objects to runtime objects
-func (s *Storage) createNewResources(ir irtypes.EnhancedIR, supportedKinds []string, targetCluster collecttypes.ClusterMetadata) []runtime.Object {
+func (s *Storage) createNewResources(ctx context.Context, ir irtypes.EnhancedIR, supportedKinds []string, targetCluster collecttypes.ClusterMetadata) []runtime.Object {
 	objs := []runtime.Object{}
 	for _, stObj := range ir.Storages {
 		if stObj.StorageType == irtypes.ConfigMapKind {
@@ -17,13 +17,14 @@
 package apiresource
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
 
 	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/transformer/kubernetes/k8sschema"
 	"github.com/konveyor/move2kube-wasm/transformer/kubernetes/k8sschema/fixer"
 	collecttypes "github.com/konveyor/move2kube-wasm/types/collection"
@@ -35,6 +36,7 @@ import (
 
 // TransformIRAndPersist transforms IR to yamls and writes to filesystem
 func TransformIRAndPersist(
+	ctx context.Context,
 	ir irtypes.EnhancedIR,
 	outputPath string,
 	apiResources []IAPIResource,
@@ -45,10 +47,10 @@ func TransformIRAndPersist(
 	defer logrus.Trace("TransformIRAndPersist end")
 	targetObjs := []runtime.Object{}
 	for _, apiResource := range apiResources {
-		newObjs := (&APIResource{IAPIResource: apiResource}).convertIRToObjects(ir, targetCluster)
+		newObjs := (&APIResource{IAPIResource: apiResource}).convertIRToObjects(ctx, ir, targetCluster)
 		targetObjs = append(targetObjs, newObjs...)
 	}
-	if err := os.MkdirAll(outputPath, common.DefaultDirectoryPermission); err != nil {
+	if err := vfs.MkdirAll(outputPath, common.DefaultDirectoryPermission); err != nil {
 		return nil, fmt.Errorf("failed to create the deploy directory at path '%s' . Error: %w", outputPath, err)
 	}
 	logrus.Debugf("number of services to be serialized %d", len(targetObjs))
@@ -74,7 +76,7 @@ func TransformObjsAndPersist(inputPath, outputPath string, apis []IAPIResource,
 		}
 		targetObjs = append(targetObjs, pendingObjs...)
 	}
-	if err := os.MkdirAll(outputPath, common.DefaultDirectoryPermission); err != nil {
+	if err := vfs.MkdirAll(outputPath, common.DefaultDirectoryPermission); err != nil {
 		logrus.Errorf("failed to create deploy directory at path '%s' . Error: %q", outputPath, err)
 	}
 	logrus.Debugf("Total %d services to be serialized.", len(targetObjs))
@@ -91,7 +93,7 @@ func TransformObjsAndPersist(inputPath, outputPath string, apis []IAPIResource,
 
 // writeObjects writes the runtime objects to yaml files
 func writeObjects(outputPath string, objs []runtime.Object) ([]string, error) {
-	if err := os.MkdirAll(outputPath, common.DefaultDirectoryPermission); err != nil {
+	if err := vfs.MkdirAll(outputPath, common.DefaultDirectoryPermission); err != nil {
 		return nil, fmt.Errorf("failed to create the output directory at path '%s' . Error: %w", outputPath, err)
 	}
 	filesWritten := []string{}
@@ -102,7 +104,7 @@ func writeObjects(outputPath string, objs []runtime.Object) ([]string, error) {
 			continue
 		}
 		yamlPath := filepath.Join(outputPath, getFilename(obj))
-		if err := os.WriteFile(yamlPath, objYamlBytes, common.DefaultFilePermission); err != nil {
+		if err := vfs.WriteFile(yamlPath, objYamlBytes, common.DefaultFilePermission); err != nil {
 			logrus.Errorf("failed to write the yaml to file at path '%s' . Error: %q", yamlPath, err)
 			continue
 		}
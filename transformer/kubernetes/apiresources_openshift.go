@@ -0,0 +1,27 @@
+//go:build !m2k_no_openshift
+// +build !m2k_no_openshift
+
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package kubernetes
+
+import "github.com/konveyor/move2kube-wasm/transformer/kubernetes/apiresource"
+
+// openshiftAPIResources are the API resources that only exist on OpenShift clusters
+var openshiftAPIResources = []apiresource.IAPIResource{
+	new(apiresource.ImageStream),
+}
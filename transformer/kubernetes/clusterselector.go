@@ -17,6 +17,7 @@
 package kubernetes
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/konveyor/move2kube-wasm/common"
@@ -41,7 +42,7 @@ const (
 	ClusterMetadata transformertypes.ConfigType = "ClusterMetadata"
 )
 
-// ClusterSelectorTransformer implements Transformer interface
+// ClusterSelectorTransformer implements the ContextTransformer interface
 type ClusterSelectorTransformer struct {
 	Config   transformertypes.Transformer
 	Env      *environment.Environment
@@ -55,7 +56,7 @@ type ClusterSelectorConfig struct {
 }
 
 // Init Initializes the transformer
-func (t *ClusterSelectorTransformer) Init(tc transformertypes.Transformer, e *environment.Environment) error {
+func (t *ClusterSelectorTransformer) Init(ctx context.Context, tc transformertypes.Transformer, e *environment.Environment) error {
 	t.Config = tc
 	t.Env = e
 	t.CSConfig = &ClusterSelectorConfig{}
@@ -93,12 +94,15 @@ func (t *ClusterSelectorTransformer) GetConfig() (transformertypes.Transformer,
 }
 
 // DirectoryDetect runs detect in each subdirectory
-func (t *ClusterSelectorTransformer) DirectoryDetect(dir string) (services map[string][]transformertypes.Artifact, err error) {
+func (t *ClusterSelectorTransformer) DirectoryDetect(ctx context.Context, dir string) (services map[string][]transformertypes.Artifact, err error) {
 	return nil, nil
 }
 
 // Transform transforms artifacts
-func (t *ClusterSelectorTransformer) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) (pathMappings []transformertypes.PathMapping, createdArtifacts []transformertypes.Artifact, err error) {
+func (t *ClusterSelectorTransformer) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) (pathMappings []transformertypes.PathMapping, createdArtifacts []transformertypes.Artifact, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
 	clusterTypeList := []string{}
 	for c := range t.Clusters {
 		clusterTypeList = append(clusterTypeList, c)
@@ -113,6 +117,7 @@ func (t *ClusterSelectorTransformer) Transform(newArtifacts []transformertypes.A
 		def = clusterTypeList[0]
 	}
 	clusterType := qaengine.FetchSelectAnswer(
+		ctx,
 		common.JoinQASubKeys(common.ConfigTargetKey, `"`+t.CSConfig.ClusterQaLabel+`"`, clusterTypeKey),
 		"Choose the cluster type:",
 		[]string{"Choose the cluster type you would like to target"}, def, clusterTypeList,
@@ -17,6 +17,7 @@
 package irpreprocessor
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -32,7 +33,7 @@ import (
 type ingressPreprocessor struct {
 }
 
-func (opt *ingressPreprocessor) preprocess(ir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error) {
+func (opt *ingressPreprocessor) preprocess(ctx context.Context, ir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error) {
 	for serviceName, service := range ir.Services {
 		tempService := ir.Services[serviceName]
 		for portForwardingIdx, portForwarding := range service.ServiceToPodPortForwardings {
@@ -52,7 +53,7 @@ func (opt *ingressPreprocessor) preprocess(ir irtypes.IR, targetCluster collecti
 			desc := fmt.Sprintf("What kind of service/ingress should be created for the service %s's %d port?", serviceName, portForwarding.ServicePort.Number)
 			hints := []string{"Choose " + common.IngressKind + " if you want a ingress/route resource to be created"}
 			quesKey := common.JoinQASubKeys(portKeyPart, "servicetype")
-			portForwarding.ServiceType = core.ServiceType(qaengine.FetchSelectAnswer(quesKey, desc, hints, common.IngressKind, options, nil))
+			portForwarding.ServiceType = core.ServiceType(qaengine.FetchSelectAnswer(ctx, quesKey, desc, hints, common.IngressKind, options, nil))
 			if string(portForwarding.ServiceType) == noneServiceType {
 				portForwarding.ServiceType = ""
 			}
@@ -60,7 +61,7 @@ func (opt *ingressPreprocessor) preprocess(ir irtypes.IR, targetCluster collecti
 				desc := fmt.Sprintf("Specify the ingress path to expose the service %s's %d port on?", serviceName, portForwarding.ServicePort.Number)
 				hints := []string{"Leave out leading / to use first part as subdomain"}
 				quesKey := common.JoinQASubKeys(portKeyPart, "urlpath")
-				portForwarding.ServiceRelPath = strings.TrimSpace(qaengine.FetchStringAnswer(quesKey, desc, hints, portForwarding.ServiceRelPath, nil))
+				portForwarding.ServiceRelPath = strings.TrimSpace(qaengine.FetchStringAnswer(ctx, quesKey, desc, hints, portForwarding.ServiceRelPath, nil))
 				portForwarding.ServiceType = core.ServiceTypeClusterIP
 			} else {
 				portForwarding.ServiceRelPath = ""
@@ -17,6 +17,7 @@
 package irpreprocessor
 
 import (
+	"context"
 	"github.com/konveyor/move2kube-wasm/types/collection"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	"github.com/sirupsen/logrus"
@@ -24,7 +25,7 @@ import (
 
 // irpreprocessor optimizes the configuration
 type irpreprocessor interface {
-	preprocess(sourceir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error)
+	preprocess(ctx context.Context, sourceir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error)
 }
 
 // getIRPreprocessors returns optimizers
@@ -35,13 +36,13 @@ func getIRPreprocessors() []irpreprocessor {
 }
 
 // Preprocess preprocesses IR before application artifacts are generated
-func Preprocess(ir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error) {
+func Preprocess(ctx context.Context, ir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error) {
 	optimizers := getIRPreprocessors()
 	logrus.Debug("Begin Optimization")
 	for _, o := range optimizers {
 		logrus.Debugf("[%T] Begin Optimization", o)
 		var err error
-		ir, err = o.preprocess(ir, targetCluster)
+		ir, err = o.preprocess(ctx, ir, targetCluster)
 		if err != nil {
 			logrus.Warnf("[%T] Failed : %s", o, err.Error())
 		} else {
@@ -17,6 +17,7 @@
 package irpreprocessor
 
 import (
+	"context"
 	"github.com/konveyor/move2kube-wasm/types/collection"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	core "k8s.io/kubernetes/pkg/apis/core"
@@ -28,7 +29,7 @@ type mergePreprocessor struct {
 }
 
 // Preprocesses the port forwardings
-func (opt *mergePreprocessor) preprocess(ir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error) {
+func (opt *mergePreprocessor) preprocess(ctx context.Context, ir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error) {
 	for serviceName, service := range ir.Services {
 		service.Containers = opt.mergeContainers(service.Containers)
 		pfs := service.ServiceToPodPortForwardings
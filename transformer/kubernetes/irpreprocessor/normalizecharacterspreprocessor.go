@@ -17,6 +17,7 @@
 package irpreprocessor
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
@@ -29,7 +30,7 @@ import (
 type normalizeCharacterPreprocessor struct {
 }
 
-func (po normalizeCharacterPreprocessor) preprocess(ir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error) {
+func (po normalizeCharacterPreprocessor) preprocess(ctx context.Context, ir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error) {
 	//TODO: Make this generic to ensure all fields have valid names
 	for i := range ir.Services {
 		for j := range ir.Services[i].Containers {
@@ -18,6 +18,7 @@ package irpreprocessor
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -52,7 +53,7 @@ const (
 	imagePullSecretSuffix = "-imagepullsecret"
 )
 
-func (p registryPreProcessor) preprocess(ir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error) {
+func (p registryPreProcessor) preprocess(ctx context.Context, ir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error) {
 	// find all the new images that we are going to create
 
 	newImageNames := []string{}
@@ -81,7 +82,7 @@ func (p registryPreProcessor) preprocess(ir irtypes.IR, targetCluster collection
 
 	// ask the user for the registry url where new images should be pushed
 
-	registryToPushImagesTo := commonqa.ImageRegistry()
+	registryToPushImagesTo := commonqa.ImageRegistry(ctx)
 	usedRegistries = common.AppendIfNotPresent(usedRegistries, registryToPushImagesTo)
 
 	// get the login credentials for each registry we use by parsing the docker config.json file
@@ -121,7 +122,7 @@ func (p registryPreProcessor) preprocess(ir irtypes.IR, targetCluster collection
 	// ask the user what type of login to use for each registry that we use
 
 	imagePullSecrets := map[string]string{} // registry url -> pull secret name
-	registryNamespace := commonqa.ImageRegistryNamespace()
+	registryNamespace := commonqa.ImageRegistryNamespace(ctx)
 	for _, registry := range usedRegistries {
 		if _, ok := imagePullSecrets[registry]; !ok {
 			imagePullSecrets[registry] = common.NormalizeForMetadataName(strings.ReplaceAll(registry, ".", "-") + imagePullSecretSuffix)
@@ -139,7 +140,7 @@ func (p registryPreProcessor) preprocess(ir irtypes.IR, targetCluster collection
 		}
 		quesKey := fmt.Sprintf(common.ConfigImageRegistryLoginTypeKey, `"`+registry+`"`)
 		desc := fmt.Sprintf("[%s] What type of container registry login do you want to use?", registry)
-		auth := qaengine.FetchSelectAnswer(quesKey, desc, nil, string(defaultOption), authOptions, nil)
+		auth := qaengine.FetchSelectAnswer(ctx, quesKey, desc, nil, string(defaultOption), authOptions, nil)
 		createPullSecret := false
 		switch registryLoginOption(auth) {
 		case noLogin:
@@ -148,6 +149,7 @@ func (p registryPreProcessor) preprocess(ir irtypes.IR, targetCluster collection
 		case existingPullSecretLogin:
 			qaKey := fmt.Sprintf(common.ConfigImageRegistryPullSecretKey, `"`+registry+`"`)
 			ps := qaengine.FetchStringAnswer(
+				ctx,
 				qaKey,
 				fmt.Sprintf("[%s] Enter the name of the pull secret : ", registry),
 				[]string{"The pull secret should exist in the namespace where you will be deploying the application."},
@@ -161,9 +163,9 @@ func (p registryPreProcessor) preprocess(ir irtypes.IR, targetCluster collection
 		case usernamePasswordLogin:
 			createPullSecret = true
 			qaUsernameKey := fmt.Sprintf(common.ConfigImageRegistryUserNameKey, `"`+registry+`"`)
-			regAuth.Username = qaengine.FetchStringAnswer(qaUsernameKey, fmt.Sprintf("[%s] Enter the username to login into the registry : ", registry), nil, "iamapikey", nil)
+			regAuth.Username = qaengine.FetchStringAnswer(ctx, qaUsernameKey, fmt.Sprintf("[%s] Enter the username to login into the registry : ", registry), nil, "iamapikey", nil)
 			qaPasswordKey := fmt.Sprintf(common.ConfigImageRegistryPasswordKey, `"`+registry+`"`)
-			regAuth.Password = qaengine.FetchPasswordAnswer(qaPasswordKey, fmt.Sprintf("[%s] Enter the password to login into the registry : ", registry), nil, nil)
+			regAuth.Password = qaengine.FetchPasswordAnswer(ctx, qaPasswordKey, fmt.Sprintf("[%s] Enter the password to login into the registry : ", registry), nil, nil)
 		case dockerConfigLogin:
 			createPullSecret = true
 			logrus.Debugf("using the credentials from the docker config.json file")
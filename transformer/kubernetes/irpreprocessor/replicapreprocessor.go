@@ -17,6 +17,7 @@
 package irpreprocessor
 
 import (
+	"context"
 	"github.com/konveyor/move2kube-wasm/types/collection"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
@@ -32,8 +33,8 @@ const (
 	minReplicas int = 2
 )
 
-func (ep replicaPreprocessor) preprocess(ir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error) {
-	replicaCountStr := commonqa.MinimumReplicaCount(cast.ToString(minReplicas))
+func (ep replicaPreprocessor) preprocess(ctx context.Context, ir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error) {
+	replicaCountStr := commonqa.MinimumReplicaCount(ctx, cast.ToString(minReplicas))
 	replicaCount, err := cast.ToIntE(replicaCountStr)
 	if err != nil {
 		logrus.Errorf("Replica count %s is not a number. Reverting to default %d.", replicaCountStr, minReplicas)
@@ -15,6 +15,7 @@
  */package irpreprocessor
 
 import (
+	"context"
 	"github.com/konveyor/move2kube-wasm/types/collection"
 	irtypes "github.com/konveyor/move2kube-wasm/types/ir"
 	"github.com/konveyor/move2kube-wasm/types/qaengine/commonqa"
@@ -26,14 +27,14 @@ const statefulSetKind = "StatefulSet"
 type statefulsetPreprocessor struct {
 }
 
-func (sp statefulsetPreprocessor) preprocess(ir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error) {
+func (sp statefulsetPreprocessor) preprocess(ctx context.Context, ir irtypes.IR, targetCluster collection.ClusterMetadata) (irtypes.IR, error) {
 	if targetCluster.Spec.GetSupportedVersions(statefulSetKind) == nil {
 		logrus.Debug("StatefulSets not supported by target cluster.\n")
 		return ir, nil
 	}
 
 	for k, scObj := range ir.Services {
-		isStateful := commonqa.IsStateful(scObj.Name)
+		isStateful := commonqa.IsStateful(ctx, scObj.Name)
 		scObj.StatefulSet = isStateful
 		ir.Services[k] = scObj
 	}
@@ -19,11 +19,11 @@ package k8sschema
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/types"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
@@ -129,7 +129,7 @@ func GetK8sResourcesWithPaths(k8sResourcesPath string, currDirOnly bool) (map[st
 	}
 	k8sResources := map[string][]K8sResourceT{}
 	for _, yamlPath := range yamlPaths {
-		k8sYamlBytes, err := os.ReadFile(yamlPath)
+		k8sYamlBytes, err := vfs.ReadFile(yamlPath)
 		if err != nil {
 			logrus.Errorf("Failed to read the yaml file at path %s . Error: %q", yamlPath, err)
 			continue
@@ -179,7 +179,7 @@ func GetKubernetesObjsInDir(dir string) []runtime.Object {
 		return nil
 	}
 	for _, filePath := range filePaths {
-		data, err := os.ReadFile(filePath)
+		data, err := vfs.ReadFile(filePath)
 		if err != nil {
 			logrus.Debugf("Failed to read the yaml file at path %q Error: %q", filePath, err)
 			continue
@@ -17,6 +17,7 @@
 package kubernetes
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -39,7 +40,7 @@ const (
 	setDefaultValuesInYamls   = false
 )
 
-// Kubernetes implements Transformer interface
+// Kubernetes implements the ContextTransformer interface
 type Kubernetes struct {
 	Config           transformertypes.Transformer
 	Env              *environment.Environment
@@ -60,7 +61,7 @@ type KubernetesPathTemplateConfig struct {
 }
 
 // Init Initializes the transformer
-func (t *Kubernetes) Init(tc transformertypes.Transformer, e *environment.Environment) error {
+func (t *Kubernetes) Init(ctx context.Context, tc transformertypes.Transformer, e *environment.Environment) error {
 	t.Config = tc
 	t.Env = e
 	t.KubernetesConfig = &KubernetesYamlConfig{}
@@ -87,17 +88,20 @@ func (t *Kubernetes) GetConfig() (transformertypes.Transformer, *environment.Env
 }
 
 // DirectoryDetect runs detect in each subdirectory
-func (t *Kubernetes) DirectoryDetect(dir string) (services map[string][]transformertypes.Artifact, err error) {
+func (t *Kubernetes) DirectoryDetect(ctx context.Context, dir string) (services map[string][]transformertypes.Artifact, err error) {
 	return nil, nil
 }
 
 // Transform transforms artifacts
-func (t *Kubernetes) Transform(newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) (pathMappings []transformertypes.PathMapping, createdArtifacts []transformertypes.Artifact, err error) {
+func (t *Kubernetes) Transform(ctx context.Context, newArtifacts []transformertypes.Artifact, alreadySeenArtifacts []transformertypes.Artifact) (pathMappings []transformertypes.PathMapping, createdArtifacts []transformertypes.Artifact, err error) {
 	logrus.Trace("Kubernetes.Transform start")
 	defer logrus.Trace("Kubernetes.Transform end")
 	pathMappings = []transformertypes.PathMapping{}
 	createdArtifacts = []transformertypes.Artifact{}
 	for _, newArtifact := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		if newArtifact.Type != irtypes.IRArtifactType {
 			continue
 		}
@@ -129,7 +133,7 @@ func (t *Kubernetes) Transform(newArtifacts []transformertypes.Artifact, already
 			logrus.Errorf("Evaluating IngressName in Kubernetes transformer resulting in empty string. Defaulting to Artifact Name.")
 			ir.Name = newArtifact.Name
 		}
-		preprocessedIR, err := irpreprocessor.Preprocess(ir, clusterConfig)
+		preprocessedIR, err := irpreprocessor.Preprocess(ctx, ir, clusterConfig)
 		if err != nil {
 			logrus.Errorf("failed to pre-preocess the IR. Error: %q", err)
 		} else {
@@ -142,10 +146,10 @@ func (t *Kubernetes) Transform(newArtifacts []transformertypes.Artifact, already
 			new(apiresource.Deployment),
 			new(apiresource.Storage),
 			new(apiresource.Service),
-			new(apiresource.ImageStream),
-			new(apiresource.NetworkPolicy),
 		}
-		files, err := apiresource.TransformIRAndPersist(irtypes.NewEnhancedIRFromIR(ir), tempDest, apis, clusterConfig, t.KubernetesConfig.SetDefaultValuesInYamls)
+		apis = append(apis, openshiftAPIResources...)
+		apis = append(apis, new(apiresource.NetworkPolicy))
+		files, err := apiresource.TransformIRAndPersist(ctx, irtypes.NewEnhancedIRFromIR(ir), tempDest, apis, clusterConfig, t.KubernetesConfig.SetDefaultValuesInYamls)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to transform and persist the IR. Error: %w", err)
 		}
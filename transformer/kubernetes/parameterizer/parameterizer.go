@@ -17,14 +17,15 @@
 package parameterizer
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/konveyor/move2kube-wasm/common"
 	"github.com/konveyor/move2kube-wasm/common/deepcopy"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/qaengine"
 	"github.com/konveyor/move2kube-wasm/transformer/kubernetes/k8sschema"
 	"github.com/konveyor/move2kube-wasm/types"
@@ -48,7 +49,7 @@ var (
 )
 
 // Parameterize does the parameterization based on a spec
-func Parameterize(srcDir, outDir string, packSpecConfig ParameterizerConfigT, ps []ParameterizerT) ([]string, error) {
+func Parameterize(ctx context.Context, srcDir, outDir string, packSpecConfig ParameterizerConfigT, ps []ParameterizerT) ([]string, error) {
 	logrus.Trace("Parameterize start")
 	defer logrus.Trace("Parameterize end")
 	filesWritten := []string{}
@@ -87,13 +88,13 @@ func Parameterize(srcDir, outDir string, packSpecConfig ParameterizerConfigT, ps
 		helmChartDir := filepath.Join(cleanOutDir, packSpecConfig.Helm, helmChartName)
 
 		helmTemplatesDir := filepath.Join(helmChartDir, "templates")
-		if err := os.MkdirAll(helmTemplatesDir, common.DefaultDirectoryPermission); err != nil {
+		if err := vfs.MkdirAll(helmTemplatesDir, common.DefaultDirectoryPermission); err != nil {
 			logrus.Errorf("Unable to create directory for helm : %s", err)
 		} else {
 			for kPath, ks := range pathedKs {
 				for _, k := range ks {
 					k = deepcopy.DeepCopy(k).(k8sschema.K8sResourceT)
-					if err := parameterize(TargetHelm, packSpecConfig.Envs, k, ps, namedValues, nil, nil); err != nil {
+					if err := parameterize(ctx, TargetHelm, packSpecConfig.Envs, k, ps, namedValues, nil, nil); err != nil {
 						logrus.Errorf("Unable to parameterize for helm : %s", err)
 						continue
 					}
@@ -135,7 +136,7 @@ func Parameterize(srcDir, outDir string, packSpecConfig ParameterizerConfigT, ps
 		// kustomize json patches with multiple overlays
 		kustDir := filepath.Join(cleanOutDir, packSpecConfig.Kustomize)
 		baseDir := filepath.Join(kustDir, "base")
-		if err := os.MkdirAll(baseDir, common.DefaultDirectoryPermission); err != nil {
+		if err := vfs.MkdirAll(baseDir, common.DefaultDirectoryPermission); err != nil {
 			logrus.Errorf("Unable to create directory %s : %s", baseDir, err)
 		} else {
 			kustPatches := map[string]map[PatchMetadataT][]PatchT{}
@@ -151,7 +152,7 @@ func Parameterize(srcDir, outDir string, packSpecConfig ParameterizerConfigT, ps
 					}
 					// compute the json patch
 					currKustPatches := map[string]map[string]PatchT{} // keyed by env and json pointer/path
-					if err := parameterize(TargetKustomize, packSpecConfig.Envs, k, ps, nil, currKustPatches, nil); err != nil {
+					if err := parameterize(ctx, TargetKustomize, packSpecConfig.Envs, k, ps, nil, currKustPatches, nil); err != nil {
 						logrus.Errorf("Unable to parameterize %s : %s", finalKPath, err)
 					}
 					// patch metadata to put in kustomization.yaml
@@ -190,7 +191,7 @@ func Parameterize(srcDir, outDir string, packSpecConfig ParameterizerConfigT, ps
 			// create a overlay for each env
 			for env, kMetaPatches := range kustPatches {
 				envDir := filepath.Join(kustDir, "overlays", env)
-				if err := os.MkdirAll(envDir, common.DefaultDirectoryPermission); err != nil {
+				if err := vfs.MkdirAll(envDir, common.DefaultDirectoryPermission); err != nil {
 					logrus.Errorf("Unable to create overlay dir for env %s (%s) : %s", env, envDir, err)
 					continue
 				}
@@ -221,7 +222,7 @@ func Parameterize(srcDir, outDir string, packSpecConfig ParameterizerConfigT, ps
 		for _, ks := range pathedKs {
 			for _, k := range ks {
 				k = deepcopy.DeepCopy(k).(k8sschema.K8sResourceT)
-				if err := parameterize(TargetOCTemplates, packSpecConfig.Envs, k, ps, nil, nil, ocParams); err != nil {
+				if err := parameterize(ctx, TargetOCTemplates, packSpecConfig.Envs, k, ps, nil, nil, ocParams); err != nil {
 					logrus.Errorf("Unable to parameterize for OC Templates : %s", err)
 					continue
 				}
@@ -246,7 +247,7 @@ func Parameterize(srcDir, outDir string, packSpecConfig ParameterizerConfigT, ps
 		}
 		ocDir := filepath.Join(cleanOutDir, packSpecConfig.OCTemplates)
 
-		if err := os.MkdirAll(ocDir, common.DefaultDirectoryPermission); err != nil {
+		if err := vfs.MkdirAll(ocDir, common.DefaultDirectoryPermission); err != nil {
 			logrus.Errorf("Unable to create OC templates dir %s : %s", ocDir, err)
 		} else {
 			finalKPath := filepath.Join(ocDir, "template.yaml")
@@ -264,7 +265,7 @@ func Parameterize(srcDir, outDir string, packSpecConfig ParameterizerConfigT, ps
 				for k, v := range params {
 					finalParams = append(finalParams, fmt.Sprintf("%s=%s", k, v))
 				}
-				if err := os.WriteFile(finalKPath, []byte(strings.Join(finalParams, "\n")), common.DefaultFilePermission); err != nil {
+				if err := vfs.WriteFile(finalKPath, []byte(strings.Join(finalParams, "\n")), common.DefaultFilePermission); err != nil {
 					logrus.Errorf("Unable to write to %s : %s", finalKPath, err)
 					continue
 				}
@@ -435,6 +436,7 @@ func fillCustomTemplate(templ, kind, apiVersion, metadataName string, matches ma
 // Parameterization
 
 func parameterize(
+	ctx context.Context,
 	target ParamTargetT,
 	envs []string,
 	k k8sschema.K8sResourceT,
@@ -453,15 +455,15 @@ func parameterize(
 		}
 		switch target {
 		case TargetHelm:
-			if err := parameterizeHelperHelm(envs, k, p, namedValues, namedKustPatches, namedOCParams); err != nil {
+			if err := parameterizeHelperHelm(ctx, envs, k, p, namedValues, namedKustPatches, namedOCParams); err != nil {
 				return err
 			}
 		case TargetKustomize:
-			if err := parameterizeHelperKustomize(envs, k, p, namedValues, namedKustPatches, namedOCParams); err != nil {
+			if err := parameterizeHelperKustomize(ctx, envs, k, p, namedValues, namedKustPatches, namedOCParams); err != nil {
 				return err
 			}
 		case TargetOCTemplates:
-			if err := parameterizeHelperOCTemplates(envs, k, p, namedValues, namedKustPatches, namedOCParams); err != nil {
+			if err := parameterizeHelperOCTemplates(ctx, envs, k, p, namedValues, namedKustPatches, namedOCParams); err != nil {
 				return err
 			}
 		default:
@@ -533,6 +535,7 @@ func parameterizeFilter(envs []string, k k8sschema.K8sResourceT, p Parameterizer
 }
 
 func parameterizeHelperHelm(
+	ctx context.Context,
 	envs []string,
 	k k8sschema.K8sResourceT,
 	p ParameterizerT,
@@ -589,7 +592,7 @@ func parameterizeHelperHelm(
 			}
 			origQuesDesc := p.Question.Desc
 			p.Question.Desc = filledDesc
-			ques, err := qaengine.FetchAnswer(*p.Question)
+			ques, err := qaengine.FetchAnswer(ctx, *p.Question)
 			if err != nil {
 				return fmt.Errorf("failed to ask a question to the user in order to parameterize a k8s resource: %+v\nError: %q", p.Question, err)
 			}
@@ -793,7 +796,7 @@ Actual value is %+v of type %T`,
 	return nil
 }
 
-func parameterizeHelperKustomize(envs []string, k k8sschema.K8sResourceT, p ParameterizerT, namedValues map[string]HelmValuesT, namedKustPatches map[string]map[string]PatchT, namedOCParams map[string]map[string]string) error {
+func parameterizeHelperKustomize(ctx context.Context, envs []string, k k8sschema.K8sResourceT, p ParameterizerT, namedValues map[string]HelmValuesT, namedKustPatches map[string]map[string]PatchT, namedOCParams map[string]map[string]string) error {
 	logrus.Trace("start parameterizeHelperKustomize")
 	defer logrus.Trace("end parameterizeHelperKustomize")
 
@@ -836,7 +839,7 @@ func parameterizeHelperKustomize(envs []string, k k8sschema.K8sResourceT, p Para
 			}
 			origQuesDesc := p.Question.Desc
 			p.Question.Desc = filledDesc
-			ques, err := qaengine.FetchAnswer(*p.Question)
+			ques, err := qaengine.FetchAnswer(ctx, *p.Question)
 			if err != nil {
 				return fmt.Errorf("failed to ask a question to the user in order to parameterize a k8s resource: %+v\nError: %q", p.Question, err)
 			}
@@ -877,7 +880,7 @@ func parameterizeHelperKustomize(envs []string, k k8sschema.K8sResourceT, p Para
 	return nil
 }
 
-func parameterizeHelperOCTemplates(envs []string, k k8sschema.K8sResourceT, p ParameterizerT, namedValues map[string]HelmValuesT, namedKustPatches map[string]map[string]PatchT, namedOCParams map[string]map[string]string) error {
+func parameterizeHelperOCTemplates(ctx context.Context, envs []string, k k8sschema.K8sResourceT, p ParameterizerT, namedValues map[string]HelmValuesT, namedKustPatches map[string]map[string]PatchT, namedOCParams map[string]map[string]string) error {
 	logrus.Trace("start parameterizeHelperOCTemplates")
 	defer logrus.Trace("end parameterizeHelperOCTemplates")
 
@@ -927,7 +930,7 @@ func parameterizeHelperOCTemplates(envs []string, k k8sschema.K8sResourceT, p Pa
 			}
 			origQuesDesc := p.Question.Desc
 			p.Question.Desc = filledDesc
-			ques, err := qaengine.FetchAnswer(*p.Question)
+			ques, err := qaengine.FetchAnswer(ctx, *p.Question)
 			if err != nil {
 				return fmt.Errorf("failed to ask a question to the user in order to parameterize a k8s resource: %+v\nError: %q", p.Question, err)
 			}
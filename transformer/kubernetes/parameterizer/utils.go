@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/transformer/kubernetes/k8sschema"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cast"
@@ -300,11 +301,11 @@ func writeResourceAppendToFile(k8sResource k8sschema.K8sResourceT, outputPath st
 		logrus.Error("Error while Encoding object")
 		return err
 	}
-	if err := os.MkdirAll(filepath.Dir(outputPath), common.DefaultDirectoryPermission); err != nil {
+	if err := vfs.MkdirAll(filepath.Dir(outputPath), common.DefaultDirectoryPermission); err != nil {
 		logrus.Fatalf("Failed to create the output directory at path %s Error: %q", filepath.Dir(outputPath), err)
 	}
 	// If the file doesn't exist, create it, or append to the file
-	f, err := os.OpenFile(outputPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, common.DefaultFilePermission)
+	f, err := vfs.OpenFile(outputPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, common.DefaultFilePermission)
 	if err != nil {
 		return fmt.Errorf("failed to open the file at path %s for creating/appending. Error: %q", outputPath, err)
 	}
@@ -325,11 +326,11 @@ func writeResourceStripQuotesAndAppendToFile(k8sResource k8sschema.K8sResourceT,
 		return err
 	}
 	strippedYamlBytes := stripHelmQuotesRegex.ReplaceAll(yamlBytes, []byte("$1"))
-	if err := os.MkdirAll(filepath.Dir(outputPath), common.DefaultDirectoryPermission); err != nil {
+	if err := vfs.MkdirAll(filepath.Dir(outputPath), common.DefaultDirectoryPermission); err != nil {
 		logrus.Fatalf("Failed to create the output directory at path %s Error: %q", filepath.Dir(outputPath), err)
 	}
 	// If the file doesn't exist, create it, or append to the file
-	f, err := os.OpenFile(outputPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, common.DefaultFilePermission)
+	f, err := vfs.OpenFile(outputPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, common.DefaultFilePermission)
 	if err != nil {
 		return fmt.Errorf("failed to open the file at path %s for creating/appending. Error: %q", outputPath, err)
 	}
@@ -17,11 +17,12 @@
 package kubernetes
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/environment"
 	"github.com/konveyor/move2kube-wasm/transformer/kubernetes/k8sschema"
 	"github.com/konveyor/move2kube-wasm/transformer/kubernetes/parameterizer"
@@ -41,7 +42,7 @@ const (
 	ExtraParameterizersConfigType transformertypes.ConfigType = "ExtraParameterizers"
 )
 
-// Parameterizer implements Transformer interface
+// Parameterizer implements the ContextTransformer interface
 type Parameterizer struct {
 	Config              transformertypes.Transformer
 	Env                 *environment.Environment
@@ -66,7 +67,7 @@ type ParameterizerPathTemplateConfig struct {
 }
 
 // Init Initializes the transformer
-func (paramTransformer *Parameterizer) Init(tc transformertypes.Transformer, e *environment.Environment) error {
+func (paramTransformer *Parameterizer) Init(ctx context.Context, tc transformertypes.Transformer, e *environment.Environment) error {
 	paramTransformer.Config = tc
 	paramTransformer.Env = e
 	paramTransformer.ParameterizerConfig = &ParameterizerYamlConfig{}
@@ -92,7 +93,7 @@ func (paramTransformer *Parameterizer) GetConfig() (transformertypes.Transformer
 }
 
 // DirectoryDetect runs detect in each subdirectory
-func (paramTransformer *Parameterizer) DirectoryDetect(dir string) (namedServices map[string][]transformertypes.Artifact, err error) {
+func (paramTransformer *Parameterizer) DirectoryDetect(ctx context.Context, dir string) (namedServices map[string][]transformertypes.Artifact, err error) {
 	k8sResMap, err := k8sschema.GetK8sResourcesWithPaths(dir, true)
 	if err != nil {
 		logrus.Debugf("failed to get K8s resources from the directory '%s' . Error: %q", dir, err)
@@ -117,16 +118,20 @@ func (paramTransformer *Parameterizer) DirectoryDetect(dir string) (namedService
 
 // Transform transforms artifacts
 func (paramTransformer *Parameterizer) Transform(
+	ctx context.Context,
 	newArtifacts []transformertypes.Artifact,
 	alreadySeenArtifacts []transformertypes.Artifact,
 ) (pathMappings []transformertypes.PathMapping, createdArtifacts []transformertypes.Artifact, err error) {
 	pathMappings = []transformertypes.PathMapping{}
 	for _, newArtifact := range newArtifacts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		if len(newArtifact.Paths[artifacts.KubernetesYamlsPathType]) == 0 {
 			continue
 		}
 		yamlsPath := newArtifact.Paths[artifacts.KubernetesYamlsPathType][0]
-		tempPath, err := os.MkdirTemp(paramTransformer.Env.TempPath, "*")
+		tempPath, err := vfs.MkdirTemp(paramTransformer.Env.TempPath, "*")
 		if err != nil {
 			logrus.Errorf("failed to create the temp directory '%s' . Error: %q", paramTransformer.Env.TempPath, err)
 			continue
@@ -169,7 +174,7 @@ func (paramTransformer *Parameterizer) Transform(
 		if len(paramTransformer.ParameterizerConfig.OCTemplatePath) == 0 {
 			pt.OCTemplates = ""
 		}
-		filesWritten, err := parameterizer.Parameterize(yamlsPath, destPath, pt, append(paramTransformer.parameterizers, moreParams...))
+		filesWritten, err := parameterizer.Parameterize(ctx, yamlsPath, destPath, pt, append(paramTransformer.parameterizers, moreParams...))
 		if err != nil {
 			logrus.Errorf(
 				"failed to parameterize the YAML files in the source directory '%s' and write to the output directory '%s' . Error: %q",
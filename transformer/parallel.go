@@ -0,0 +1,172 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/environment"
+	"github.com/konveyor/move2kube-wasm/qaengine"
+	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
+	provenancetypes "github.com/konveyor/move2kube-wasm/types/provenance"
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+)
+
+// transformJob is the work done by a single transformer in the consume phase of an iteration
+type transformJob struct {
+	transformer ContextTransformer
+	tConfig     transformertypes.Transformer
+	env         *environment.Environment
+	// artifactsToProcess are the artifacts the transformer consumes, before dependency processing
+	artifactsToProcess []transformertypes.Artifact
+	// fingerprints identify the artifacts to process, jobs with overlapping fingerprints never run concurrently
+	fingerprints map[string]bool
+
+	dependencyPathMappings []transformertypes.PathMapping
+	dependencyArtifacts    []transformertypes.Artifact
+	artifactsToConsume     []transformertypes.Artifact
+	output                 transformOutput
+	executeErr             error
+}
+
+// getParallelism returns the maximum number of transformers that can run concurrently in an iteration
+func getParallelism(ctx context.Context) int {
+	parallelism := qaengine.FetchStringAnswer(
+		ctx,
+		common.ConfigTransformersParallelismKey,
+		"Enter the maximum number of transformers that can run concurrently",
+		[]string{"Transformers that consume different artifacts in the same iteration can run concurrently. By default they run one after another. With more than 1 the questions asked by the transformers can come in any order."},
+		"1",
+		func(ans interface{}) error {
+			if n, err := cast.ToIntE(ans); err != nil || n < 1 {
+				return fmt.Errorf("the parallelism must be a positive integer. Actual: %v", ans)
+			}
+			return nil
+		},
+	)
+	return cast.ToInt(parallelism)
+}
+
+// transformConcurrently is the consume phase of transform where transformers with disjoint inputs run concurrently.
+// The transformers, which are sorted by name when they are initialized, are split into waves. Only the transformers themselves run
+// concurrently, the dependency processing, graph bookkeeping, post processing and pass through are done in order,
+// so the path mappings, artifacts and graph vertices are the same for every run.
+func transformConcurrently(ctx context.Context, newArtifactsToProcess, allArtifacts []transformertypes.Artifact, parallelism int, graph *graphtypes.Graph, manifest *provenancetypes.OutputManifest, iteration int) (pathMappings []transformertypes.PathMapping, newArtifactsCreated []transformertypes.Artifact) {
+	logrus.Trace("transformConcurrently start")
+	defer logrus.Trace("transformConcurrently end")
+	jobs := []*transformJob{}
+	for _, transformer := range registry.transformers {
+		tConfig, env := transformer.GetConfig()
+		if isTransformerAbandoned(transformer) {
+			logrus.Debugf("skipping the transformer named '%s' since it was abandoned after a timeout", tConfig.Name)
+			continue
+		}
+		artifactsToProcess, _ := getArtifactsToProcess(newArtifactsToProcess, allArtifacts, tConfig, consume)
+		if len(artifactsToProcess) == 0 {
+			logrus.Debugf("did not find any artifacts for the transformer named '%s' to process", tConfig.Name)
+			continue
+		}
+		job := &transformJob{transformer: transformer, tConfig: tConfig, env: env, artifactsToProcess: artifactsToProcess, fingerprints: map[string]bool{}}
+		for _, artifact := range artifactsToProcess {
+			job.fingerprints[getArtifactFingerprint(artifact)] = true
+		}
+		jobs = append(jobs, job)
+	}
+	for _, wave := range getTransformWaves(jobs, parallelism) {
+		if ctx.Err() != nil {
+			break
+		}
+		for _, job := range wave {
+			logrus.Debugf("Transformer '%s' will be processing %d artifacts in %d mode", job.tConfig.Name, len(job.artifactsToProcess), consume)
+			var dependencyUpdatedArtifacts []transformertypes.Artifact
+			job.dependencyPathMappings, job.dependencyArtifacts, dependencyUpdatedArtifacts = transform(ctx, job.artifactsToProcess, allArtifacts, dependency, job.tConfig.Spec.DependencySelector, graph, manifest, iteration)
+			var artifactsToNotConsume []transformertypes.Artifact
+			job.artifactsToConsume, artifactsToNotConsume = getArtifactsToProcess(dependencyUpdatedArtifacts, allArtifacts, job.tConfig, consume)
+			if len(artifactsToNotConsume) != 0 {
+				logrus.Errorf("Artifacts to not consume: %d. This should have been 0.", len(artifactsToNotConsume))
+			}
+		}
+		var wg sync.WaitGroup
+		for _, job := range wave {
+			logrus.Infof("Transformer '%s' processing %d artifacts", job.tConfig.Name, len(job.artifactsToConsume))
+			wg.Add(1)
+			go func(job *transformJob) {
+				defer wg.Done()
+				job.output, job.executeErr = executeSingleTransform(ctx, job.artifactsToConsume, allArtifacts, job.transformer, job.tConfig, job.env, iteration)
+			}(job)
+		}
+		wg.Wait()
+		for _, job := range wave {
+			pathMappings = append(pathMappings, job.dependencyPathMappings...)
+			var producedNewPathMappings []transformertypes.PathMapping
+			var producedNewArtifacts []transformertypes.Artifact
+			err := job.executeErr
+			if err == nil {
+				producedNewPathMappings, producedNewArtifacts, err = recordSingleTransform(job.artifactsToConsume, job.output, job.tConfig, job.env, graph, manifest, iteration)
+			}
+			if err != nil {
+				logrus.Errorf("failed to run a single transformation using the transformer %+v on the artifacts: %+v", job.tConfig, job.artifactsToConsume)
+				logrus.Error(err.Error())
+				reportTransformError(job.tConfig, job.artifactsToConsume, err)
+				continue
+			}
+			pathMappings = append(pathMappings, producedNewPathMappings...)
+			artifactsToPassThrough := append(job.dependencyArtifacts, producedNewArtifacts...)
+			passedThroughPathMappings, passedThroughNewArtifactsCreated, passedThroughUpdatedArtifacts := transform(ctx, artifactsToPassThrough, allArtifacts, passthrough, nil, graph, manifest, iteration)
+			pathMappings = append(pathMappings, passedThroughPathMappings...)
+			newArtifactsCreated = append(newArtifactsCreated, passedThroughNewArtifactsCreated...)
+			newArtifactsCreated = append(newArtifactsCreated, passedThroughUpdatedArtifacts...)
+			logrus.Infof("Transformer %s Done", job.tConfig.Name)
+		}
+	}
+	logrus.Debugf("Created %d pathMappings and %d artifacts from transform.", len(pathMappings), len(newArtifactsCreated))
+	return pathMappings, newArtifactsCreated
+}
+
+// getTransformWaves splits the jobs, in order, into groups of at most parallelism jobs whose artifacts to process are disjoint
+func getTransformWaves(jobs []*transformJob, parallelism int) [][]*transformJob {
+	waves := [][]*transformJob{}
+	wave := []*transformJob{}
+	waveFingerprints := map[string]bool{}
+	for _, job := range jobs {
+		overlaps := false
+		for fingerprint := range job.fingerprints {
+			if waveFingerprints[fingerprint] {
+				overlaps = true
+				break
+			}
+		}
+		if len(wave) != 0 && (overlaps || len(wave) >= parallelism) {
+			waves = append(waves, wave)
+			wave = []*transformJob{}
+			waveFingerprints = map[string]bool{}
+		}
+		wave = append(wave, job)
+		for fingerprint := range job.fingerprints {
+			waveFingerprints[fingerprint] = true
+		}
+	}
+	if len(wave) != 0 {
+		waves = append(waves, wave)
+	}
+	return waves
+}
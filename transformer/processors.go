@@ -18,10 +18,10 @@ package transformer
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	"github.com/konveyor/move2kube-wasm/filesystem"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
 	"github.com/sirupsen/logrus"
@@ -52,6 +52,7 @@ func processPathMappings(pms []transformertypes.PathMapping, sourcePath, outputP
 				return fmt.Errorf("failed to copy the source path '%s' to the destination path '%s' for the path mapping %+v . Error: %w", srcPath, destPath, pm, err)
 			}
 			logrus.Errorf("failed to copy the source path '%s' to the destination path '%s' for the path mapping %+v . Error: %q", srcPath, destPath, pm, err)
+			reportPathMappingError(pm, err)
 			continue
 		}
 		copiedSourceDests[getpair(pm.SrcPath, pm.DestPath)] = true
@@ -71,6 +72,7 @@ func processPathMappings(pms []transformertypes.PathMapping, sourcePath, outputP
 					return fmt.Errorf("failed to merge for the path mapping %+v . Error: %w", pm, err)
 				}
 				logrus.Errorf("Error while copying sourcepath for %+v . Error: %q", pm, err)
+				reportPathMappingError(pm, err)
 			}
 		case strings.ToLower(string(transformertypes.TemplatePathMappingType)):
 			if err := filesystem.TemplateCopy(pm.SrcPath, destPath, filesystem.AddOnConfig{Config: pm.TemplateConfig}); err != nil {
@@ -78,6 +80,7 @@ func processPathMappings(pms []transformertypes.PathMapping, sourcePath, outputP
 					return fmt.Errorf("failed to copy the template for the path mapping %+v . Error: %w", pm, err)
 				}
 				logrus.Errorf("Error while copying sourcepath for %+v . Error: %q", pm, err)
+				reportPathMappingError(pm, err)
 			}
 		case strings.ToLower(string(transformertypes.SpecialTemplatePathMappingType)):
 			if err := filesystem.TemplateCopy(
@@ -93,6 +96,7 @@ func processPathMappings(pms []transformertypes.PathMapping, sourcePath, outputP
 					return fmt.Errorf("failed to copy the special template for the path mapping %+v . Error: %w", pm, err)
 				}
 				logrus.Errorf("Error while copying sourcepath for %+v . Error: %q", pm, err)
+				reportPathMappingError(pm, err)
 			}
 		default:
 			if !copiedDefaultDests[getpair(pm.SrcPath, pm.DestPath)] {
@@ -101,6 +105,7 @@ func processPathMappings(pms []transformertypes.PathMapping, sourcePath, outputP
 						return fmt.Errorf("failed to merge for the path mapping %+v . Error: %w", pm, err)
 					}
 					logrus.Errorf("Error while copying sourcepath for %+v . Error: %q", pm, err)
+					reportPathMappingError(pm, err)
 				}
 				copiedDefaultDests[getpair(pm.SrcPath, pm.DestPath)] = true
 			}
@@ -115,11 +120,12 @@ func processPathMappings(pms []transformertypes.PathMapping, sourcePath, outputP
 		if !filepath.IsAbs(pm.DestPath) {
 			destPath = filepath.Join(outputPath, pm.DestPath)
 		}
-		if err := os.RemoveAll(destPath); err != nil {
+		if err := vfs.RemoveAll(destPath); err != nil {
 			if failOnFirstError {
 				return fmt.Errorf("failed to remove the destination path '%s' . Error: %w", destPath, err)
 			}
 			logrus.Errorf("Path [%s] marked by delete-path-mapping could not be deleted. Error: %q", destPath, err)
+			reportPathMappingError(pm, err)
 			continue
 		}
 		logrus.Debugf("Path [%s] marked by delete-path-mapping has been deleted", destPath)
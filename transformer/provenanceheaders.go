@@ -0,0 +1,199 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
+	"github.com/konveyor/move2kube-wasm/qaengine"
+	provenancetypes "github.com/konveyor/move2kube-wasm/types/provenance"
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+	"github.com/sirupsen/logrus"
+)
+
+const provenanceHeaderPrefix = "# Generated by move2kube."
+
+// writeOutputManifest writes the output manifest to the output directory and optionally
+// adds a header comment to each generated file that supports comments.
+func writeOutputManifest(ctx context.Context, manifest *provenancetypes.OutputManifest, outputPath string) error {
+	manifestPath := filepath.Join(outputPath, provenancetypes.OutputManifestFileName)
+	manifestBytes, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the output manifest to json. Error: %w", err)
+	}
+	if err := vfs.MkdirAll(outputPath, common.DefaultDirectoryPermission); err != nil {
+		return fmt.Errorf("failed to create the output directory at path %s . Error: %w", outputPath, err)
+	}
+	if err := vfs.WriteFile(manifestPath, manifestBytes, common.DefaultFilePermission); err != nil {
+		return fmt.Errorf("failed to write the output manifest to a file at path %s . Error: %w", manifestPath, err)
+	}
+	addHeaders := qaengine.FetchBoolAnswer(
+		ctx,
+		common.ConfigOutputProvenanceHeadersKey,
+		"Add a header comment to the generated files mentioning the transformer and the artifacts they were generated from?",
+		[]string{"The full details are always available in " + provenancetypes.OutputManifestFileName},
+		false,
+		nil,
+	)
+	if !addHeaders {
+		return nil
+	}
+	// the later path mappings overwrite the files written by the earlier ones
+	records := map[string]provenancetypes.Record{}
+	for _, record := range manifest.Records {
+		for _, file := range record.Files {
+			records[filepath.Join(record.DestPath, file)] = record
+		}
+	}
+	paths := []string{}
+	for path := range records {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		record := records[path]
+		if !isGeneratedPathMappingType(record.Type) {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(outputPath, path)
+		}
+		if !common.IsParent(path, outputPath) {
+			continue
+		}
+		if _, err := vfs.Stat(path); os.IsNotExist(err) {
+			// removed by a Delete path mapping
+			continue
+		}
+		header := fmt.Sprintf(
+			"%s transformer: %s, iteration: %d, artifacts: %s",
+			provenanceHeaderPrefix, record.Transformer, record.Iteration, strings.Join(record.ConsumedArtifacts, ", "),
+		)
+		if err := addProvenanceHeader(path, header); err != nil {
+			logrus.Errorf("failed to add the provenance header to the file at path %s . Error: %q", path, err)
+		}
+	}
+	return nil
+}
+
+// isGeneratedPathMappingType returns true for the path mappings whose files are generated by the transformer instead of copied from the source
+func isGeneratedPathMappingType(pathMappingType transformertypes.PathMappingType) bool {
+	switch strings.ToLower(string(pathMappingType)) {
+	case "", strings.ToLower(string(transformertypes.DefaultPathMappingType)),
+		strings.ToLower(string(transformertypes.TemplatePathMappingType)),
+		strings.ToLower(string(transformertypes.SpecialTemplatePathMappingType)):
+		return true
+	}
+	return false
+}
+
+// getPathMappingFiles returns the files written by each path mapping, relative to its destination path.
+// The files are not known for the Source, Delete and PathTemplate path mappings.
+func getPathMappingFiles(pathMappings []transformertypes.PathMapping) [][]string {
+	files := [][]string{}
+	for _, pathMapping := range pathMappings {
+		pathMappingFiles, err := getFilesWrittenByPathMapping(pathMapping)
+		if err != nil {
+			logrus.Debugf("failed to get the files written by the path mapping %+v . Error: %q", pathMapping, err)
+		}
+		files = append(files, pathMappingFiles)
+	}
+	return files
+}
+
+// getFilesWrittenByPathMapping returns the files written by the path mapping, relative to its destination path.
+// The names of the files copied by Template path mappings are filled in using the template config, like filesystem.TemplateCopy does.
+func getFilesWrittenByPathMapping(pathMapping transformertypes.PathMapping) ([]string, error) {
+	isTemplate := false
+	switch strings.ToLower(string(pathMapping.Type)) {
+	case strings.ToLower(string(transformertypes.TemplatePathMappingType)), strings.ToLower(string(transformertypes.SpecialTemplatePathMappingType)):
+		isTemplate = true
+	case "", strings.ToLower(string(transformertypes.DefaultPathMappingType)), strings.ToLower(string(transformertypes.ModifiedSourcePathMappingType)):
+	default:
+		return nil, nil
+	}
+	fi, err := vfs.Stat(pathMapping.SrcPath)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return []string{"."}, nil
+	}
+	files := []string{}
+	err = vfs.WalkDir(pathMapping.SrcPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(pathMapping.SrcPath, path)
+		if err != nil {
+			return err
+		}
+		if isTemplate {
+			if relPath, err = common.GetStringFromTemplate(relPath, pathMapping.TemplateConfig); err != nil {
+				return err
+			}
+		}
+		files = append(files, relPath)
+		return nil
+	})
+	return files, err
+}
+
+// addProvenanceHeader adds a header comment to files whose formats use # for comments
+func addProvenanceHeader(path, header string) error {
+	base := filepath.Base(path)
+	ext := strings.ToLower(filepath.Ext(base))
+	if ext != ".yaml" && ext != ".yml" && ext != ".sh" && !strings.HasPrefix(base, "Dockerfile") {
+		return nil
+	}
+	contentBytes, err := vfs.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the file at path %s . Error: %w", path, err)
+	}
+	content := string(contentBytes)
+	if strings.Contains(content, provenanceHeaderPrefix) {
+		return nil
+	}
+	fi, err := vfs.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat the file at path %s . Error: %w", path, err)
+	}
+	if strings.HasPrefix(content, "#!") {
+		// keep the shebang on the first line
+		idx := strings.Index(content, "\n")
+		if idx == -1 {
+			content = content + "\n" + header + "\n"
+		} else {
+			content = content[:idx+1] + header + "\n" + content[idx+1:]
+		}
+	} else {
+		content = header + "\n" + content
+	}
+	return vfs.WriteFile(path, []byte(content), fi.Mode())
+}
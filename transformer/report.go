@@ -0,0 +1,64 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"errors"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/types/report"
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+)
+
+// pathMappingError is returned when the path mappings created by a transformer could not be processed
+type pathMappingError struct {
+	err error
+}
+
+func (e *pathMappingError) Error() string {
+	return e.err.Error()
+}
+
+func (e *pathMappingError) Unwrap() error {
+	return e.err
+}
+
+// reportTransformError records the failure of a transformer on the artifacts it consumed in the error report
+func reportTransformError(tConfig transformertypes.Transformer, artifacts []transformertypes.Artifact, err error) {
+	kind := report.TransformFailure
+	var pmErr *pathMappingError
+	if errors.As(err, &pmErr) {
+		kind = report.PathMappingFailure
+	}
+	report.AddError(kind, err, report.RunError{Transformer: tConfig.Name, Services: getServiceNames(artifacts)})
+}
+
+// reportPathMappingError records a path mapping that could not be processed in the error report
+func reportPathMappingError(pm transformertypes.PathMapping, err error) {
+	report.AddError(report.PathMappingFailure, err, report.RunError{Transformer: pm.TransformerName, Path: pm.DestPath})
+}
+
+// getServiceNames returns the unique names of the artifacts
+func getServiceNames(artifacts []transformertypes.Artifact) []string {
+	names := []string{}
+	for _, artifact := range artifacts {
+		if artifact.Name != "" {
+			names = common.AppendIfNotPresent(names, artifact.Name)
+		}
+	}
+	return names
+}
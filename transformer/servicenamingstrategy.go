@@ -0,0 +1,322 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/konveyor/move2kube-wasm/common"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
+	"github.com/konveyor/move2kube-wasm/qaengine"
+	"github.com/konveyor/move2kube-wasm/transformer/dockerfilegenerator"
+	dotnetutils "github.com/konveyor/move2kube-wasm/transformer/dockerfilegenerator/dotnet"
+	plantypes "github.com/konveyor/move2kube-wasm/types/plan"
+	"github.com/konveyor/move2kube-wasm/types/source/maven"
+	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// ServiceNamingStrategy names the services that were detected without a name
+type ServiceNamingStrategy interface {
+	// NameServices returns the services keyed by their names. Unnamed services are stored under the empty key.
+	NameServices(projectName string, services map[string][]plantypes.PlanArtifact) map[string][]plantypes.PlanArtifact
+}
+
+const (
+	// DirectoryServiceNamingStrategy names services using the directory structure of the source
+	DirectoryServiceNamingStrategy = "directory"
+	// ManifestServiceNamingStrategy names services using the metadata in their build manifests (package.json, pom.xml, go.mod, etc.)
+	ManifestServiceNamingStrategy = "manifest"
+)
+
+var serviceNamingStrategies = map[string]ServiceNamingStrategy{
+	DirectoryServiceNamingStrategy: directoryNamingStrategy{},
+	ManifestServiceNamingStrategy:  manifestNamingStrategy{},
+}
+
+// RegisterServiceNamingStrategy allows for adding service naming strategies after initialization
+func RegisterServiceNamingStrategy(name string, strategy ServiceNamingStrategy) error {
+	if _, ok := serviceNamingStrategies[name]; ok {
+		return fmt.Errorf("couldn't register the service naming strategy '%s' because a strategy with that name already exists", name)
+	}
+	serviceNamingStrategies[name] = strategy
+	return nil
+}
+
+// GetServiceNaming asks for the service naming strategy and the naming rules
+func GetServiceNaming(ctx context.Context) plantypes.ServiceNaming {
+	strategyNames := []string{}
+	for strategyName := range serviceNamingStrategies {
+		strategyNames = append(strategyNames, strategyName)
+	}
+	sort.Strings(strategyNames)
+	serviceNaming := plantypes.ServiceNaming{}
+	serviceNaming.Strategy = qaengine.FetchSelectAnswer(
+		ctx,
+		common.ConfigServiceNamingStrategyKey,
+		"Select the strategy to use for naming the services:",
+		[]string{
+			"directory: derive the names from the directory structure of the source.",
+			"manifest: use the names in package.json, pom.xml, go.mod, .csproj and Cargo.toml, falling back to directory.",
+		},
+		DirectoryServiceNamingStrategy,
+		strategyNames,
+		nil,
+	)
+	renameRules := qaengine.FetchMultilineInputAnswer(
+		ctx,
+		common.ConfigServiceNamingRenameRulesKey,
+		"Specify the rules to rename the services:",
+		[]string{"One rule per line in the format <regex>=<replacement> . Example: ^src-(.*)$=$1"},
+		"",
+		func(ans interface{}) error {
+			_, err := parseServiceRenameRules(cast.ToString(ans))
+			return err
+		},
+	)
+	var err error
+	if serviceNaming.RenameRules, err = parseServiceRenameRules(renameRules); err != nil {
+		logrus.Errorf("failed to parse the service rename rules. Ignoring them. Error: %q", err)
+		serviceNaming.RenameRules = nil
+	}
+	serviceNaming.Prefix = qaengine.FetchStringAnswer(
+		ctx,
+		common.ConfigServiceNamingPrefixKey,
+		"Specify the prefix that every service name must start with:",
+		[]string{"Leave empty to not require a prefix."},
+		"",
+		nil,
+	)
+	serviceNaming.Pattern = qaengine.FetchStringAnswer(
+		ctx,
+		common.ConfigServiceNamingPatternKey,
+		"Specify a regex that every service name must match:",
+		[]string{"Leave empty to allow all names."},
+		"",
+		func(ans interface{}) error {
+			_, err := regexp.Compile(cast.ToString(ans))
+			return err
+		},
+	)
+	maxLength := qaengine.FetchStringAnswer(
+		ctx,
+		common.ConfigServiceNamingMaxLengthKey,
+		"Specify the maximum length of a service name:",
+		[]string{"Longer names will be truncated. Use 0 for no limit."},
+		"0",
+		func(ans interface{}) error {
+			if _, err := cast.ToIntE(ans); err != nil {
+				return fmt.Errorf("the max length must be a number. Error: %w", err)
+			}
+			return nil
+		},
+	)
+	serviceNaming.MaxLength = cast.ToInt(maxLength)
+	return serviceNaming
+}
+
+func parseServiceRenameRules(renameRules string) ([]plantypes.ServiceRenameRule, error) {
+	rules := []plantypes.ServiceRenameRule{}
+	for _, line := range strings.Split(renameRules, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		idx := strings.LastIndex(line, "=")
+		if idx == -1 {
+			return nil, fmt.Errorf("the rename rule '%s' is not in the format <regex>=<replacement>", line)
+		}
+		rule := plantypes.ServiceRenameRule{Pattern: line[:idx], Replacement: line[idx+1:]}
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return nil, fmt.Errorf("the pattern '%s' in the rename rule '%s' is not a valid regex. Error: %w", rule.Pattern, line, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// applyServiceNaming names the services using the chosen strategy and then applies the rename and org naming rules.
+// Services that end up with the same name after renaming get a numeric suffix, so that none of them are lost.
+// It returns an error if a service name does not match the naming pattern.
+func applyServiceNaming(projectName string, services map[string][]plantypes.PlanArtifact, serviceNaming plantypes.ServiceNaming) (map[string][]plantypes.PlanArtifact, error) {
+	strategy, ok := serviceNamingStrategies[serviceNaming.Strategy]
+	if !ok {
+		if serviceNaming.Strategy != "" {
+			logrus.Errorf("the service naming strategy '%s' was not found. Using the '%s' strategy instead.", serviceNaming.Strategy, DirectoryServiceNamingStrategy)
+		}
+		strategy = serviceNamingStrategies[DirectoryServiceNamingStrategy]
+	}
+	var pattern *regexp.Regexp
+	if serviceNaming.Pattern != "" {
+		var err error
+		if pattern, err = regexp.Compile(serviceNaming.Pattern); err != nil {
+			return nil, fmt.Errorf("the service name pattern '%s' is not a valid regex. Error: %w", serviceNaming.Pattern, err)
+		}
+	}
+	services = strategy.NameServices(projectName, services)
+	serviceNames := []string{}
+	for serviceName := range services {
+		serviceNames = append(serviceNames, serviceName)
+	}
+	// sort the names so that the same service gets the suffix every time
+	sort.Strings(serviceNames)
+	renamedServices := map[string][]plantypes.PlanArtifact{}
+	mismatchedServiceNames := []string{}
+	for _, serviceName := range serviceNames {
+		options := services[serviceName]
+		newServiceName := renameService(serviceName, serviceNaming)
+		if _, ok := renamedServices[newServiceName]; ok && newServiceName != "" {
+			uniqueServiceName := getUniqueServiceName(newServiceName, serviceNaming.MaxLength, renamedServices)
+			logrus.Warnf("More than one service was named '%s' . Naming the service '%s' as '%s' instead.", newServiceName, serviceName, uniqueServiceName)
+			newServiceName = uniqueServiceName
+		}
+		if newServiceName != serviceName {
+			logrus.Infof("Renamed the service '%s' to '%s'", serviceName, newServiceName)
+		}
+		if pattern != nil && newServiceName != "" && !pattern.MatchString(newServiceName) {
+			mismatchedServiceNames = append(mismatchedServiceNames, newServiceName)
+		}
+		renamedServices[newServiceName] = append(renamedServices[newServiceName], options...)
+	}
+	if len(mismatchedServiceNames) > 0 {
+		return renamedServices, fmt.Errorf("the service names %+v do not match the naming pattern '%s'", mismatchedServiceNames, serviceNaming.Pattern)
+	}
+	return renamedServices, nil
+}
+
+// getUniqueServiceName adds the smallest numeric suffix that makes the service name unique, while staying within the max length
+func getUniqueServiceName(serviceName string, maxLength int, services map[string][]plantypes.PlanArtifact) string {
+	for i := 2; ; i++ {
+		suffix := "-" + cast.ToString(i)
+		baseName := serviceName
+		if maxLength > 0 && len(baseName)+len(suffix) > maxLength && maxLength > len(suffix) {
+			baseName = strings.TrimRight(baseName[:maxLength-len(suffix)], "-")
+		}
+		if _, ok := services[baseName+suffix]; !ok {
+			return baseName + suffix
+		}
+	}
+}
+
+func renameService(serviceName string, serviceNaming plantypes.ServiceNaming) string {
+	if serviceName == "" {
+		return serviceName
+	}
+	originalServiceName := serviceName
+	for _, rule := range serviceNaming.RenameRules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			logrus.Errorf("the pattern '%s' in the rename rule is not a valid regex. Ignoring. Error: %q", rule.Pattern, err)
+			continue
+		}
+		serviceName = re.ReplaceAllString(serviceName, rule.Replacement)
+	}
+	if serviceNaming.Prefix != "" && !strings.HasPrefix(serviceName, serviceNaming.Prefix) {
+		serviceName = serviceNaming.Prefix + serviceName
+	}
+	if serviceName != originalServiceName {
+		serviceName = common.NormalizeForMetadataName(serviceName)
+	}
+	if serviceNaming.MaxLength > 0 && len(serviceName) > serviceNaming.MaxLength {
+		serviceName = strings.TrimRight(serviceName[:serviceNaming.MaxLength], "-")
+	}
+	return serviceName
+}
+
+type directoryNamingStrategy struct{}
+
+// NameServices names the services by bucketing the service directories and trimming common prefixes
+func (directoryNamingStrategy) NameServices(projectName string, services map[string][]plantypes.PlanArtifact) map[string][]plantypes.PlanArtifact {
+	return nameServices(projectName, services)
+}
+
+type manifestNamingStrategy struct{}
+
+// NameServices names the unnamed services using their build manifests and falls back to the directory strategy
+func (manifestNamingStrategy) NameServices(projectName string, services map[string][]plantypes.PlanArtifact) map[string][]plantypes.PlanArtifact {
+	remainingUnnamedServices := []plantypes.PlanArtifact{}
+	for _, unnamedService := range services[""] {
+		serviceDirs := unnamedService.Paths[artifacts.ServiceDirPathType]
+		if len(serviceDirs) == 0 {
+			remainingUnnamedServices = append(remainingUnnamedServices, unnamedService)
+			continue
+		}
+		serviceDir := common.CleanAndFindCommonDirectory(serviceDirs)
+		serviceName, manifestPath := getServiceNameFromManifests(serviceDir)
+		if serviceName == "" {
+			remainingUnnamedServices = append(remainingUnnamedServices, unnamedService)
+			continue
+		}
+		serviceName = common.NormalizeForMetadataName(serviceName)
+		logrus.Debugf("named the service in the directory '%s' as '%s' using the manifest '%s'", serviceDir, serviceName, manifestPath)
+		services[serviceName] = append(services[serviceName], unnamedService)
+	}
+	delete(services, "")
+	if len(remainingUnnamedServices) > 0 {
+		services[""] = remainingUnnamedServices
+	}
+	return nameServices(projectName, services)
+}
+
+// getServiceNameFromManifests returns the name given to the project by the first build manifest found in the directory
+func getServiceNameFromManifests(dir string) (name string, manifestPath string) {
+	packageJSONPath := filepath.Join(dir, "package.json")
+	packageJSON := dockerfilegenerator.PackageJSON{}
+	if err := common.ReadJSON(packageJSONPath, &packageJSON); err == nil && packageJSON.Name != "" {
+		return packageJSON.Name, packageJSONPath
+	}
+	pomPath := filepath.Join(dir, maven.PomXMLFileName)
+	pom := &maven.Pom{}
+	if err := pom.Load(pomPath); err == nil && pom.ArtifactID != "" {
+		return pom.ArtifactID, pomPath
+	}
+	goModPath := filepath.Join(dir, "go.mod")
+	if data, err := vfs.ReadFile(goModPath); err == nil {
+		if modFile, err := modfile.Parse(goModPath, data, nil); err == nil && modFile.Module != nil {
+			if prefix, _, ok := module.SplitPathVersion(modFile.Module.Mod.Path); ok {
+				return filepath.Base(prefix), goModPath
+			}
+		}
+	}
+	cargoTomlPath := filepath.Join(dir, "Cargo.toml")
+	cargoToml := dockerfilegenerator.CargoTomlConfig{}
+	if _, err := toml.DecodeFile(cargoTomlPath, &cargoToml); err == nil && cargoToml.Package.Name != "" {
+		return cargoToml.Package.Name, cargoTomlPath
+	}
+	csProjPaths, err := common.GetFilesByExtInCurrDir(dir, []string{".csproj"})
+	if err == nil && len(csProjPaths) == 1 {
+		csProjPath := csProjPaths[0]
+		if csProj, err := dotnetutils.ParseCSProj(csProjPath); err == nil {
+			for _, propertyGroup := range csProj.PropertyGroups {
+				if propertyGroup.AssemblyName != "" {
+					return propertyGroup.AssemblyName, csProjPath
+				}
+			}
+		}
+		return dotnetutils.GetChildProjectName(csProjPath), csProjPath
+	}
+	return "", ""
+}
@@ -17,7 +17,7 @@
 package transformer
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/konveyor/move2kube-wasm/common"
@@ -25,14 +25,9 @@ import (
 	containertypes "github.com/konveyor/move2kube-wasm/environment/container"
 	"github.com/konveyor/move2kube-wasm/filesystem"
 	"github.com/konveyor/move2kube-wasm/qaengine"
-	"github.com/konveyor/move2kube-wasm/transformer/containerimage"
-	"github.com/konveyor/move2kube-wasm/transformer/dockerfile"
-	"github.com/konveyor/move2kube-wasm/transformer/dockerfilegenerator"
-	"github.com/konveyor/move2kube-wasm/transformer/dockerfilegenerator/java"
-	"github.com/konveyor/move2kube-wasm/transformer/dockerfilegenerator/windows"
-	"github.com/konveyor/move2kube-wasm/transformer/kubernetes"
 	"github.com/konveyor/move2kube-wasm/types"
 	environmenttypes "github.com/konveyor/move2kube-wasm/types/environment"
+	"github.com/konveyor/move2kube-wasm/types/event"
 	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
 	"github.com/spf13/cast"
 	"k8s.io/apimachinery/pkg/labels"
@@ -41,11 +36,15 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 
 	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
 	plantypes "github.com/konveyor/move2kube-wasm/types/plan"
+	provenancetypes "github.com/konveyor/move2kube-wasm/types/provenance"
+	"github.com/konveyor/move2kube-wasm/types/report"
 	"reflect"
 
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -78,68 +77,56 @@ const (
 )
 
 var (
-	initialized                  = false
-	transformerTypes             = map[string]reflect.Type{}
-	transformers                 = []Transformer{}
-	invokedByDefaultTransformers = []Transformer{}
-	transformerMap               = map[string]Transformer{}
+	transformerTypes = map[string]reflect.Type{}
+	// registry contains the transformers used by the package level functions
+	registry = NewRegistry()
 )
 
+// Registry contains the transformers initialized for a plan or a transformation
+type Registry struct {
+	initialized                  bool
+	transformers                 []ContextTransformer
+	invokedByDefaultTransformers []ContextTransformer
+	transformerMap               map[string]ContextTransformer
+}
+
+// NewRegistry returns a registry without any transformers
+func NewRegistry() *Registry {
+	return &Registry{
+		transformers:                 []ContextTransformer{},
+		invokedByDefaultTransformers: []ContextTransformer{},
+		transformerMap:               map[string]ContextTransformer{},
+	}
+}
+
+// SetRegistry makes the registry the one used by the package level functions like Init, Transform and Reset.
+// It returns the registry that was used before.
+func SetRegistry(r *Registry) *Registry {
+	previous := registry
+	registry = r
+	return previous
+}
+
 func init() {
-	transformerObjs := []Transformer{
-		//new(external.Starlark),
-		//new(external.Executable),
-		//
-		//new(Router),
-		//
-		//new(dockerfile.DockerfileDetector),
-		//new(dockerfile.DockerfileParser),
-		new(dockerfile.DockerfileImageBuildScript),
-		new(dockerfilegenerator.NodejsDockerfileGenerator),
-		new(dockerfilegenerator.GolangDockerfileGenerator),
-		new(dockerfilegenerator.PHPDockerfileGenerator),
-		new(dockerfilegenerator.PythonDockerfileGenerator),
-		new(dockerfilegenerator.RubyDockerfileGenerator),
-		new(dockerfilegenerator.RustDockerfileGenerator),
-		new(dockerfilegenerator.DotNetCoreDockerfileGenerator),
-		new(java.JarAnalyser),
-		new(java.WarAnalyser),
-		new(java.EarAnalyser),
-		new(java.Tomcat),
-		new(java.Liberty),
-		new(java.Jboss),
-		new(java.MavenAnalyser),
-		new(java.GradleAnalyser),
-		new(java.ZuulAnalyser),
-		new(windows.WinConsoleAppDockerfileGenerator),
-		new(windows.WinSilverLightWebAppDockerfileGenerator),
-		new(windows.WinWebAppDockerfileGenerator),
-		//new(CNBContainerizer),
-		//new(compose.ComposeAnalyser),
-		//new(compose.ComposeGenerator),
-		//
-		//new(CloudFoundry),
-
-		new(containerimage.ContainerImagesPushScript),
-
-		new(kubernetes.ClusterSelectorTransformer),
-		new(kubernetes.Kubernetes),
-		//new(kubernetes.Knative),
-		//new(kubernetes.Tekton),
-		// new(kubernetes.ArgoCD),
-		//new(kubernetes.BuildConfig),
-		new(kubernetes.Parameterizer),
-		//new(kubernetes.KubernetesVersionChanger),
-		//new(kubernetes.OperatorTransformer),
-
-		new(ReadMeGenerator),
-		//new(InvokeDetect),
-	}
-	transformerTypes = common.GetTypesMap(transformerObjs)
+	transformerTypes = common.GetTypesMap(getBuiltinTransformers())
+	for _, t := range getBuiltinContextTransformers() {
+		if err := registerTransformerClass(t); err != nil {
+			logrus.Errorf("failed to register the transformer class %T . Error: %q", t, err)
+		}
+	}
 }
 
 // RegisterTransformer allows for adding transformers after initialization
 func RegisterTransformer(tf Transformer) error {
+	return registerTransformerClass(tf)
+}
+
+// RegisterContextTransformer allows for adding transformers that support cancellation after initialization
+func RegisterContextTransformer(tf ContextTransformer) error {
+	return registerTransformerClass(tf)
+}
+
+func registerTransformerClass(tf interface{}) error {
 	tval := reflect.ValueOf(tf)
 	t := reflect.TypeOf(tval.Interface()).Elem()
 	tn := t.Name()
@@ -151,8 +138,8 @@ func RegisterTransformer(tf Transformer) error {
 	return nil
 }
 
-// Init initializes the transformers
-func Init(assetsPath, sourcePath string, selector labels.Selector, outputPath, projName string) (map[string]string, error) {
+// GetTransformerYamlPaths returns the paths of the transformer yamls in the directory, keyed by the transformer name
+func GetTransformerYamlPaths(assetsPath string) (map[string]string, error) {
 	yamlPaths, err := common.GetFilesByExt(assetsPath, []string{".yml", ".yaml"})
 	if err != nil {
 		return nil, fmt.Errorf("failed to look for yaml files in the directory '%s' . Error: %w", assetsPath, err)
@@ -169,7 +156,16 @@ func Init(assetsPath, sourcePath string, selector labels.Selector, outputPath, p
 		}
 		transformerYamlPaths[tc.Name] = yamlPath
 	}
-	deselectedTransformers, err := InitTransformers(transformerYamlPaths, selector, sourcePath, outputPath, projName, false, false)
+	return transformerYamlPaths, nil
+}
+
+// Init initializes the transformers
+func Init(ctx context.Context, assetsPath, sourcePath string, selector labels.Selector, outputPath, projName string) (map[string]string, error) {
+	transformerYamlPaths, err := GetTransformerYamlPaths(assetsPath)
+	if err != nil {
+		return nil, err
+	}
+	deselectedTransformers, err := InitTransformers(ctx, transformerYamlPaths, selector, sourcePath, outputPath, projName, false, false)
 	if err != nil {
 		return deselectedTransformers, fmt.Errorf(
 			"failed to initialize the transformers using the source path '%s' and the output path '%s' . Error: %w",
@@ -180,14 +176,15 @@ func Init(assetsPath, sourcePath string, selector labels.Selector, outputPath, p
 }
 
 // InitTransformers initializes a subset of transformers
-func InitTransformers(transformerYamlPaths map[string]string, selector labels.Selector, sourcePath, outputPath, projName string, logError, preExistingPlan bool) (map[string]string, error) {
+func InitTransformers(ctx context.Context, transformerYamlPaths map[string]string, selector labels.Selector, sourcePath, outputPath, projName string, logError, preExistingPlan bool) (map[string]string, error) {
 	logrus.Trace("InitTransformers start")
 	defer logrus.Trace("InitTransformers end")
-	if initialized {
+	if registry.initialized {
 		logrus.Debug("already initialized")
 		return nil, nil
 	}
 	transformerFilterString := qaengine.FetchStringAnswer(
+		ctx,
 		common.TransformerSelectorKey,
 		"Specify a Kubernetes style selector to select only the transformers that you want to run.",
 		[]string{"Leave empty to select everything. This is the default."},
@@ -219,6 +216,7 @@ func InitTransformers(transformerYamlPaths map[string]string, selector labels.Se
 	}
 	sort.Strings(transformerNames)
 	selectedTransformerNames := qaengine.FetchMultiSelectAnswer(
+		ctx,
 		common.ConfigTransformerTypesKey,
 		"Select all transformer types that you are interested in:",
 		[]string{"Services that don't support any of the transformer types you are interested in will be ignored."},
@@ -226,11 +224,15 @@ func InitTransformers(transformerYamlPaths map[string]string, selector labels.Se
 		transformerNames,
 		nil,
 	)
+	activeConfigs := map[string]transformertypes.Transformer{}
 	for _, transformerName := range transformerNames {
 		if !common.IsPresent(selectedTransformerNames, transformerName) {
 			deselectedTransformers[transformerName] = transformerYamlPaths[transformerName]
+			continue
 		}
+		activeConfigs[transformerName] = transformerConfigs[transformerName]
 	}
+	logTransformerValidationIssues(validateTransformerConfigs(loadTransformerConfigs(transformerYamlPaths, selector), activeConfigs))
 	for _, selectedTransformerName := range selectedTransformerNames {
 		transformerConfig, ok := transformerConfigs[selectedTransformerName]
 		if !ok {
@@ -242,7 +244,12 @@ func InitTransformers(transformerYamlPaths map[string]string, selector labels.Se
 			logrus.Errorf("failed to find the transformer class '%s' . Valid transformer classes are: %+v", transformerConfig.Spec.Class, transformerTypes)
 			continue
 		}
-		transformer := reflect.New(transformerClass).Interface().(Transformer)
+		transformer, err := getContextTransformer(reflect.New(transformerClass).Interface())
+		if err != nil {
+			logrus.Errorf("failed to create the transformer '%s' . Error: %q", transformerConfig.Name, err)
+			report.AddError(report.InitFailure, err, report.RunError{Transformer: transformerConfig.Name})
+			continue
+		}
 		transformerContextPath := filepath.Dir(transformerConfig.Spec.TransformerYamlPath)
 		envInfo := environment.EnvInfo{
 			Name:            transformerConfig.Name,
@@ -274,50 +281,75 @@ func InitTransformers(transformerYamlPaths map[string]string, selector labels.Se
 		if err != nil {
 			return deselectedTransformers, fmt.Errorf("failed to create the environment %+v . Error: %w", envInfo, err)
 		}
-		if err := transformer.Init(transformerConfig, env); err != nil {
+		initCtx, cancel := withTransformerTimeout(ctx, transformerConfig)
+		err = getTransformerTimeoutError(ctx, transformer.Init(initCtx, transformerConfig, env), transformerConfig, "init")
+		cancel()
+		if ctx.Err() != nil {
+			return deselectedTransformers, fmt.Errorf("the initialization of the transformers was cancelled. Error: %w", ctx.Err())
+		}
+		if err != nil {
 			if errors.Is(err, containertypes.ErrNoContainerRuntime) {
 				logrus.Debugf("failed to initialize the transformer '%s' . Error: %q", transformerConfig.Name, err)
 			} else {
 				logrus.Errorf("failed to initialize the transformer '%s' . Error: %q", transformerConfig.Name, err)
+				report.AddError(report.InitFailure, err, report.RunError{Transformer: transformerConfig.Name})
 			}
 			continue
 		}
-		transformers = append(transformers, transformer)
-		transformerMap[selectedTransformerName] = transformer
+		registry.transformers = append(registry.transformers, transformer)
+		registry.transformerMap[selectedTransformerName] = transformer
 		if transformerConfig.Spec.InvokedByDefault.Enabled {
-			invokedByDefaultTransformers = append(invokedByDefaultTransformers, transformer)
+			registry.invokedByDefaultTransformers = append(registry.invokedByDefaultTransformers, transformer)
 		}
 	}
-	initialized = true
+	// the selected transformer names are not in a fixed order, sort the transformers so that
+	// the path mappings and artifacts are in the same order for every run and every parallelism
+	sort.SliceStable(registry.transformers, func(i, j int) bool {
+		iConfig, _ := registry.transformers[i].GetConfig()
+		jConfig, _ := registry.transformers[j].GetConfig()
+		return iConfig.Name < jConfig.Name
+	})
+	registry.initialized = true
 	return deselectedTransformers, nil
 }
 
 // Destroy destroys the transformers
 func Destroy() {
-	for _, t := range transformers {
-		_, env := t.GetConfig()
+	for _, t := range registry.transformers {
+		tConfig, env := t.GetConfig()
+		if isTransformerAbandoned(t) {
+			// the transformer might still be using the environment
+			logrus.Debugf("not destroying the environment of the transformer named '%s' since it was abandoned after a timeout", tConfig.Name)
+			continue
+		}
 		if err := env.Destroy(); err != nil {
 			logrus.Errorf("Unable to destroy environment : %s", err)
 		}
 	}
 }
 
+// Reset destroys the initialized transformers and forgets them, so that the transformers can be initialized again for another project
+func Reset() {
+	Destroy()
+	*registry = *NewRegistry()
+}
+
 // GetInitializedTransformers returns the list of initialized transformers
-func GetInitializedTransformers() []Transformer {
-	return transformers
+func GetInitializedTransformers() []ContextTransformer {
+	return registry.transformers
 }
 
 // GetTransformerByName returns the transformer chosen by name
-func GetTransformerByName(name string) (t Transformer, err error) {
-	if t, ok := transformerMap[name]; ok {
+func GetTransformerByName(name string) (t ContextTransformer, err error) {
+	if t, ok := registry.transformerMap[name]; ok {
 		return t, nil
 	}
 	return nil, fmt.Errorf("no transformer found")
 }
 
 // GetInitializedTransformersF returns the list of initialized transformers after filtering
-func GetInitializedTransformersF(filters labels.Selector) []Transformer {
-	filteredTransformers := []Transformer{}
+func GetInitializedTransformersF(filters labels.Selector) []ContextTransformer {
+	filteredTransformers := []ContextTransformer{}
 	for _, t := range GetInitializedTransformers() {
 		tc, _ := t.GetConfig()
 		if tc.ObjectMeta.Labels == nil {
@@ -332,10 +364,10 @@ func GetInitializedTransformersF(filters labels.Selector) []Transformer {
 }
 
 // GetServices returns the list of services detected in a directory
-func GetServices(projectName string, dir string, transformerSelector *metav1.LabelSelector) (map[string][]plantypes.PlanArtifact, error) {
+func GetServices(ctx context.Context, projectName string, dir string, transformerSelector *metav1.LabelSelector, serviceNaming plantypes.ServiceNaming) (map[string][]plantypes.PlanArtifact, error) {
 	logrus.Trace("GetServices start")
 	defer logrus.Trace("GetServices end")
-	selectedTransformers := transformers
+	selectedTransformers := registry.transformers
 	if transformerSelector != nil {
 		filters, err := metav1.LabelSelectorAsSelector(transformerSelector)
 		if err != nil {
@@ -345,9 +377,17 @@ func GetServices(projectName string, dir string, transformerSelector *metav1.Lab
 	}
 	planServices := map[string][]plantypes.PlanArtifact{}
 	logrus.Infof("Planning started on the base directory: '%s'", dir)
+	event.Publish(event.Event{Type: event.PlanStarted, Directory: dir})
 	logrus.Debugf("selectedTransformers: %+v", selectedTransformers)
 	for _, transformer := range selectedTransformers {
+		if ctx.Err() != nil {
+			return planServices, fmt.Errorf("planning was cancelled. Error: %w", ctx.Err())
+		}
 		config, env := transformer.GetConfig()
+		if isTransformerAbandoned(transformer) {
+			logrus.Debugf("skipping the transformer named '%s' since it was abandoned after a timeout", config.Name)
+			continue
+		}
 		if err := env.Reset(); err != nil {
 			logrus.Errorf("failed to reset the environment for the transformer named '%s' . Error: %q", config.Name, err)
 			continue
@@ -356,13 +396,15 @@ func GetServices(projectName string, dir string, transformerSelector *metav1.Lab
 			continue
 		}
 		logrus.Infof("[%s] Planning", config.Name)
-		newServices, err := transformer.DirectoryDetect(env.Encode(dir).(string))
+		newServices, err := runDirectoryDetect(ctx, transformer, config, env.Encode(dir).(string))
 		if err != nil {
 			logrus.Errorf("failed to look for services in the directory '%s' using the transformer named '%s' . Error: %q", dir, config.Name, err)
+			report.AddError(report.DetectFailure, err, report.RunError{Transformer: config.Name, Path: dir})
 			continue
 		}
 		newPlanServices := getPlanArtifactsFromArtifacts(*env.Decode(&newServices).(*map[string][]transformertypes.Artifact), config)
 		planServices = plantypes.MergeServices(planServices, newPlanServices)
+		publishDetectedServices(newPlanServices, config.Name, dir)
 		if len(newPlanServices) > 0 {
 			logrus.Infof(getNamedAndUnNamedServicesLogMessage(newPlanServices))
 		}
@@ -372,7 +414,10 @@ func GetServices(projectName string, dir string, transformerSelector *metav1.Lab
 	logrus.Infof("[Base Directory] %s", getNamedAndUnNamedServicesLogMessage(planServices))
 	logrus.Infof("Planning finished on the base directory: '%s'", dir)
 	logrus.Info("Planning started on its sub directories")
-	nservices, err := walkForServices(dir, planServices)
+	nservices, err := walkForServices(ctx, dir, planServices)
+	if ctx.Err() != nil {
+		return planServices, fmt.Errorf("planning was cancelled. Error: %w", ctx.Err())
+	}
 	if err != nil {
 		logrus.Errorf("Transformation planning - Directory Walk failed. Error: %q", err)
 	} else {
@@ -380,17 +425,27 @@ func GetServices(projectName string, dir string, transformerSelector *metav1.Lab
 		logrus.Infoln("Planning finished on its sub directories")
 	}
 	logrus.Infof("[Directory Walk] %s", getNamedAndUnNamedServicesLogMessage(planServices))
-	planServices = nameServices(projectName, planServices)
+	planServices, err = applyServiceNaming(projectName, planServices, serviceNaming)
+	if err != nil {
+		return planServices, fmt.Errorf("failed to name the services. Error: %w", err)
+	}
+	for _, options := range planServices {
+		plantypes.SortPlanArtifacts(options)
+	}
 	logrus.Infof("[Named Services] Identified %d named services", len(planServices))
+	event.Publish(event.Event{Type: event.PlanFinished, Directory: dir, NumServices: len(planServices)})
 	return planServices, nil
 }
 
-func walkForServices(inputPath string, bservices map[string][]plantypes.PlanArtifact) (map[string][]plantypes.PlanArtifact, error) {
+func walkForServices(ctx context.Context, inputPath string, bservices map[string][]plantypes.PlanArtifact) (map[string][]plantypes.PlanArtifact, error) {
 	services := bservices
 	ignoreDirectories, ignoreContents := getIgnorePaths(inputPath)
 	knownServiceDirPaths := []string{}
 
-	err := filepath.WalkDir(inputPath, func(path string, info os.DirEntry, err error) error {
+	err := vfs.WalkDir(inputPath, func(path string, info os.DirEntry, err error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		if err != nil {
 			logrus.Warnf("Skipping path %q due to error. Error: %q", path, err)
 			return nil
@@ -416,8 +471,12 @@ func walkForServices(inputPath string, bservices map[string][]plantypes.PlanArti
 		logrus.Debugf("Planning in directory %s", path)
 		numfound := 0
 		skipThisDir := false
-		for _, transformer := range transformers {
+		for _, transformer := range registry.transformers {
 			config, env := transformer.GetConfig()
+			if isTransformerAbandoned(transformer) {
+				logrus.Debugf("[%s] skipping the directory %s since the transformer was abandoned after a timeout", config.Name, path)
+				continue
+			}
 			logrus.Debugf("[%s] Planning in directory %s", config.Name, path)
 			if err := env.Reset(); err != nil {
 				logrus.Errorf("failed to reset the environment for the transformer %s . Error: %q", config.Name, err)
@@ -426,9 +485,10 @@ func walkForServices(inputPath string, bservices map[string][]plantypes.PlanArti
 			if config.Spec.DirectoryDetect.Levels == 1 || config.Spec.DirectoryDetect.Levels == 0 {
 				continue
 			}
-			newServicesToArtifacts, err := transformer.DirectoryDetect(env.Encode(path).(string))
+			newServicesToArtifacts, err := runDirectoryDetect(ctx, transformer, config, env.Encode(path).(string))
 			if err != nil {
 				logrus.Warnf("[%s] directory detect failed. Error: %q", config.Name, err)
+				report.AddError(report.DetectFailure, err, report.RunError{Transformer: config.Name, Path: path})
 				continue
 			}
 			for _, newServiceArtifacts := range newServicesToArtifacts {
@@ -444,6 +504,7 @@ func walkForServices(inputPath string, bservices map[string][]plantypes.PlanArti
 			}
 			newPlanServices := getPlanArtifactsFromArtifacts(*env.Decode(&newServicesToArtifacts).(*map[string][]transformertypes.Artifact), config)
 			services = plantypes.MergeServices(services, newPlanServices)
+			publishDetectedServices(newPlanServices, config.Name, path)
 			logrus.Debugf("[%s] Done", config.Name)
 			numfound += len(newPlanServices)
 			if len(newPlanServices) > 0 {
@@ -458,6 +519,7 @@ func walkForServices(inputPath string, bservices map[string][]plantypes.PlanArti
 			}
 		}
 		logrus.Debugf("planning finished for the directory %s and %d services were detected", path, numfound)
+		event.Publish(event.Event{Type: event.DirectoryScanned, Directory: path, NumServices: numfound})
 		if skipThisDir || common.IsPresent(ignoreContents, path) {
 			return filepath.SkipDir
 		}
@@ -469,6 +531,14 @@ func walkForServices(inputPath string, bservices map[string][]plantypes.PlanArti
 	return services, nil
 }
 
+// runDirectoryDetect runs the directory detect of the transformer, limited by the timeout in its config
+func runDirectoryDetect(ctx context.Context, transformer ContextTransformer, tc transformertypes.Transformer, dir string) (map[string][]transformertypes.Artifact, error) {
+	detectCtx, cancel := withTransformerTimeout(ctx, tc)
+	defer cancel()
+	services, err := transformer.DirectoryDetect(detectCtx, dir)
+	return services, getTransformerTimeoutError(ctx, err, tc, "directory detect")
+}
+
 func summarizeArtifacts(artifacts []transformertypes.Artifact) []string {
 	arts := []string{}
 	for _, a := range artifacts {
@@ -497,51 +567,96 @@ func preprocessArtifact(planArtifact plantypes.PlanArtifact) plantypes.PlanArtif
 	}
 	if planArtifact.Name == "" {
 		planArtifact.Name = planArtifact.ServiceName
+		if planArtifact.ContainerName != "" {
+			// services merged into the same pod must remain distinct artifacts
+			planArtifact.Name = planArtifact.ServiceName + "-" + planArtifact.ContainerName
+		}
 	}
 	if planArtifact.Configs == nil {
 		planArtifact.Configs = map[transformertypes.ConfigType]interface{}{}
 	}
 	planArtifact.Configs[artifacts.ServiceConfigType] = artifacts.ServiceConfig{
-		ServiceName: planArtifact.ServiceName,
+		ServiceName:   planArtifact.ServiceName,
+		ContainerName: planArtifact.ContainerName,
 	}
 	return planArtifact
 }
 
-// Transform transforms as per the plan
-func Transform(planArtifacts []plantypes.PlanArtifact, sourceDir, outputPath string, maxIterations int) error {
+// Transform transforms as per the plan and returns all the path mappings that were processed along with the graph of the transformer runs.
+// The state is written to a checkpoint after every iteration, and the transformation can be resumed from a checkpoint.
+func Transform(ctx context.Context, planArtifacts []plantypes.PlanArtifact, sourceDir, outputPath string, maxIterations int, checkpoint CheckpointOptions) (_ []transformertypes.PathMapping, _ *graphtypes.Graph, err error) {
 	logrus.Trace("transformer.Transform start")
 	defer logrus.Trace("transformer.Transform end")
+	transformStartTime := time.Now()
+	defer func() {
+		e := event.Event{Type: event.TransformFinished, Duration: time.Since(transformStartTime).String()}
+		if err != nil {
+			e.Error = err.Error()
+		}
+		event.Publish(e)
+	}()
 	var allArtifacts []transformertypes.Artifact
 	newArtifactsToProcess := []transformertypes.Artifact{}
 	pathMappings := []transformertypes.PathMapping{}
 	defaultNewArtifactsToProcess := []transformertypes.Artifact{}
 	iteration := 1
-	// transform default transformers
 	graph := graphtypes.NewGraph()
-	startVertexId := graph.AddVertex("start", iteration, nil)
-	for _, invokedByDefaultTransformer := range invokedByDefaultTransformers {
-		tDefaultConfig, defaultEnv := invokedByDefaultTransformer.GetConfig()
-		newPathMappings, defaultArtifacts, err := runSingleTransform(nil, nil, invokedByDefaultTransformer, tDefaultConfig, defaultEnv, graph, iteration)
-		if err != nil {
-			logrus.Errorf("failed to transform using the transformer %s. Error: %q", tDefaultConfig.Name, err)
+	manifest := provenancetypes.NewOutputManifest(sourceDir, outputPath)
+	if checkpoint.Resume != nil {
+		if err := checkCheckpointTransformers(checkpoint.Resume); err != nil {
+			return nil, nil, err
+		}
+		iteration = checkpoint.Resume.Iteration
+		allArtifacts = checkpoint.Resume.AllArtifacts
+		newArtifactsToProcess = checkpoint.Resume.NewArtifactsToProcess
+		pathMappings = restorePathMappings(checkpoint.Resume.PathMappings)
+		if checkpoint.Resume.Graph != nil {
+			graph = checkpoint.Resume.Graph
+			graph.RestoreIds()
+		}
+		if checkpoint.Resume.Manifest != nil {
+			manifest.Records = checkpoint.Resume.Manifest.Records
+		}
+		logrus.Infof("Resuming the transformation after iteration %d - %d artifacts to process", iteration, len(newArtifactsToProcess))
+		event.Publish(event.Event{Type: event.TransformStarted, Iteration: iteration, NumArtifacts: len(newArtifactsToProcess)})
+	} else {
+		// transform default transformers
+		startVertexId := graph.AddVertex("start", iteration, nil)
+		startVertex := graph.Vertices[startVertexId]
+		event.Publish(event.Event{Type: event.TransformStarted, Iteration: iteration, NumArtifacts: len(planArtifacts), Vertex: &startVertex})
+		for _, invokedByDefaultTransformer := range registry.invokedByDefaultTransformers {
+			tDefaultConfig, defaultEnv := invokedByDefaultTransformer.GetConfig()
+			newPathMappings, defaultArtifacts, err := runSingleTransform(ctx, nil, nil, invokedByDefaultTransformer, tDefaultConfig, defaultEnv, graph, manifest, iteration)
+			if err != nil {
+				logrus.Errorf("failed to transform using the transformer %s. Error: %q", tDefaultConfig.Name, err)
+				reportTransformError(tDefaultConfig, nil, err)
+			}
+			defaultNewArtifactsToProcess = append(defaultNewArtifactsToProcess, defaultArtifacts...)
+			pathMappings = append(pathMappings, newPathMappings...)
+		}
+		logrus.Infof("Iteration %d", iteration)
+		for _, planArtifact := range planArtifacts {
+			planArtifact = preprocessArtifact(planArtifact)
+			newArtifactsToProcess = append(newArtifactsToProcess, planArtifact.Artifact)
 		}
-		defaultNewArtifactsToProcess = append(defaultNewArtifactsToProcess, defaultArtifacts...)
-		pathMappings = append(pathMappings, newPathMappings...)
-	}
-	logrus.Infof("Iteration %d", iteration)
-	for _, planArtifact := range planArtifacts {
-		planArtifact = preprocessArtifact(planArtifact)
-		newArtifactsToProcess = append(newArtifactsToProcess, planArtifact.Artifact)
-	}
 
-	// logging
-	for _, artifact := range newArtifactsToProcess {
-		artifact.Configs[graphtypes.GraphSourceVertexKey] = startVertexId
+		// logging
+		for _, artifact := range newArtifactsToProcess {
+			artifact.Configs[graphtypes.GraphSourceVertexKey] = startVertexId
+		}
+		newArtifactsToProcess = append(newArtifactsToProcess, defaultNewArtifactsToProcess...)
+		allArtifacts = newArtifactsToProcess
+		// logging
+		writeCheckpoint(checkpoint, iteration, allArtifacts, newArtifactsToProcess, pathMappings, graph, manifest)
+		event.Publish(event.Event{Type: event.IterationDone, Iteration: iteration, NumArtifacts: len(newArtifactsToProcess)})
 	}
-	newArtifactsToProcess = append(newArtifactsToProcess, defaultNewArtifactsToProcess...)
-	allArtifacts = newArtifactsToProcess
-	// logging
 
+	conflictPolicy := getGlobalConflictPolicy(ctx)
+	parallelism := getParallelism(ctx)
+	cycleDetector := newArtifactCycleDetector(graph)
+	cycleDetector.add(allArtifacts, iteration)
+	repeatedIterations := 0
+	conflicts := []pathMappingConflict{}
 	for {
 		iteration++
 		if maxIterations >= 0 && iteration > maxIterations {
@@ -549,52 +664,83 @@ func Transform(planArtifacts []plantypes.PlanArtifact, sourceDir, outputPath str
 			break
 		}
 		logrus.Infof("Iteration %d - %d artifacts to process", iteration, len(newArtifactsToProcess))
-		newPathMappings, newArtifacts, _ := transform(newArtifactsToProcess, allArtifacts, consume, nil, graph, iteration)
+		var newPathMappings []transformertypes.PathMapping
+		var newArtifacts []transformertypes.Artifact
+		if parallelism > 1 {
+			newPathMappings, newArtifacts = transformConcurrently(ctx, newArtifactsToProcess, allArtifacts, parallelism, graph, manifest, iteration)
+		} else {
+			newPathMappings, newArtifacts, _ = transform(ctx, newArtifactsToProcess, allArtifacts, consume, nil, graph, manifest, iteration)
+		}
+		if ctx.Err() != nil {
+			// the checkpoint of the previous iteration is kept, so the transformation can be resumed
+			return pathMappings, graph, fmt.Errorf("the transformation was cancelled during iteration %d . Error: %w", iteration, ctx.Err())
+		}
 		pathMappings = append(pathMappings, newPathMappings...)
 		//if err := os.RemoveAll(outputPath); err != nil {
 		//	return fmt.Errorf("failed to remove the output directory '%s' . Error: %w", outputPath, err)
 		//}
-		if err := processPathMappings(pathMappings, sourceDir, outputPath, false); err != nil {
-			return fmt.Errorf("failed to process the path mappings: %+v . Error: %w", pathMappings, err)
+		resolvedPathMappings, newConflicts, err := resolvePathMappingConflicts(pathMappings, conflictPolicy)
+		conflicts = newConflicts
+		if err != nil {
+			logPathMappingConflicts(conflicts)
+			report.AddError(report.PathMappingFailure, err, report.RunError{})
+			return pathMappings, graph, fmt.Errorf("failed to resolve the conflicts between the path mappings. Error: %w", err)
+		}
+		if err := processPathMappings(resolvedPathMappings, sourceDir, outputPath, false); err != nil {
+			report.AddError(report.PathMappingFailure, err, report.RunError{Path: outputPath})
+			return pathMappings, graph, fmt.Errorf("failed to process the path mappings: %+v . Error: %w", pathMappings, err)
 		}
+		event.Publish(event.Event{Type: event.IterationDone, Iteration: iteration, NumArtifacts: len(newArtifacts)})
 		if len(newArtifacts) == 0 {
 			break
 		}
+		if repeated := cycleDetector.check(newArtifacts); repeated != nil {
+			// a single iteration of equivalent artifacts can be consumed by other transformers, a second one is a cycle
+			if repeatedIterations++; repeatedIterations >= maxRepeatedIterations {
+				err := getArtifactCycleError(repeated, iteration)
+				logrus.Errorf("%s", err)
+				return pathMappings, graph, err
+			}
+		} else {
+			repeatedIterations = 0
+		}
+		cycleDetector.add(newArtifacts, iteration)
 		logrus.Infof(
 			"Created %d pathMappings and %d artifacts. Total Path Mappings : %d. Total Artifacts : %d.",
 			len(newPathMappings), len(newArtifacts), len(pathMappings), len(allArtifacts),
 		)
 		allArtifacts = append(allArtifacts, newArtifacts...)
 		newArtifactsToProcess = newArtifacts
+		writeCheckpoint(checkpoint, iteration, allArtifacts, newArtifactsToProcess, pathMappings, graph, manifest)
 	}
 
-	// logging
-	{
-		graphFilePath := graphtypes.GraphFileName
-		graphFile, err := os.Create(graphFilePath)
-		if err != nil {
-			logrus.Errorf("failed to create a %s file to write to the graph. Error: %q", graphFilePath, err)
-		} else {
-			enc := json.NewEncoder(graphFile)
-			enc.SetIndent("", "    ")
-			if err := enc.Encode(graph); err != nil {
-				logrus.Errorf("failed to encode the graph as json. Error: %q", err)
-			}
+	logPathMappingConflicts(conflicts)
+	if checkpoint.Path != "" {
+		if err := vfs.Remove(checkpoint.Path); err != nil && !os.IsNotExist(err) {
+			logrus.Errorf("failed to remove the checkpoint file at path %s . Error: %q", checkpoint.Path, err)
 		}
 	}
-	// logging
-
-	return nil
+	if err := writeOutputManifest(ctx, manifest, outputPath); err != nil {
+		logrus.Errorf("failed to write the output manifest. Error: %q", err)
+	}
+	return pathMappings, graph, nil
 }
 
-func transform(newArtifactsToProcess, allArtifacts []transformertypes.Artifact, pt processType, depSel labels.Selector, graph *graphtypes.Graph, iteration int) (pathMappings []transformertypes.PathMapping, newArtifactsCreated, updatedArtifacts []transformertypes.Artifact) {
+func transform(ctx context.Context, newArtifactsToProcess, allArtifacts []transformertypes.Artifact, pt processType, depSel labels.Selector, graph *graphtypes.Graph, manifest *provenancetypes.OutputManifest, iteration int) (pathMappings []transformertypes.PathMapping, newArtifactsCreated, updatedArtifacts []transformertypes.Artifact) {
 	logrus.Trace("transform start")
 	defer logrus.Trace("transform end")
 	if pt == dependency && (depSel == nil || depSel.String() == "") {
 		return nil, nil, newArtifactsToProcess
 	}
-	for _, transformer := range transformers {
+	for _, transformer := range registry.transformers {
+		if ctx.Err() != nil {
+			break
+		}
 		tConfig, env := transformer.GetConfig()
+		if isTransformerAbandoned(transformer) {
+			logrus.Debugf("skipping the transformer named '%s' since it was abandoned after a timeout", tConfig.Name)
+			continue
+		}
 		if pt == dependency && !depSel.Matches(labels.Set(tConfig.Labels)) {
 			logrus.Debugf("currently in dependency mode and the dependency selector does not match the transformer named '%s'", tConfig.Name)
 			continue
@@ -607,7 +753,7 @@ func transform(newArtifactsToProcess, allArtifacts []transformertypes.Artifact,
 
 		logrus.Debugf("Transformer '%s' will be processing %d artifacts in %d mode", tConfig.Name, len(artifactsToProcess), pt)
 		// Dependency processing
-		dependencyCreatedNewPathMappings, dependencyCreatedNewArtifacts, dependencyUpdatedArtifacts := transform(artifactsToProcess, allArtifacts, dependency, tConfig.Spec.DependencySelector, graph, iteration)
+		dependencyCreatedNewPathMappings, dependencyCreatedNewArtifacts, dependencyUpdatedArtifacts := transform(ctx, artifactsToProcess, allArtifacts, dependency, tConfig.Spec.DependencySelector, graph, manifest, iteration)
 		pathMappings = append(pathMappings, dependencyCreatedNewPathMappings...)
 		// Dependency processing
 
@@ -617,10 +763,11 @@ func transform(newArtifactsToProcess, allArtifacts []transformertypes.Artifact,
 		}
 
 		logrus.Infof("Transformer '%s' processing %d artifacts", tConfig.Name, len(artifactsToConsume))
-		producedNewPathMappings, producedNewArtifacts, err := runSingleTransform(artifactsToConsume, allArtifacts, transformer, tConfig, env, graph, iteration)
+		producedNewPathMappings, producedNewArtifacts, err := runSingleTransform(ctx, artifactsToConsume, allArtifacts, transformer, tConfig, env, graph, manifest, iteration)
 		if err != nil {
 			logrus.Errorf("failed to run a single transformation using the transformer %+v on the artifacts: %+v", tConfig, artifactsToConsume)
 			logrus.Error(err.Error())
+			reportTransformError(tConfig, artifactsToConsume, err)
 			continue
 		}
 		pathMappings = append(pathMappings, producedNewPathMappings...)
@@ -639,7 +786,7 @@ func transform(newArtifactsToProcess, allArtifacts []transformertypes.Artifact,
 			}
 		}
 
-		passedThroughPathMappings, passedThroughNewArtifactsCreated, passedThroughUpdatedArtifacts := transform(artifactsToPassThrough, allArtifacts, passthrough, nil, graph, iteration)
+		passedThroughPathMappings, passedThroughNewArtifactsCreated, passedThroughUpdatedArtifacts := transform(ctx, artifactsToPassThrough, allArtifacts, passthrough, nil, graph, manifest, iteration)
 
 		pathMappings = append(pathMappings, passedThroughPathMappings...)
 		newArtifactsCreated = append(newArtifactsCreated, passedThroughNewArtifactsCreated...)
@@ -662,32 +809,86 @@ func transform(newArtifactsToProcess, allArtifacts []transformertypes.Artifact,
 	return pathMappings, newArtifactsCreated, nil
 }
 
-func runSingleTransform(artifactsToProcess, allArtifacts []transformertypes.Artifact, transformer Transformer, tconfig transformertypes.Transformer, env *environment.Environment, graph *graphtypes.Graph, iteration int) (newPathMappings []transformertypes.PathMapping, newArtifacts []transformertypes.Artifact, err error) {
+func runSingleTransform(ctx context.Context, artifactsToProcess, allArtifacts []transformertypes.Artifact, transformer ContextTransformer, tconfig transformertypes.Transformer, env *environment.Environment, graph *graphtypes.Graph, manifest *provenancetypes.OutputManifest, iteration int) (newPathMappings []transformertypes.PathMapping, newArtifacts []transformertypes.Artifact, err error) {
 	logrus.Trace("runSingleTransform start")
 	defer logrus.Trace("runSingleTransform end")
-	if err := env.Reset(); err != nil {
-		return nil, nil, fmt.Errorf("failed to reset the environment: %+v Error: %q", env, err)
+	output, err := executeSingleTransform(ctx, artifactsToProcess, allArtifacts, transformer, tconfig, env, iteration)
+	if err != nil {
+		return nil, nil, err
 	}
+	return recordSingleTransform(artifactsToProcess, output, tconfig, env, graph, manifest, iteration)
+}
+
+// transformOutput is the output of a transformer before it is added to the graph and post processed
+type transformOutput struct {
+	pathMappings []transformertypes.PathMapping
+	artifacts    []transformertypes.Artifact
+	err          error
+	startTime    time.Time
+	endTime      time.Time
+	// qaProblemIds are the QA problems asked by the transformer while it ran
+	qaProblemIds []string
+}
+
+// executeSingleTransform runs the transformer without touching the graph or the manifest, so that it can run concurrently with other transformers
+func executeSingleTransform(ctx context.Context, artifactsToProcess, allArtifacts []transformertypes.Artifact, transformer ContextTransformer, tconfig transformertypes.Transformer, env *environment.Environment, iteration int) (transformOutput, error) {
+	if err := env.Reset(); err != nil {
+		return transformOutput{}, fmt.Errorf("failed to reset the environment: %+v Error: %q", env, err)
+	}
+	transformCtx, cancel := withTransformerTimeout(ctx, tconfig)
+	defer cancel()
+	// the recorder only collects the problems asked by this transformer, even when other transformers run concurrently
+	problemRecorder := qaengine.NewProblemRecorder()
+	transformCtx = qaengine.WithProblemRecorder(transformCtx, problemRecorder)
+	startTime := time.Now()
+	event.Publish(event.Event{Type: event.TransformerStarted, Time: startTime, Transformer: tconfig.Name, Iteration: iteration, NumArtifacts: len(artifactsToProcess)})
+	var newPathMappings []transformertypes.PathMapping
+	var newArtifacts []transformertypes.Artifact
+	var err error
 	newPathMappings, newArtifacts, err = transformer.Transform(
+		transformCtx,
 		*env.Encode(&artifactsToProcess).(*[]transformertypes.Artifact),
 		*env.Encode(&allArtifacts).(*[]transformertypes.Artifact),
 	)
+	endTime := time.Now()
+	err = getTransformerTimeoutError(ctx, err, tconfig, "transform")
+	return transformOutput{
+		pathMappings: newPathMappings,
+		artifacts:    newArtifacts,
+		err:          err,
+		startTime:    startTime,
+		endTime:      endTime,
+		qaProblemIds: problemRecorder.GetProblemIDs(),
+	}, nil
+}
+
+// recordSingleTransform adds the output of the transformer to the graph and the manifest and post processes it
+func recordSingleTransform(artifactsToProcess []transformertypes.Artifact, output transformOutput, tconfig transformertypes.Transformer, env *environment.Environment, graph *graphtypes.Graph, manifest *provenancetypes.OutputManifest, iteration int) (newPathMappings []transformertypes.PathMapping, newArtifacts []transformertypes.Artifact, err error) {
+	newPathMappings, newArtifacts, err = output.pathMappings, output.artifacts, output.err
 	// logging
 	{
 		vertexName := fmt.Sprintf("iteration: %d\nclass: %s\nname: %s", iteration, tconfig.Spec.Class, tconfig.Name)
-		targetVertexId := graph.AddVertex(
-			vertexName,
-			iteration,
-			map[string]interface{}{
-				"consumedArtifacts": summarizeArtifacts(artifactsToProcess),
-				"producedArtifacts": summarizeArtifacts(newArtifacts),
-				"pathMappings":      summarizePathMappings(newPathMappings),
-			},
-		)
+		vertexData := map[string]interface{}{
+			"consumedArtifacts":                 summarizeArtifacts(artifactsToProcess),
+			"producedArtifacts":                 summarizeArtifacts(newArtifacts),
+			"pathMappings":                      summarizePathMappings(newPathMappings),
+			graphtypes.GraphTransformerNameKey:  tconfig.Name,
+			graphtypes.GraphTransformerClassKey: tconfig.Spec.Class,
+			graphtypes.GraphStartTimeKey:        output.startTime.Format(time.RFC3339Nano),
+			graphtypes.GraphEndTimeKey:          output.endTime.Format(time.RFC3339Nano),
+			graphtypes.GraphDurationKey:         output.endTime.Sub(output.startTime).String(),
+			graphtypes.GraphEnvironmentTypeKey:  env.GetType(),
+			graphtypes.GraphQAProblemIdsKey:     output.qaProblemIds,
+		}
+		if err != nil {
+			vertexData[graphtypes.GraphErrorKey] = err.Error()
+		}
+		targetVertexId := graph.AddVertex(vertexName, iteration, vertexData)
+		edgeIds := []int{}
 		// transformers that are invoked by default has source vertex as start
 		if tconfig.Spec.InvokedByDefault.Enabled {
 			edgeName := fmt.Sprintf("%d -> %d (invoked by default)", 0, targetVertexId)
-			graph.AddEdge(graph.SourceVertexId, targetVertexId, edgeName, nil)
+			edgeIds = append(edgeIds, graph.AddEdge(graph.SourceVertexId, targetVertexId, edgeName, nil))
 		}
 		for _, artifact := range artifactsToProcess {
 			sourceVertexId, ok := artifact.Configs[graphtypes.GraphSourceVertexKey].(int)
@@ -700,8 +901,9 @@ func runSingleTransform(artifactsToProcess, allArtifacts []transformertypes.Arti
 				sourceVertexId = processVertexId
 				edgeName = fmt.Sprintf("%d -> %d", processVertexId, targetVertexId)
 			}
-			graph.AddEdge(sourceVertexId, targetVertexId, edgeName, map[string]interface{}{"newArtifact": summarizeArtifacts([]transformertypes.Artifact{artifact})})
+			edgeIds = append(edgeIds, graph.AddEdge(sourceVertexId, targetVertexId, edgeName, map[string]interface{}{"newArtifact": summarizeArtifacts([]transformertypes.Artifact{artifact})}))
 		}
+		publishTransformerFinished(graph, targetVertexId, edgeIds, tconfig.Name, len(newArtifacts), err)
 		for i, newArtifact := range newArtifacts {
 			if newArtifact.Configs == nil {
 				newArtifact.Configs = map[transformertypes.ConfigType]interface{}{}
@@ -736,9 +938,13 @@ func runSingleTransform(artifactsToProcess, allArtifacts []transformertypes.Arti
 	newArtifacts = filteredArtifacts
 	newPathMappings = env.ProcessPathMappings(newPathMappings)
 	newPathMappings = *env.DownloadAndDecode(&newPathMappings, true).(*[]transformertypes.PathMapping)
+	for i := range newPathMappings {
+		newPathMappings[i].TransformerName = tconfig.Name
+	}
 	if err := processPathMappings(newPathMappings, env.Source, env.Output, false); err != nil {
-		return newPathMappings, newArtifacts, fmt.Errorf("failed to process the path mappings: %+v . Error: %q", newPathMappings, err)
+		return newPathMappings, newArtifacts, &pathMappingError{err: fmt.Errorf("failed to process the path mappings: %+v . Error: %w", newPathMappings, err)}
 	}
+	manifest.AddPathMappings(newPathMappings, getPathMappingFiles(newPathMappings), tconfig.Name, tconfig.Spec.Class, iteration, artifactsToProcess)
 	newArtifacts = *env.DownloadAndDecode(&newArtifacts, false).(*[]transformertypes.Artifact)
 	newArtifacts = postProcessArtifacts(newArtifacts, tconfig)
 	return newPathMappings, newArtifacts, nil
@@ -19,13 +19,14 @@ package transformer
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/konveyor/move2kube-wasm/common"
 	"github.com/konveyor/move2kube-wasm/common/deepcopy"
+	"github.com/konveyor/move2kube-wasm/common/vfs"
 	plantypes "github.com/konveyor/move2kube-wasm/types/plan"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
 	"github.com/konveyor/move2kube-wasm/types/transformer/artifacts"
@@ -59,6 +60,12 @@ func getTransformerConfig(transformerYamlPath string) (transformertypes.Transfor
 		logrus.Errorf("failed to parse the dependency selector for the transformer '%s' , Ignoring selector: %+v . Error: %q", tc.Name, tc.Spec.Dependency, err)
 		tc.Spec.DependencySelector = nil
 	}
+	if tc.Spec.Timeout != "" {
+		if tc.Spec.TimeoutDuration, err = time.ParseDuration(tc.Spec.Timeout); err != nil || tc.Spec.TimeoutDuration < 0 {
+			logrus.Errorf("failed to parse the timeout for the transformer '%s' , Ignoring timeout: %s . Error: %q", tc.Name, tc.Spec.Timeout, err)
+			tc.Spec.TimeoutDuration = 0
+		}
+	}
 	// TODO: Add check for consistency between consumes and produces
 	return tc, nil
 }
@@ -85,7 +92,7 @@ func getIgnorePaths(inputPath string) (ignoreDirectories []string, ignoreContent
 		return ignoreDirectories, ignoreContents
 	}
 	for _, filePath := range filePaths {
-		file, err := os.Open(filePath)
+		file, err := vfs.Open(filePath)
 		if err != nil {
 			logrus.Warnf("failed to open the .m2kignore file at path '%s' . Error: %q", filePath, err)
 			continue
@@ -212,8 +219,11 @@ func getPlanArtifactsFromArtifacts(services map[string][]transformertypes.Artifa
 	planServices := map[string][]plantypes.PlanArtifact{}
 	for sn, s := range services {
 		for _, st := range s {
+			detectionConfig := getDetectionConfig(&st)
 			planServices[sn] = append(planServices[sn], plantypes.PlanArtifact{
 				TransformerName: t.Name,
+				Confidence:      detectionConfig.Confidence,
+				Reason:          detectionConfig.Reason,
 				Artifact:        st,
 			})
 		}
@@ -221,6 +231,25 @@ func getPlanArtifactsFromArtifacts(services map[string][]transformertypes.Artifa
 	return planServices
 }
 
+// getDetectionConfig removes the detection config from the artifact and returns it.
+// If the transformer did not set a detection config, the default confidence is used.
+func getDetectionConfig(artifact *transformertypes.Artifact) artifacts.DetectionConfig {
+	detectionConfig := artifacts.DetectionConfig{Confidence: artifacts.DefaultDetectionConfidence}
+	if _, ok := artifact.Configs[artifacts.DetectionConfigType]; !ok {
+		return detectionConfig
+	}
+	if err := artifact.GetConfig(artifacts.DetectionConfigType, &detectionConfig); err != nil {
+		logrus.Errorf("failed to load the detection config of the artifact '%s' . Error: %q", artifact.Name, err)
+	}
+	delete(artifact.Configs, artifacts.DetectionConfigType)
+	if detectionConfig.Confidence < 0 {
+		detectionConfig.Confidence = 0
+	} else if detectionConfig.Confidence > 1 {
+		detectionConfig.Confidence = 1
+	}
+	return detectionConfig
+}
+
 func getNamedAndUnNamedServicesLogMessage(services map[string][]plantypes.PlanArtifact) string {
 	nnservices := len(services)
 	nuntransformers := len(services[""])
@@ -260,6 +289,10 @@ func getFilteredTransformers(transformerYamlPaths map[string]string, selector la
 			filteredTransformerConfigs[tc.Name] = tc
 			continue
 		}
+		if isExcludedTransformerClass(tc.Spec.Class) {
+			logrus.Debugf("Ignoring the transformer '%s' since the transformer class '%s' was left out of this build", transformerName, tc.Spec.Class)
+			continue
+		}
 		logrus.Errorf("Ignoring the transformer '%s' since the transformer class '%s' was not found", transformerName, tc.Spec.Class)
 	}
 	transformerConfigs := map[string]transformertypes.Transformer{}
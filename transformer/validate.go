@@ -0,0 +1,271 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package transformer
+
+import (
+	"fmt"
+	"sort"
+
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// TransformerValidationIssueType is the type of problem found in the transformer configs
+type TransformerValidationIssueType string
+
+const (
+	// UnreachableTransformerIssue is a transformer that never gets any artifacts to consume
+	UnreachableTransformerIssue TransformerValidationIssueType = "UnreachableTransformer"
+	// DanglingProducedTypeIssue is an artifact type that is produced but not consumed by any transformer
+	DanglingProducedTypeIssue TransformerValidationIssueType = "DanglingProducedType"
+	// UnknownChangeTypeToIssue is a changeTypeTo target that is not consumed or produced by any transformer
+	UnknownChangeTypeToIssue TransformerValidationIssueType = "UnknownChangeTypeTo"
+	// UnmatchedDependencySelectorIssue is a dependency selector that does not match any transformer
+	UnmatchedDependencySelectorIssue TransformerValidationIssueType = "UnmatchedDependencySelector"
+	// UnmatchedOverrideSelectorIssue is an override selector that does not match any transformer
+	UnmatchedOverrideSelectorIssue TransformerValidationIssueType = "UnmatchedOverrideSelector"
+)
+
+// TransformerValidationIssue is a problem found by statically checking the consumes/produces graph of the transformers
+type TransformerValidationIssue struct {
+	Type        TransformerValidationIssueType `json:"type"`
+	Transformer string                         `json:"transformer"`
+	Message     string                         `json:"message"`
+}
+
+// ValidateTransformers loads the transformer configs that match the selector and checks the flow of artifact types between them
+func ValidateTransformers(transformerYamlPaths map[string]string, selector labels.Selector) []TransformerValidationIssue {
+	allConfigs := loadTransformerConfigs(transformerYamlPaths, selector)
+	activeConfigs := getFilteredTransformers(transformerYamlPaths, selector, false)
+	return validateTransformerConfigs(allConfigs, activeConfigs)
+}
+
+// ValidateCustomTransformers is ValidateTransformers limited to the issues of the transformers in the customizations.
+// The built-in transformers still take part in the flow of artifact types, but their issues are not reported.
+func ValidateCustomTransformers(assetsPath string, transformerYamlPaths map[string]string, selector labels.Selector) []TransformerValidationIssue {
+	issues := []TransformerValidationIssue{}
+	for _, issue := range ValidateTransformers(transformerYamlPaths, selector) {
+		if getTransformerSource(assetsPath, transformerYamlPaths[issue.Transformer]) == CustomTransformerSource {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// loadTransformerConfigs loads the transformer configs that match the selector, including the overridden ones
+func loadTransformerConfigs(transformerYamlPaths map[string]string, selector labels.Selector) map[string]transformertypes.Transformer {
+	configs := map[string]transformertypes.Transformer{}
+	for _, transformerYamlPath := range transformerYamlPaths {
+		tc, err := getTransformerConfig(transformerYamlPath)
+		if err != nil {
+			logrus.Debugf("failed to load the YAML file at path '%s' as a Transformer config. Error: %q", transformerYamlPath, err)
+			continue
+		}
+		if selector != nil && !selector.Matches(labels.Set(tc.Labels)) {
+			continue
+		}
+		configs[tc.Name] = tc
+	}
+	return configs
+}
+
+// validateTransformerConfigs checks the artifact type flow graph of the active transformers.
+// All the configs, including overridden transformers, are used to check the selectors.
+// The transformers whose class is not compiled in cannot run, but they still take part in the flow of artifact types
+// and no issues are reported for them, so that leaving classes out of the build does not cause issues in the other transformers.
+func validateTransformerConfigs(allConfigs, activeConfigs map[string]transformertypes.Transformer) []TransformerValidationIssue {
+	issues := []TransformerValidationIssue{}
+	flowConfigs := map[string]transformertypes.Transformer{}
+	for name, tc := range activeConfigs {
+		flowConfigs[name] = tc
+	}
+	for name, tc := range allConfigs {
+		if _, ok := flowConfigs[name]; !ok && !IsTransformerClassAvailable(tc.Spec.Class) {
+			flowConfigs[name] = tc
+		}
+	}
+	names := []string{}
+	for name := range flowConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	knownTypes := map[transformertypes.ArtifactType]bool{}
+	for _, tc := range allConfigs {
+		for artifactType := range tc.Spec.ConsumedArtifacts {
+			knownTypes[artifactType] = true
+		}
+		for artifactType := range tc.Spec.ProducedArtifacts {
+			knownTypes[artifactType] = true
+		}
+	}
+	consumedTypes := map[transformertypes.ArtifactType]bool{}
+	for _, tc := range flowConfigs {
+		for artifactType, consumed := range tc.Spec.ConsumedArtifacts {
+			if !consumed.Disabled {
+				consumedTypes[artifactType] = true
+			}
+		}
+	}
+
+	// find the transformers that can receive artifacts, starting from the ones that detect services or are invoked by default
+	reachable := map[string]bool{}
+	reachableTypes := map[transformertypes.ArtifactType]bool{}
+	for changed := true; changed; {
+		changed = false
+		for _, name := range names {
+			if reachable[name] {
+				continue
+			}
+			tc := flowConfigs[name]
+			isRoot := tc.Spec.DirectoryDetect.Levels != 0 || tc.Spec.InvokedByDefault.Enabled
+			if !isRoot && !consumesAny(tc, reachableTypes) {
+				continue
+			}
+			reachable[name] = true
+			changed = true
+			if isRoot {
+				// the artifacts created by directory detect are consumed by the same transformer
+				for artifactType := range tc.Spec.ConsumedArtifacts {
+					reachableTypes[artifactType] = true
+				}
+			}
+			for artifactType := range getProducedTypes(tc) {
+				reachableTypes[artifactType] = true
+			}
+		}
+	}
+
+	for _, name := range names {
+		tc, ok := activeConfigs[name]
+		if !ok || !IsTransformerClassAvailable(tc.Spec.Class) {
+			continue
+		}
+		if !reachable[name] {
+			issues = append(issues, TransformerValidationIssue{
+				Type:        UnreachableTransformerIssue,
+				Transformer: name,
+				Message:     fmt.Sprintf("none of the consumed artifact types %+v are produced by any of the selected transformers", getSortedTypes(tc.Spec.ConsumedArtifacts)),
+			})
+		}
+		producedTypes := getProducedTypes(tc)
+		for _, artifactType := range getSortedTypes(producedTypes) {
+			if artifactType == ALLOW_ALL_ARTIFACT_TYPES || consumedTypes[artifactType] || consumedTypes[ALLOW_ALL_ARTIFACT_TYPES] {
+				continue
+			}
+			issues = append(issues, TransformerValidationIssue{
+				Type:        DanglingProducedTypeIssue,
+				Transformer: name,
+				Message:     fmt.Sprintf("the produced artifact type '%s' is not consumed by any of the selected transformers", artifactType),
+			})
+		}
+		for _, artifactType := range getSortedTypes(tc.Spec.ProducedArtifacts) {
+			changeTypeTo := tc.Spec.ProducedArtifacts[artifactType].ChangeTypeTo
+			if changeTypeTo == "" || knownTypes[changeTypeTo] {
+				continue
+			}
+			issues = append(issues, TransformerValidationIssue{
+				Type:        UnknownChangeTypeToIssue,
+				Transformer: name,
+				Message:     fmt.Sprintf("the produced artifact type '%s' is changed to the unknown type '%s'", artifactType, changeTypeTo),
+			})
+		}
+		if tc.Spec.DependencySelector != nil && !matchesAnyTransformer(tc.Spec.DependencySelector, name, allConfigs) {
+			issues = append(issues, TransformerValidationIssue{
+				Type:        UnmatchedDependencySelectorIssue,
+				Transformer: name,
+				Message:     fmt.Sprintf("the dependency selector '%s' does not match any transformer", tc.Spec.DependencySelector.String()),
+			})
+		}
+	}
+	allNames := []string{}
+	for name := range allConfigs {
+		allNames = append(allNames, name)
+	}
+	sort.Strings(allNames)
+	for _, name := range allNames {
+		tc := allConfigs[name]
+		if !IsTransformerClassAvailable(tc.Spec.Class) {
+			continue
+		}
+		if tc.Spec.OverrideSelector != nil && !matchesAnyTransformer(tc.Spec.OverrideSelector, name, allConfigs) {
+			issues = append(issues, TransformerValidationIssue{
+				Type:        UnmatchedOverrideSelectorIssue,
+				Transformer: name,
+				Message:     fmt.Sprintf("the override selector '%s' does not match any transformer", tc.Spec.OverrideSelector.String()),
+			})
+		}
+	}
+	return issues
+}
+
+// getProducedTypes returns the enabled produced artifact types after applying changeTypeTo
+func getProducedTypes(tc transformertypes.Transformer) map[transformertypes.ArtifactType]bool {
+	producedTypes := map[transformertypes.ArtifactType]bool{}
+	for artifactType, produced := range tc.Spec.ProducedArtifacts {
+		if produced.Disabled {
+			continue
+		}
+		if produced.ChangeTypeTo != "" {
+			artifactType = produced.ChangeTypeTo
+		}
+		producedTypes[artifactType] = true
+	}
+	return producedTypes
+}
+
+func consumesAny(tc transformertypes.Transformer, artifactTypes map[transformertypes.ArtifactType]bool) bool {
+	if artifactTypes[ALLOW_ALL_ARTIFACT_TYPES] && len(tc.Spec.ConsumedArtifacts) != 0 {
+		return true
+	}
+	for artifactType, consumed := range tc.Spec.ConsumedArtifacts {
+		if consumed.Disabled {
+			continue
+		}
+		if artifactTypes[artifactType] || (artifactType == ALLOW_ALL_ARTIFACT_TYPES && len(artifactTypes) != 0) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyTransformer(selector labels.Selector, self string, configs map[string]transformertypes.Transformer) bool {
+	for name, tc := range configs {
+		if name != self && selector.Matches(labels.Set(tc.Labels)) {
+			return true
+		}
+	}
+	return false
+}
+
+func getSortedTypes[V any](artifactTypes map[transformertypes.ArtifactType]V) []transformertypes.ArtifactType {
+	sortedTypes := []transformertypes.ArtifactType{}
+	for artifactType := range artifactTypes {
+		sortedTypes = append(sortedTypes, artifactType)
+	}
+	sort.Slice(sortedTypes, func(i, j int) bool { return sortedTypes[i] < sortedTypes[j] })
+	return sortedTypes
+}
+
+// logTransformerValidationIssues prints the issues at the debug level, since they are not errors in the run.
+// The transformers check command shows them to the transformer authors.
+func logTransformerValidationIssues(issues []TransformerValidationIssue) {
+	for _, issue := range issues {
+		logrus.Debugf("transformer '%s' : %s : %s", issue.Transformer, issue.Type, issue.Message)
+	}
+}
@@ -1,3 +1,6 @@
+//go:build !m2k_no_cf
+// +build !m2k_no_cf
+
 /*
  *  Copyright IBM Corporation 2021
  *
@@ -0,0 +1,113 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package event
+
+import (
+	"sync"
+	"time"
+
+	graphtypes "github.com/konveyor/move2kube-wasm/types/graph"
+)
+
+// Type is the type of an event
+type Type string
+
+const (
+	// PlanStarted is published when the planning starts
+	PlanStarted Type = "PlanStarted"
+	// DirectoryScanned is published after the transformers looked for services in a directory
+	DirectoryScanned Type = "DirectoryScanned"
+	// ServiceDetected is published when a transformer finds a service
+	ServiceDetected Type = "ServiceDetected"
+	// PlanFinished is published when the planning finishes
+	PlanFinished Type = "PlanFinished"
+	// TransformStarted is published when the transformation starts, it contains the start vertex of the graph
+	TransformStarted Type = "TransformStarted"
+	// TransformerStarted is published when a transformer starts transforming artifacts
+	TransformerStarted Type = "TransformerStarted"
+	// TransformerFinished is published when a transformer finishes, it contains the vertex and edges added to the graph
+	TransformerFinished Type = "TransformerFinished"
+	// IterationDone is published at the end of every iteration of the transformation
+	IterationDone Type = "IterationDone"
+	// TransformFinished is published when the transformation finishes
+	TransformFinished Type = "TransformFinished"
+	// QuestionAsked is published when a QA problem is asked
+	QuestionAsked Type = "QuestionAsked"
+)
+
+// Event is a step of the planning or the transformation
+type Event struct {
+	// Sequence increases by one for every event published
+	Sequence     int                `json:"seq"`
+	Type         Type               `json:"type"`
+	Time         time.Time          `json:"time"`
+	Transformer  string             `json:"transformer,omitempty"`
+	Iteration    int                `json:"iteration,omitempty"`
+	Directory    string             `json:"directory,omitempty"`
+	Service      string             `json:"service,omitempty"`
+	QuestionID   string             `json:"questionId,omitempty"`
+	NumServices  int                `json:"numServices,omitempty"`
+	NumArtifacts int                `json:"numArtifacts,omitempty"`
+	Duration     string             `json:"duration,omitempty"`
+	Error        string             `json:"error,omitempty"`
+	Vertex       *graphtypes.Vertex `json:"vertex,omitempty"`
+	Edges        []graphtypes.Edge  `json:"edges,omitempty"`
+}
+
+// Listener is called with every event that is published
+type Listener func(Event)
+
+var (
+	listeners      = map[int]Listener{}
+	nextListenerId = 0
+	sequence       = 0
+	eventsMutex    sync.Mutex
+)
+
+// AddListener adds a listener for the events and returns a function that removes it
+func AddListener(listener Listener) func() {
+	eventsMutex.Lock()
+	defer eventsMutex.Unlock()
+	id := nextListenerId
+	nextListenerId++
+	listeners[id] = listener
+	return func() {
+		eventsMutex.Lock()
+		defer eventsMutex.Unlock()
+		delete(listeners, id)
+	}
+}
+
+// Publish sends the event to all the listeners, in the order the events are published.
+// The listeners are called synchronously, so they should not block.
+func Publish(e Event) {
+	eventsMutex.Lock()
+	defer eventsMutex.Unlock()
+	if len(listeners) == 0 {
+		return
+	}
+	sequence++
+	e.Sequence = sequence
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	for id := 0; id < nextListenerId; id++ {
+		if listener, ok := listeners[id]; ok {
+			listener(e)
+		}
+	}
+}
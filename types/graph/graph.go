@@ -52,19 +52,21 @@ type GraphT struct {
 
 // Node is the web UI version of Vertex.
 type Node struct {
-	Id       string   `json:"id"`
-	Type     string   `json:"type,omitempty"`
-	Position Position `json:"position"`
-	Data     Data     `json:"data"`
+	Id       string            `json:"id"`
+	Type     string            `json:"type,omitempty"`
+	Position Position          `json:"position"`
+	Data     Data              `json:"data"`
+	Style    map[string]string `json:"style,omitempty"`
 }
 
 // EdgeT is the web UI version of Edge.
 type EdgeT struct {
-	Id        string    `json:"id"`
-	Source    string    `json:"source"`
-	Target    string    `json:"target"`
-	Label     string    `json:"label"`
-	MarkerEnd MarkerEnd `json:"markerEnd"`
+	Id        string            `json:"id"`
+	Source    string            `json:"source"`
+	Target    string            `json:"target"`
+	Label     string            `json:"label"`
+	MarkerEnd MarkerEnd         `json:"markerEnd"`
+	Style     map[string]string `json:"style,omitempty"`
 }
 
 // MarkerEnd is used to indicate the style of edge endings.
@@ -84,6 +86,78 @@ type Data struct {
 	PathMappings string `json:"pathMappings,omitempty"`
 }
 
+// ExportedGraph is the layout independent version of Graph used to export it.
+type ExportedGraph struct {
+	Version  string           `json:"version"`
+	Vertices []ExportedVertex `json:"vertices"`
+	Edges    []ExportedEdge   `json:"edges"`
+}
+
+// ExportedVertex is a single transformer run in an ExportedGraph.
+type ExportedVertex struct {
+	Id          int    `json:"id"`
+	Iteration   int    `json:"iteration"`
+	Name        string `json:"name"`
+	Transformer string `json:"transformer,omitempty"`
+	Duration    string `json:"duration,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// ExportedEdge contains all the artifacts passed between two transformer runs in an ExportedGraph.
+type ExportedEdge struct {
+	From             int      `json:"from"`
+	To               int      `json:"to"`
+	Artifacts        []string `json:"artifacts"`
+	InvokedByDefault bool     `json:"invokedByDefault,omitempty"`
+}
+
+// DiffStatus is the status of a vertex or an edge when comparing two graphs.
+type DiffStatus string
+
+const (
+	// DiffAdded is used for the vertices and edges that are only in the new graph.
+	DiffAdded DiffStatus = "Added"
+	// DiffRemoved is used for the vertices and edges that are only in the old graph.
+	DiffRemoved DiffStatus = "Removed"
+	// DiffChanged is used for the vertices and edges that are in both graphs but are different.
+	DiffChanged DiffStatus = "Changed"
+	// DiffUnchanged is used for the vertices and edges that are the same in both graphs.
+	DiffUnchanged DiffStatus = "Unchanged"
+)
+
+// GraphDiff contains the differences between two graphs.
+type GraphDiff struct {
+	Vertices []VertexDiff `json:"vertices"`
+	Edges    []EdgeDiff   `json:"edges"`
+}
+
+// VertexDiff is a transformer run matched by the transformer name, class and iteration.
+type VertexDiff struct {
+	Key         string        `json:"key"`
+	Status      DiffStatus    `json:"status"`
+	Transformer string        `json:"transformer,omitempty"`
+	Class       string        `json:"class,omitempty"`
+	Iteration   int           `json:"iteration"`
+	Changes     []FieldChange `json:"changes,omitempty"`
+}
+
+// FieldChange is a field of a vertex that is different in the two graphs.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// EdgeDiff is an artifact matched by the artifact name and type and the vertex consuming it.
+type EdgeDiff struct {
+	Artifact string     `json:"artifact"`
+	Status   DiffStatus `json:"status"`
+	From     string     `json:"from"`
+	To       string     `json:"to"`
+	// PreviousFrom is the vertex that created the artifact in the old graph, when it is different
+	PreviousFrom string `json:"previousFrom,omitempty"`
+}
+
 const (
 	// GraphFileVersion is the version of the graph file that is generated.
 	GraphFileVersion = "1.0.0"
@@ -93,6 +167,22 @@ const (
 	GraphSourceVertexKey = "m2k-logging-source-vertex"
 	// GraphProcessVertexKey is used to track an artifact across iterations. It contains the transformer that last processed this artifact.
 	GraphProcessVertexKey = "m2k-logging-process-vertex"
+	// GraphTransformerNameKey is the key in the vertex data that contains the name of the transformer.
+	GraphTransformerNameKey = "transformer"
+	// GraphTransformerClassKey is the key in the vertex data that contains the class of the transformer.
+	GraphTransformerClassKey = "transformerClass"
+	// GraphStartTimeKey is the key in the vertex data that contains the time the transformer started running.
+	GraphStartTimeKey = "startTime"
+	// GraphEndTimeKey is the key in the vertex data that contains the time the transformer finished running.
+	GraphEndTimeKey = "endTime"
+	// GraphDurationKey is the key in the vertex data that contains how long the transformer ran.
+	GraphDurationKey = "duration"
+	// GraphErrorKey is the key in the vertex data that contains the error returned by the transformer.
+	GraphErrorKey = "error"
+	// GraphEnvironmentTypeKey is the key in the vertex data that contains the type of environment the transformer ran in.
+	GraphEnvironmentTypeKey = "environmentType"
+	// GraphQAProblemIdsKey is the key in the vertex data that contains the ids of the QA problems asked while the transformer ran.
+	GraphQAProblemIdsKey = "qaProblemIds"
 	// GraphEdgeArrowClosed is used to indicate a closed arrow edge ending.
 	GraphEdgeArrowClosed = "arrowclosed"
 	// GraphNodeTypeInput is used to indicate that the Node is an input/starting node.
@@ -127,3 +217,18 @@ func (g *Graph) AddEdge(from, to int, name string, data map[string]interface{})
 	g.Edges[g.edgeId] = Edge{Id: g.edgeId, From: from, To: to, Name: name, Data: data}
 	return g.edgeId
 }
+
+// RestoreIds recalculates the ids for new vertices and edges after the graph has been unmarshalled.
+func (g *Graph) RestoreIds() {
+	g.vertexId, g.edgeId = -1, -1
+	for id := range g.Vertices {
+		if id > g.vertexId {
+			g.vertexId = id
+		}
+	}
+	for id := range g.Edges {
+		if id > g.edgeId {
+			g.edgeId = id
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package plan
 
 import (
+	"sort"
+
 	"github.com/konveyor/move2kube-wasm/common"
 	"github.com/konveyor/move2kube-wasm/types"
 	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
@@ -24,7 +26,8 @@ type Spec struct {
 	SourceDir         string `yaml:"sourceDir"`
 	CustomizationsDir string `yaml:"customizationsDir,omitempty"`
 
-	Services map[string][]PlanArtifact `yaml:"services"` //[servicename]
+	Services      map[string][]PlanArtifact `yaml:"services"` //[servicename]
+	ServiceNaming ServiceNaming             `yaml:"serviceNaming,omitempty"`
 
 	TransformerSelector          metav1.LabelSelector `yaml:"transformerSelector,omitempty"`
 	Transformers                 map[string]string    `yaml:"transformers,omitempty" m2kpath:"normal"` //[name]filepath
@@ -32,10 +35,28 @@ type Spec struct {
 	DisabledTransformers         map[string]string    `yaml:"disabledTransformers,omitempty" m2kpath:"normal"` //[name]filepath
 }
 
+// ServiceNaming stores the strategy and the rules that were used to name the services
+type ServiceNaming struct {
+	Strategy    string              `yaml:"strategy,omitempty"`
+	RenameRules []ServiceRenameRule `yaml:"renameRules,omitempty"`
+	Prefix      string              `yaml:"prefix,omitempty"`    // Prefix that every service name must start with
+	Pattern     string              `yaml:"pattern,omitempty"`   // Regex that every service name must match
+	MaxLength   int                 `yaml:"maxLength,omitempty"` // Service names longer than this are truncated, 0 means no limit
+}
+
+// ServiceRenameRule renames the services whose names match the regex pattern
+type ServiceRenameRule struct {
+	Pattern     string `yaml:"pattern"`
+	Replacement string `yaml:"replacement"`
+}
+
 // PlanArtifact stores the artifact with the transformerName
 type PlanArtifact struct {
-	ServiceName               string `yaml:"-"`
-	TransformerName           string `yaml:"transformerName"`
+	ServiceName               string  `yaml:"-"`
+	TransformerName           string  `yaml:"transformerName"`
+	Confidence                float64 `yaml:"confidence,omitempty"`    // Between 0 and 1, used to rank the transformation options of a service
+	Reason                    string  `yaml:"reason,omitempty"`        // Why the transformer detected this service
+	ContainerName             string  `yaml:"containerName,omitempty"` // Set when several services were merged into one, each becomes a container in the same pod
 	transformertypes.Artifact `yaml:",inline"`
 }
 
@@ -70,6 +91,14 @@ func MergeServices(s1 map[string][]PlanArtifact, s2 map[string][]PlanArtifact) m
 	return s1
 }
 
+// SortPlanArtifacts sorts the transformation options of a service by confidence, highest first.
+// Options with the same confidence retain their relative order.
+func SortPlanArtifacts(options []PlanArtifact) {
+	sort.SliceStable(options, func(i, j int) bool {
+		return options[i].Confidence > options[j].Confidence
+	})
+}
+
 // MergeServicesT merges two service maps
 func MergeServicesT(s1 map[string][]transformertypes.Artifact, s2 map[string][]transformertypes.Artifact) map[string][]transformertypes.Artifact {
 	if s1 == nil {
@@ -46,6 +46,16 @@ func ReadPlan(path string, sourceDir string) (Plan, error) {
 
 // WritePlan encodes the plan to yaml converting absolute paths to relative.
 func WritePlan(path string, plan Plan) error {
+	return writePlan(path, plan, plan.Spec.SourceDir)
+}
+
+// WritePlanForArchive encodes the plan to yaml like WritePlan, but uses the archive
+// the source directory was extracted from as the source directory of the plan.
+func WritePlanForArchive(path string, plan Plan, archivePath string) error {
+	return writePlan(path, plan, archivePath)
+}
+
+func writePlan(path string, plan Plan, sourcePath string) error {
 	inputFSPath := plan.Spec.SourceDir
 	//TODO: WASI
 	// remoteSrcPath := vcs.GetClonedPath(plan.Spec.SourceDir, common.RemoteSourcesFolder, false)
@@ -63,8 +73,8 @@ func WritePlan(path string, plan Plan) error {
 		return err
 	}
 	// if remoteSrcPath == "" && plan.Spec.SourceDir != "" {
-	if plan.Spec.SourceDir != "" {
-		if newPlan.Spec.SourceDir, err = filepath.Rel(wd, plan.Spec.SourceDir); err != nil {
+	if sourcePath != "" {
+		if newPlan.Spec.SourceDir, err = filepath.Rel(wd, sourcePath); err != nil {
 			return err
 		}
 	}
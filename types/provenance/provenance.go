@@ -0,0 +1,137 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package provenance
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/konveyor/move2kube-wasm/common"
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+)
+
+const (
+	// OutputManifestFileVersion is the version of the output manifest file that is generated.
+	OutputManifestFileVersion = "1.0.0"
+	// OutputManifestFileName is the default file name used to save the output manifest.
+	OutputManifestFileName = "m2k-output-manifest.json"
+)
+
+// OutputManifest maps every path mapping that was processed to the transformer and inputs that produced it.
+type OutputManifest struct {
+	Version    string   `json:"version"`
+	SourcePath string   `json:"sourcePath"`
+	OutputPath string   `json:"outputPath"`
+	Records    []Record `json:"records"`
+}
+
+// Record is the provenance of a single path mapping.
+type Record struct {
+	// DestPath is relative to the output directory when it lies inside it
+	DestPath          string                           `json:"destinationPath"`
+	Type              transformertypes.PathMappingType `json:"type"`
+	Transformer       string                           `json:"transformer"`
+	TransformerClass  string                           `json:"transformerClass,omitempty"`
+	Iteration         int                              `json:"iteration"`
+	ConsumedArtifacts []string                         `json:"consumedArtifacts"`
+	// SourcePaths are relative to the source directory when they lie inside it
+	SourcePaths []string `json:"sourcePaths"`
+	// Files are the files written by the path mapping, relative to DestPath. They are not known for Source path mappings.
+	Files []string `json:"files,omitempty"`
+}
+
+// NewOutputManifest creates a new output manifest.
+func NewOutputManifest(sourcePath, outputPath string) *OutputManifest {
+	return &OutputManifest{
+		Version:    OutputManifestFileVersion,
+		SourcePath: sourcePath,
+		OutputPath: outputPath,
+		Records:    []Record{},
+	}
+}
+
+// AddPathMappings records the provenance of path mappings created by a transformer from the consumed artifacts.
+// The files written by each path mapping are given in the same order as the path mappings.
+func (m *OutputManifest) AddPathMappings(pathMappings []transformertypes.PathMapping, files [][]string, transformerName, transformerClass string, iteration int, consumedArtifacts []transformertypes.Artifact) {
+	artifactNames := []string{}
+	artifactPaths := []string{}
+	for _, artifact := range consumedArtifacts {
+		artifactNames = append(artifactNames, artifact.Name)
+		for _, paths := range artifact.Paths {
+			for _, path := range paths {
+				artifactPaths = append(artifactPaths, m.relToSource(path))
+			}
+		}
+	}
+	for i, pathMapping := range pathMappings {
+		pathMappingType := pathMapping.Type
+		if pathMappingType == "" {
+			pathMappingType = transformertypes.DefaultPathMappingType
+		}
+		sourcePaths := artifactPaths
+		if pathMappingType == transformertypes.SourcePathMappingType || pathMappingType == transformertypes.ModifiedSourcePathMappingType {
+			sourcePaths = append([]string{m.relToSource(pathMapping.SrcPath)}, artifactPaths...)
+		}
+		record := Record{
+			DestPath:          m.relToOutput(pathMapping.DestPath),
+			Type:              pathMappingType,
+			Transformer:       transformerName,
+			TransformerClass:  transformerClass,
+			Iteration:         iteration,
+			ConsumedArtifacts: uniqueSorted(artifactNames),
+			SourcePaths:       uniqueSorted(sourcePaths),
+		}
+		if i < len(files) {
+			record.Files = files[i]
+		}
+		m.Records = append(m.Records, record)
+	}
+}
+
+func (m *OutputManifest) relToSource(path string) string {
+	if m.SourcePath == "" || !filepath.IsAbs(path) || !common.IsParent(path, m.SourcePath) {
+		return path
+	}
+	if relPath, err := filepath.Rel(m.SourcePath, path); err == nil {
+		return relPath
+	}
+	return path
+}
+
+func (m *OutputManifest) relToOutput(path string) string {
+	if m.OutputPath == "" || !filepath.IsAbs(path) || !common.IsParent(path, m.OutputPath) {
+		return path
+	}
+	if relPath, err := filepath.Rel(m.OutputPath, path); err == nil {
+		return relPath
+	}
+	return path
+}
+
+func uniqueSorted(xs []string) []string {
+	seen := map[string]bool{}
+	ys := []string{}
+	for _, x := range xs {
+		if seen[x] {
+			continue
+		}
+		seen[x] = true
+		ys = append(ys, x)
+	}
+	sort.Strings(ys)
+	return ys
+}
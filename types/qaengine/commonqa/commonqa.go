@@ -17,6 +17,7 @@
 package commonqa
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -31,7 +32,7 @@ import (
 )
 
 // ImageRegistry returns Image Registry URL
-func ImageRegistry() string {
+func ImageRegistry(ctx context.Context) string {
 	// DefaultRegistryURL points to the default registry url that will be used
 	defaultRegistryURL := "quay.io"
 	registryList := []string{qatypes.OtherAnswer}
@@ -63,6 +64,7 @@ func ImageRegistry() string {
 		defaultRegistry = defaultRegistryURL
 	}
 	return qaengine.FetchSelectAnswer(
+		ctx,
 		common.ConfigImageRegistryURLKey,
 		"Enter the URL of the image registry where the new images should be pushed : ",
 		[]string{"You can always change it later by changing the yamls."},
@@ -73,19 +75,19 @@ func ImageRegistry() string {
 }
 
 // ImageRegistryNamespace returns Image Registry Namespace
-func ImageRegistryNamespace() string {
-	return qaengine.FetchStringAnswer(common.ConfigImageRegistryNamespaceKey, "Enter the namespace where the new images should be pushed : ", []string{"Ex : " + common.ProjectName}, common.ProjectName, nil)
+func ImageRegistryNamespace(ctx context.Context) string {
+	return qaengine.FetchStringAnswer(ctx, common.ConfigImageRegistryNamespaceKey, "Enter the namespace where the new images should be pushed : ", []string{"Ex : " + common.ProjectName}, common.ProjectName, nil)
 }
 
 // IngressHost returns Ingress host
-func IngressHost(defaulthost string, clusterQaLabel string) string {
+func IngressHost(ctx context.Context, defaulthost string, clusterQaLabel string) string {
 	key := common.JoinQASubKeys(common.ConfigTargetKey, `"`+clusterQaLabel+`"`, common.ConfigIngressHostKeySuffix)
-	return qaengine.FetchStringAnswer(key, "Provide the ingress host domain", []string{"Ingress host domain is part of service URL"}, defaulthost, nil)
+	return qaengine.FetchStringAnswer(ctx, key, "Provide the ingress host domain", []string{"Ingress host domain is part of service URL"}, defaulthost, nil)
 }
 
 // MinimumReplicaCount returns minimum replica count
-func MinimumReplicaCount(defaultminreplicas string) string {
-	return qaengine.FetchStringAnswer(common.ConfigMinReplicasKey, "Provide the minimum number of replicas each service should have", []string{"If the value is 0 pods won't be started by default"}, defaultminreplicas, func(replicaCount interface{}) error {
+func MinimumReplicaCount(ctx context.Context, defaultminreplicas string) string {
+	return qaengine.FetchStringAnswer(ctx, common.ConfigMinReplicasKey, "Provide the minimum number of replicas each service should have", []string{"If the value is 0 pods won't be started by default"}, defaultminreplicas, func(replicaCount interface{}) error {
 		replicaCountI, err := cast.ToIntE(replicaCount)
 		if err != nil {
 			return err
@@ -98,7 +100,7 @@ func MinimumReplicaCount(defaultminreplicas string) string {
 }
 
 // GetPortsForService returns ports used by a service
-func GetPortsForService(detectedPorts []int32, qaSubKey string) []int32 {
+func GetPortsForService(ctx context.Context, detectedPorts []int32, qaSubKey string) []int32 {
 	var selectedPortsStr, detectedPortsStr []string
 	var exposePorts []int32
 	if len(detectedPorts) != 0 {
@@ -109,7 +111,7 @@ func GetPortsForService(detectedPorts []int32, qaSubKey string) []int32 {
 		quesKey := common.JoinQASubKeys(common.ConfigServicesKey, qaSubKey, common.ConfigPortsForServiceKeySegment)
 		desc := fmt.Sprintf("Select ports to be exposed for the service '%s' :", qaSubKey)
 		hints := []string{"Select 'Other' if you want to add more ports"}
-		selectedPortsStr = qaengine.FetchMultiSelectAnswer(quesKey, desc, hints, detectedPortsStr, allDetectedPortsStr, nil)
+		selectedPortsStr = qaengine.FetchMultiSelectAnswer(ctx, quesKey, desc, hints, detectedPortsStr, allDetectedPortsStr, nil)
 	}
 	for _, portStr := range selectedPortsStr {
 		portStr = strings.TrimSpace(portStr)
@@ -126,7 +128,7 @@ func GetPortsForService(detectedPorts []int32, qaSubKey string) []int32 {
 }
 
 // GetPortForService returns the port to expose the service on.
-func GetPortForService(detectedPorts []int32, qaSubKey string) int32 {
+func GetPortForService(ctx context.Context, detectedPorts []int32, qaSubKey string) int32 {
 	quesKey := common.JoinQASubKeys(common.ConfigServicesKey, qaSubKey, common.ConfigPortForServiceKeySegment)
 	desc := fmt.Sprintf("Select the port to be exposed for the '%s' service :", qaSubKey)
 	hints := []string{"Select 'Other' if you want to expose the service using a different port."}
@@ -138,7 +140,7 @@ func GetPortForService(detectedPorts []int32, qaSubKey string) int32 {
 		detectedPortStrs = append(detectedPortStrs, cast.ToString(common.DefaultServicePort))
 	}
 	detectedPortStrs = append(detectedPortStrs, qatypes.OtherAnswer)
-	selectedPortStr := qaengine.FetchSelectAnswer(quesKey, desc, hints, detectedPortStrs[0], detectedPortStrs, nil)
+	selectedPortStr := qaengine.FetchSelectAnswer(ctx, quesKey, desc, hints, detectedPortStrs[0], detectedPortStrs, nil)
 	selectedPortStr = strings.TrimSpace(selectedPortStr)
 	selectedPort, err := strconv.ParseInt(selectedPortStr, 10, 32)
 	if err != nil {
@@ -153,7 +155,7 @@ func GetPortForService(detectedPorts []int32, qaSubKey string) int32 {
 }
 
 // IsStateful returns whether the Service should generate a StatefulSet
-func IsStateful(serviceName string) bool {
+func IsStateful(ctx context.Context, serviceName string) bool {
 	quesKey := common.JoinQASubKeys(common.ConfigServicesKey, serviceName, common.ConfigStatefulSetKey)
-	return qaengine.FetchBoolAnswer(quesKey, fmt.Sprintf("For the service '%s', do you require a StatefulSet instead of a Deployment?", serviceName), nil, false, nil)
+	return qaengine.FetchBoolAnswer(ctx, quesKey, fmt.Sprintf("For the service '%s', do you require a StatefulSet instead of a Deployment?", serviceName), nil, false, nil)
 }
@@ -0,0 +1,167 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package report
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	// ErrorReportFileVersion is the version of the error report file that is generated.
+	ErrorReportFileVersion = "1.0.0"
+	// ErrorReportFileName is the default file name used to save the error report.
+	ErrorReportFileName = "m2k-errors.json"
+)
+
+// ErrorKind is the kind of failure that happened during a run
+type ErrorKind string
+
+const (
+	// InitFailure is a transformer that could not be created or initialized
+	InitFailure ErrorKind = "InitFailure"
+	// DetectFailure is a transformer that failed to look for services in a directory
+	DetectFailure ErrorKind = "DetectFailure"
+	// TransformFailure is a transformer that failed to transform the artifacts it consumed
+	TransformFailure ErrorKind = "TransformFailure"
+	// PathMappingFailure is a path mapping that could not be written to the output directory
+	PathMappingFailure ErrorKind = "PathMappingFailure"
+	// QAValidationFailure is an answer to a question that failed validation
+	QAValidationFailure ErrorKind = "QAValidationFailure"
+	// FatalFailure is an error that aborted the run
+	FatalFailure ErrorKind = "Fatal"
+)
+
+const (
+	// ExitCodeSuccess is used when the run completed without errors
+	ExitCodeSuccess = 0
+	// ExitCodeFatal is used when the run was aborted
+	ExitCodeFatal = 1
+	// ExitCodePathMappingFailure is used when the run completed, but some path mappings failed
+	ExitCodePathMappingFailure = 10
+	// ExitCodeTransformFailure is used when the run completed, but some transformers failed to transform
+	ExitCodeTransformFailure = 11
+	// ExitCodeInitFailure is used when the run completed, but some transformers failed to initialize
+	ExitCodeInitFailure = 12
+	// ExitCodeDetectFailure is used when the run completed, but some transformers failed to detect services
+	ExitCodeDetectFailure = 13
+	// ExitCodeQAValidationFailure is used when the run completed, but some answers failed validation
+	ExitCodeQAValidationFailure = 14
+)
+
+// exitCodes are the exit codes of the error kinds, from the most severe to the least severe
+var exitCodes = []struct {
+	kind ErrorKind
+	code int
+}{
+	{kind: FatalFailure, code: ExitCodeFatal},
+	{kind: PathMappingFailure, code: ExitCodePathMappingFailure},
+	{kind: TransformFailure, code: ExitCodeTransformFailure},
+	{kind: InitFailure, code: ExitCodeInitFailure},
+	{kind: DetectFailure, code: ExitCodeDetectFailure},
+	{kind: QAValidationFailure, code: ExitCodeQAValidationFailure},
+}
+
+// RunError is a single failure that happened during a run
+type RunError struct {
+	Kind        ErrorKind `json:"kind"`
+	Message     string    `json:"message"`
+	Transformer string    `json:"transformer,omitempty"`
+	Services    []string  `json:"services,omitempty"`
+	// Path is the directory being planned for detect failures and the destination path for path mapping failures
+	Path       string    `json:"path,omitempty"`
+	QuestionID string    `json:"questionId,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+// ErrorReport contains all the failures of a run
+type ErrorReport struct {
+	Version  string     `json:"version"`
+	Command  string     `json:"command"`
+	ExitCode int        `json:"exitCode"`
+	Errors   []RunError `json:"errors"`
+}
+
+var (
+	runErrors      = []RunError{}
+	runErrorsMutex = sync.Mutex{}
+)
+
+// AddError records a failure of the current run. Failures that were already recorded, like the path mappings
+// that are processed again in every iteration, are ignored.
+func AddError(kind ErrorKind, err error, runError RunError) {
+	runError.Kind = kind
+	if err != nil {
+		runError.Message = err.Error()
+	}
+	if runError.Time.IsZero() {
+		runError.Time = time.Now()
+	}
+	runErrorsMutex.Lock()
+	defer runErrorsMutex.Unlock()
+	for _, recorded := range runErrors {
+		if recorded.isSameAs(runError) {
+			return
+		}
+	}
+	runErrors = append(runErrors, runError)
+}
+
+func (e RunError) isSameAs(other RunError) bool {
+	return e.Kind == other.Kind && e.Message == other.Message && e.Transformer == other.Transformer &&
+		e.Path == other.Path && e.QuestionID == other.QuestionID && reflect.DeepEqual(e.Services, other.Services)
+}
+
+// ResetErrors clears the failures recorded so far
+func ResetErrors() {
+	runErrorsMutex.Lock()
+	defer runErrorsMutex.Unlock()
+	runErrors = []RunError{}
+}
+
+// GetErrors returns the failures recorded so far
+func GetErrors() []RunError {
+	runErrorsMutex.Lock()
+	defer runErrorsMutex.Unlock()
+	return append([]RunError{}, runErrors...)
+}
+
+// NewErrorReport creates an error report containing the failures recorded so far
+func NewErrorReport(command string) ErrorReport {
+	errs := GetErrors()
+	return ErrorReport{
+		Version:  ErrorReportFileVersion,
+		Command:  command,
+		ExitCode: GetExitCode(errs),
+		Errors:   errs,
+	}
+}
+
+// GetExitCode returns the exit code of the most severe failure
+func GetExitCode(errs []RunError) int {
+	kinds := map[ErrorKind]bool{}
+	for _, runError := range errs {
+		kinds[runError.Kind] = true
+	}
+	for _, exitCode := range exitCodes {
+		if kinds[exitCode.kind] {
+			return exitCode.code
+		}
+	}
+	return ExitCodeSuccess
+}
@@ -92,6 +92,7 @@ type PropertyGroup struct {
 	Condition              string      `xml:"Condition,attr"`
 	TargetFramework        string      `xml:"TargetFramework"`
 	TargetFrameworkVersion string      `xml:"TargetFrameworkVersion"`
+	AssemblyName           string      `xml:"AssemblyName"`
 	OutputPath             string      `xml:"OutputPath"`
 	Properties             *Properties `xml:"properties,omitempty"`
 }
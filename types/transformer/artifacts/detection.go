@@ -0,0 +1,39 @@
+/*
+ *  Copyright IBM Corporation 2023
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package artifacts
+
+import (
+	transformertypes "github.com/konveyor/move2kube-wasm/types/transformer"
+)
+
+const (
+	// DetectionConfigType stores the confidence with which a service was detected by DirectoryDetect
+	DetectionConfigType transformertypes.ConfigType = "Detection"
+	// DefaultDetectionConfidence is the confidence assumed when a transformer does not report one
+	DefaultDetectionConfidence = 0.5
+	// HighDetectionConfidence is used when the service was detected using a build manifest that names it
+	HighDetectionConfidence = 0.9
+	// LowDetectionConfidence is used when the service was detected using pre-built binaries or heuristics
+	LowDetectionConfidence = 0.3
+)
+
+// DetectionConfig stores the confidence score and the reason for a detected service.
+// The confidence is a number between 0 and 1, higher is better.
+type DetectionConfig struct {
+	Confidence float64 `yaml:"confidence" json:"confidence"`
+	Reason     string  `yaml:"reason,omitempty" json:"reason,omitempty"`
+}
@@ -28,5 +28,32 @@ const ServiceConfigType transformertypes.ConfigType = "Service"
 
 // ServiceConfig stores config related to service
 type ServiceConfig struct {
-	ServiceName string `yaml:"serviceName"`
+	ServiceName   string `yaml:"serviceName"`
+	ContainerName string `yaml:"containerName,omitempty"` // Set when the service was merged with other services into one pod
+}
+
+// GetContainerName returns the name of the container of the service in its pod
+func (c ServiceConfig) GetContainerName() string {
+	if c.ContainerName == "" {
+		return c.ServiceName
+	}
+	return c.ContainerName
+}
+
+// GetImageName returns the name of the container image of the service.
+// Services that were merged into one pod get an image for each container.
+func (c ServiceConfig) GetImageName() string {
+	if c.ContainerName == "" {
+		return c.ServiceName
+	}
+	return c.ServiceName + "-" + c.ContainerName
+}
+
+// GetChildServiceConfig returns the config of a service created for a child module or project of the service.
+// The children of a service that was merged with other services become containers in the same pod.
+func (c ServiceConfig) GetChildServiceConfig(childServiceName string) ServiceConfig {
+	if c.ContainerName == "" {
+		return ServiceConfig{ServiceName: childServiceName}
+	}
+	return ServiceConfig{ServiceName: c.ServiceName, ContainerName: childServiceName}
 }
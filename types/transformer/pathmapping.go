@@ -36,10 +36,31 @@ const (
 	SpecialTemplatePathMappingType PathMappingType = "SpecialTemplate" // Source path when relative, is relative to yaml file location
 )
 
+// ConflictPolicy refers to the way collisions between path mappings writing to the same destination are resolved
+type ConflictPolicy string
+
+const (
+	// ErrorConflictPolicy fails the transformation when a collision is found
+	ErrorConflictPolicy ConflictPolicy = "error"
+	// LastWinsConflictPolicy keeps the path mapping that was processed last
+	LastWinsConflictPolicy ConflictPolicy = "last-wins"
+	// FirstWinsConflictPolicy keeps the path mapping that was processed first
+	FirstWinsConflictPolicy ConflictPolicy = "first-wins"
+	// RenameConflictPolicy writes the later path mapping to a new destination with a numeric suffix
+	RenameConflictPolicy ConflictPolicy = "rename"
+	// MergeYamlConflictPolicy concatenates the yaml documents of all the colliding path mappings
+	MergeYamlConflictPolicy ConflictPolicy = "merge-yaml"
+)
+
+// ConflictPolicies contains all the supported conflict policies
+var ConflictPolicies = []ConflictPolicy{ErrorConflictPolicy, LastWinsConflictPolicy, FirstWinsConflictPolicy, RenameConflictPolicy, MergeYamlConflictPolicy}
+
 // PathMapping is the mapping between source and intermediate files and output files
 type PathMapping struct {
 	Type           PathMappingType `yaml:"type,omitempty" json:"type,omitempty"` // Default - Normal copy
 	SrcPath        string          `yaml:"sourcePath" json:"sourcePath" m2kpath:"normal"`
 	DestPath       string          `yaml:"destinationPath" json:"destinationPath" m2kpath:"normal"` // Relative to output directory
 	TemplateConfig interface{}     `yaml:"templateConfig" json:"templateConfig"`
+	// TransformerName is set by the engine to the name of the transformer that created the path mapping
+	TransformerName string `yaml:"transformerName,omitempty" json:"-"`
 }
@@ -17,6 +17,8 @@
 package transformer
 
 import (
+	"time"
+
 	"github.com/konveyor/move2kube-wasm/types"
 	"k8s.io/apimachinery/pkg/labels"
 )
@@ -47,6 +49,9 @@ type TransformerSpec struct {
 	TemplatesDir        string                                 `yaml:"templates" json:"templates"` // Relative to yaml directory or working directory in image
 	Config              interface{}                            `yaml:"config" json:"config"`
 	InvokedByDefault    InvokedByDefault                       `yaml:"invokedByDefault" json:"invokedByDefault"`
+	ConflictPolicy      ConflictPolicy                         `yaml:"conflictPolicy,omitempty" json:"conflictPolicy,omitempty"` // Overrides the global policy for path mappings created by this transformer
+	Timeout             string                                 `yaml:"timeout,omitempty" json:"timeout,omitempty"`               // time.Duration string like 10m, limits each call to the transformer
+	TimeoutDuration     time.Duration                          `yaml:"-" json:"-"`
 }
 
 // InvokedByDefault stores config to toggle transformers invoke by default